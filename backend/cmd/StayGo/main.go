@@ -14,10 +14,13 @@ import (
 	"backend/internal/config"
 	"backend/internal/handlers"
 	"backend/internal/logger"
+	"backend/internal/mailer"
 	"backend/internal/middleware"
 	"backend/internal/repos"
 	"backend/internal/services"
+	"backend/internal/storage"
 	"log"
+	"time"
     _ "backend/docs"
 
 	_"github.com/gin-gonic/gin"
@@ -47,34 +50,110 @@ func main() {
 	favoriteRoomRepo := repos.NewFavoriteRoomRepo(db)
 	roomRepo := repos.NewRoomRepo(db)
 	reviewRepo := repos.NewReviewRepo(db)
+	bookingRepo := repos.NewBookingRepo(db)
+	refreshTokenRepo := repos.NewRefreshTokenRepo(db)
+	sessionRepo := repos.NewSessionRepo(db)
+	amenityRepo := repos.NewAmenityRepo(db)
+	imageRepo := repos.NewImageRepo(db)
+	friendRepo := repos.NewFriendRepo(db)
+	recommendationRepo := repos.NewRecommendationRepo(db)
+	feedRepo := repos.NewFeedRepo(db)
+	networkRepo := repos.NewNetworkRepo(db)
+	identityRepo := repos.NewIdentityRepo(db)
+	accountRepo := repos.NewAccountRepo(db)
+	securityEventRepo := repos.NewSecurityEventRepo(db)
+
+	// Счетчики неудачных входов: в памяти — только для одной реплики
+	var loginAttemptRepo repos.LoginAttemptRepoInterface
+	switch cfg.Security.LoginStore {
+	case "", "memory":
+		loginAttemptRepo = repos.NewMemoryLoginAttemptRepo()
+	case "postgres":
+		loginAttemptRepo = repos.NewLoginAttemptRepo(db)
+	default:
+		log.Fatalf("unsupported login attempt store: %s", cfg.Security.LoginStore)
+	}
+
+	// Хранилище файлов
+	if cfg.Storage.Driver != "" && cfg.Storage.Driver != "local" {
+		log.Fatalf("unsupported storage driver: %s", cfg.Storage.Driver)
+	}
+	fileStorage, err := storage.NewLocalStorage(cfg.Storage.Dir, cfg.Storage.BaseURL)
+	if err != nil {
+		log.Fatalf("could not init storage: %v", err)
+	}
+	maxUploadBytes := int64(cfg.Storage.MaxUploadMB) << 20
+
+	// Исходящая почта
+	var mail mailer.Mailer
+	switch cfg.Mail.Driver {
+	case "", "log":
+		mail = mailer.NewLogMailer(cfg.Mail.From, logger.NewLogger())
+	case "file":
+		mail, err = mailer.NewFileMailer(cfg.Mail.Dir, cfg.Mail.From)
+		if err != nil {
+			log.Fatalf("could not init mailer: %v", err)
+		}
+	default:
+		log.Fatalf("unsupported mail driver: %s", cfg.Mail.Driver)
+	}
 
 	// Сервисы
 	jwtService := services.NewJWTService(*cfg)
-	authService := services.NewAuthService(cfg, authRepo, logger.NewLogger())
-	userService := services.NewUserInfoServ(userRepo)
+	loginGuardService := services.NewLoginGuardService(cfg.Security, loginAttemptRepo, securityEventRepo, logger.NewLogger())
+	authService := services.NewAuthService(cfg, authRepo, loginGuardService, logger.NewLogger())
+	sessionService := services.NewSessionService(sessionRepo, time.Duration(cfg.JWT.SessionCacheTTL)*time.Second)
+	tokenService := services.NewTokenService(jwtService, refreshTokenRepo, authRepo, sessionService, logger.NewLogger())
+	userService := services.NewUserInfoServ(userRepo, sessionService)
+	accountService := services.NewAccountService(cfg.Account, cfg.Mail.AppURL, accountRepo, authRepo, sessionService, mail, logger.NewLogger())
 	hotelService := services.NewHotelService(hotelRepo)
 	favoriteRoomService := services.NewFavoriteRoomService(favoriteRoomRepo)
-	roomService := services.NewRoomService(roomRepo)
+	roomService := services.NewRoomService(roomRepo, hotelRepo)
+	bookingService := services.NewBookingService(bookingRepo, roomRepo, hotelRepo)
+	reviewService := services.NewReviewService(reviewRepo, roomRepo, hotelRepo, accountService, time.Duration(cfg.Reviews.EditWindowHours)*time.Hour, cfg.Reviews.ReportThreshold)
+	amenityService := services.NewAmenityService(amenityRepo)
+	imageService := services.NewImageService(imageRepo, hotelRepo, roomRepo, fileStorage, maxUploadBytes, logger.NewLogger())
+	friendService := services.NewFriendService(friendRepo)
+	recommendationService := services.NewRecommendationService(recommendationRepo)
+	feedService := services.NewFeedService(feedRepo)
+	telegramService := services.NewTelegramService(cfg.Telegram, networkRepo, authRepo, logger.NewLogger())
+	oauthService := services.NewOAuthService(cfg.OAuth, identityRepo, authRepo, nil, logger.NewLogger())
 
 	// Middleware
-	authMiddleware := middleware.NewAuthMiddleware(jwtService)
+	authMiddleware := middleware.NewAuthMiddleware(jwtService, sessionService)
 
 	// Хендлеры
-	authHandler := handlers.NewAuthHandler(authService, &jwtService)
+	authHandler := handlers.NewAuthHandler(authService, tokenService, sessionService, accountService)
 	userHandler := handlers.NewUserHandler(userService)
 	hotelHandler := handlers.NewHotelHandler(hotelService)
 	favoriteRoomHandler := handlers.NewFavoriteRoomHandler(favoriteRoomService)
 	roomHandler := handlers.NewRoomHandler(roomService)
-	reviewHandler := handlers.NewReviewHandler(*reviewRepo)
+	reviewHandler := handlers.NewReviewHandler(reviewService)
+	bookingHandler := handlers.NewBookingHandler(bookingService)
+	partnerHandler := handlers.NewPartnerHandler(hotelService, roomService)
+	amenityHandler := handlers.NewAmenityHandler(amenityService)
+	imageHandler := handlers.NewImageHandler(imageService, maxUploadBytes)
+	friendHandler := handlers.NewFriendHandler(friendService)
+	recommendationHandler := handlers.NewRecommendationHandler(recommendationService)
+	feedHandler := handlers.NewFeedHandler(feedService)
+	telegramHandler := handlers.NewTelegramHandler(telegramService, tokenService)
+	oauthHandler := handlers.NewOAuthHandler(oauthService, tokenService)
+	securityHandler := handlers.NewSecurityHandler(loginGuardService)
 
 	// Инициализация API и маршрутов
-	apiHandlers := NewApi(*authHandler, userHandler, authMiddleware, hotelHandler, favoriteRoomHandler, roomHandler, reviewHandler)
-	r := apiHandlers.InitRoutes()
+	apiHandlers := NewApi(*authHandler, userHandler, authMiddleware, hotelHandler, favoriteRoomHandler, roomHandler, reviewHandler, bookingHandler, partnerHandler, amenityHandler, imageHandler, friendHandler, recommendationHandler, feedHandler, telegramHandler, oauthHandler, securityHandler)
+	r, err := apiHandlers.InitRoutes(cfg.Server.TrustedProxies)
+	if err != nil {
+		log.Fatalf("could not init routes: %v", err)
+	}
 
 	// Подключение Swagger UI
 	// Перейти по: http://localhost:8080/swagger/index.html
 	r.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerFiles.Handler))
 
+	// Загруженные фотографии (локальное хранилище)
+	r.Static(cfg.Storage.BaseURL, cfg.Storage.Dir)
+
 	// Запуск сервера
 	log.Println("Starting server on port 8080")
 	if err := r.Run(":8080"); err != nil {
@@ -13,22 +13,32 @@ package main
 import (
 	"backend/internal/handlers"
 	"backend/internal/middleware"
+	"backend/internal/models"
 
 	"github.com/gin-gonic/gin"
-
 	// Swagger UI (раскомментировать после `swag init` и установки зависимостей)
 	// swaggerFiles "github.com/swaggo/files"
 	// ginSwagger "github.com/swaggo/gin-swagger"
 )
 
 type Api struct {
-	authHandler         handlers.AuthHandler
-	userHandler         handlers.UserHandler
-	authMiddleware      middleware.AuthMiddleware
-	hotelHandler        handlers.HotelHandler
-	favoriteRoomHandler handlers.FavoriteRoomHandler
-	roomHandler         handlers.RoomHandler
-	reviewHandler       handlers.ReviewHandler
+	authHandler           handlers.AuthHandler
+	userHandler           handlers.UserHandler
+	authMiddleware        middleware.AuthMiddleware
+	hotelHandler          handlers.HotelHandler
+	favoriteRoomHandler   handlers.FavoriteRoomHandler
+	roomHandler           handlers.RoomHandler
+	reviewHandler         handlers.ReviewHandler
+	bookingHandler        handlers.BookingHandler
+	partnerHandler        handlers.PartnerHandler
+	amenityHandler        handlers.AmenityHandler
+	imageHandler          handlers.ImageHandler
+	friendHandler         handlers.FriendHandler
+	recommendationHandler handlers.RecommendationHandler
+	feedHandler           handlers.FeedHandler
+	telegramHandler       handlers.TelegramHandler
+	oauthHandler          handlers.OAuthHandler
+	securityHandler       handlers.SecurityHandler
 }
 
 func NewApi(
@@ -39,20 +49,45 @@ func NewApi(
 	favoriteRoomHandler handlers.FavoriteRoomHandler,
 	roomHandler handlers.RoomHandler,
 	reviewHandler handlers.ReviewHandler,
+	bookingHandler handlers.BookingHandler,
+	partnerHandler handlers.PartnerHandler,
+	amenityHandler handlers.AmenityHandler,
+	imageHandler handlers.ImageHandler,
+	friendHandler handlers.FriendHandler,
+	recommendationHandler handlers.RecommendationHandler,
+	feedHandler handlers.FeedHandler,
+	telegramHandler handlers.TelegramHandler,
+	oauthHandler handlers.OAuthHandler,
+	securityHandler handlers.SecurityHandler,
 ) Api {
 	return Api{
-		authHandler:         authHandler,
-		userHandler:         userHandler,
-		authMiddleware:      authMiddleware,
-		hotelHandler:        hotelHandler,
-		favoriteRoomHandler: favoriteRoomHandler,
-		roomHandler:         roomHandler,
-		reviewHandler:       reviewHandler,
+		authHandler:           authHandler,
+		userHandler:           userHandler,
+		authMiddleware:        authMiddleware,
+		hotelHandler:          hotelHandler,
+		favoriteRoomHandler:   favoriteRoomHandler,
+		roomHandler:           roomHandler,
+		reviewHandler:         reviewHandler,
+		bookingHandler:        bookingHandler,
+		partnerHandler:        partnerHandler,
+		amenityHandler:        amenityHandler,
+		imageHandler:          imageHandler,
+		friendHandler:         friendHandler,
+		recommendationHandler: recommendationHandler,
+		feedHandler:           feedHandler,
+		telegramHandler:       telegramHandler,
+		oauthHandler:          oauthHandler,
+		securityHandler:       securityHandler,
 	}
 }
 
-func (a Api) InitRoutes() *gin.Engine {
+func (a Api) InitRoutes(trustedProxies []string) (*gin.Engine, error) {
 	router := gin.New()
+	// По умолчанию gin доверяет X-Forwarded-For от любого клиента, и ClientIP можно подделать,
+	// обходя ограничение попыток входа по IP. Доверяем только явно указанным прокси.
+	if err := router.SetTrustedProxies(trustedProxies); err != nil {
+		return nil, err
+	}
 	// Swagger UI (после swag init)
 	// import "github.com/swaggo/files"
 	// import ginSwagger "github.com/swaggo/gin-swagger"
@@ -65,12 +100,13 @@ func (a Api) InitRoutes() *gin.Engine {
 	auth := router.Group("/auth")
 	{
 		// @Summary Регистрация пользователя
+		// @Description На указанный email уходит ссылка подтверждения. Пока адрес не подтвержден, нельзя оставлять отзывы.
 		// @Tags auth
 		// @Accept json
 		// @Produce json
 		// @Param input body models.CreateUserDTO true "Данные регистрации"
 		// @Success 201 {integer} int64 "ID созданного пользователя"
-		// @Failure 400 {object} map[string]string "Неверные данные запроса или пользователь уже существует"
+		// @Failure 400 {object} map[string]any "Неверные данные запроса (fields — ошибки по полям, erors.FieldError) или пользователь уже существует"
 		// @Failure 500 {object} map[string]string "Внутренняя ошибка сервера"
 		// @Router /auth/register [post]
 		auth.POST("/register", a.authHandler.Register)
@@ -83,18 +119,201 @@ func (a Api) InitRoutes() *gin.Engine {
 		// @Success 200 {object} models.AuthResponse "Пара токенов"
 		// @Failure 400 {object} map[string]string "Неверные данные запроса"
 		// @Failure 401 {object} map[string]string "Неверные учетные данные"
+		// @Failure 429 {object} map[string]any "Слишком много неудачных попыток: error и retry_after (секунд), заголовок Retry-After"
 		// @Failure 500 {object} map[string]string "Внутренняя ошибка сервера"
 		// @Router /auth/login [post]
 		auth.POST("/login", a.authHandler.Login)
+
+		// @Summary Обновить пару токенов
+		// @Tags auth
+		// @Accept json
+		// @Produce json
+		// @Param input body models.RefreshTokenDTO true "Refresh token"
+		// @Success 200 {object} models.AuthResponse "Новая пара токенов"
+		// @Failure 400 {object} map[string]string "Неверные данные запроса"
+		// @Failure 401 {object} map[string]string "Недействительный refresh token"
+		// @Failure 500 {object} map[string]string "Внутренняя ошибка сервера"
+		// @Router /auth/refresh [post]
+		auth.POST("/refresh", a.authHandler.Refresh)
+
+		// @Summary Выйти из текущей сессии
+		// @Tags auth
+		// @Security BearerAuth
+		// @Produce json
+		// @Success 204 "Сессия завершена"
+		// @Failure 401 {object} map[string]string "user authentication required"
+		// @Failure 500 {object} map[string]string "Внутренняя ошибка сервера"
+		// @Router /auth/logout [post]
+		auth.POST("/logout", a.authMiddleware.RequireAuth(), a.authHandler.Logout)
+
+		// @Summary Выйти со всех устройств
+		// @Tags auth
+		// @Security BearerAuth
+		// @Produce json
+		// @Success 204 "Все сессии завершены"
+		// @Failure 401 {object} map[string]string "user authentication required"
+		// @Failure 500 {object} map[string]string "Внутренняя ошибка сервера"
+		// @Router /auth/logout-all [post]
+		auth.POST("/logout-all", a.authMiddleware.RequireAuth(), a.authHandler.LogoutAll)
+
+		// @Summary Подтвердить email
+		// @Description Токен одноразовый и действует ограниченное время (account.verify_token_ttl).
+		// @Description После смены email ссылки, отправленные на прежний адрес, не действуют.
+		// @Tags auth
+		// @Accept json
+		// @Produce json
+		// @Param input body models.VerifyEmailDTO true "Токен из письма"
+		// @Success 204 "Email подтвержден"
+		// @Failure 400 {object} map[string]string "Неверные данные запроса | Ссылка недействительна или устарела"
+		// @Failure 500 {object} map[string]string "Внутренняя ошибка сервера"
+		// @Router /auth/verify-email [post]
+		auth.POST("/verify-email", a.authHandler.VerifyEmail)
+
+		// @Summary Отправить ссылку подтверждения повторно
+		// @Description Ссылка уходит на текущий email пользователя, ранее отправленные ссылки перестают действовать.
+		// @Tags auth
+		// @Security BearerAuth
+		// @Produce json
+		// @Success 202 "Письмо отправлено"
+		// @Failure 400 {object} map[string]string "Нет адреса для подтверждения"
+		// @Failure 401 {object} map[string]string "user authentication required"
+		// @Failure 409 {object} map[string]string "Email уже подтвержден"
+		// @Failure 500 {object} map[string]string "Внутренняя ошибка сервера"
+		// @Router /auth/verify-email/resend [post]
+		auth.POST("/verify-email/resend", a.authMiddleware.RequireAuth(), a.authHandler.ResendVerification)
+
+		// @Summary Забыли пароль
+		// @Description Если адрес зарегистрирован, на него уходит ссылка сброса пароля (account.reset_token_ttl).
+		// @Description Ответ не зависит от того, существует ли учетная запись.
+		// @Tags auth
+		// @Accept json
+		// @Produce json
+		// @Param input body models.ForgotPasswordDTO true "Email"
+		// @Success 202 "Запрос принят"
+		// @Failure 400 {object} map[string]string "Неверные данные запроса"
+		// @Failure 500 {object} map[string]string "Внутренняя ошибка сервера"
+		// @Router /auth/password/forgot [post]
+		auth.POST("/password/forgot", a.authHandler.ForgotPassword)
+
+		// @Summary Сбросить пароль
+		// @Description Токен одноразовый. После сброса все сессии пользователя завершаются, email считается подтвержденным.
+		// @Tags auth
+		// @Accept json
+		// @Produce json
+		// @Param input body models.ResetPasswordDTO true "Токен из письма и новый пароль"
+		// @Success 204 "Пароль изменен"
+		// @Failure 400 {object} map[string]any "Неверные данные запроса (fields — ошибки по полям) | Ссылка недействительна или устарела"
+		// @Failure 500 {object} map[string]string "Внутренняя ошибка сервера"
+		// @Router /auth/password/reset [post]
+		auth.POST("/password/reset", a.authHandler.ResetPassword)
+
+		// @Summary Вход через Telegram
+		// @Description Проверяет подпись данных виджета токеном бота и их свежесть (auth_date).
+		// @Description С заголовком Authorization привязывает Telegram к текущей учетной записи,
+		// @Description без него — входит в привязанную учетную запись или создает новую.
+		// @Tags auth
+		// @Accept json
+		// @Produce json
+		// @Param input body models.TelegramLoginDTO true "Данные Telegram Login Widget"
+		// @Success 200 {object} models.AuthResponse "Пара токенов"
+		// @Failure 400 {object} map[string]string "Неверные данные запроса"
+		// @Failure 401 {object} map[string]string "Недействительные данные Telegram | Данные Telegram устарели"
+		// @Failure 409 {object} map[string]string "Telegram привязан к другому пользователю"
+		// @Failure 500 {object} map[string]string "Внутренняя ошибка сервера"
+		// @Failure 503 {object} map[string]string "Вход через Telegram не настроен"
+		// @Router /auth/telegram [post]
+		auth.POST("/telegram", a.authMiddleware.OptionalAuth(), a.telegramHandler.Login)
+
+		// @Summary Параметры бота для входа через Telegram
+		// @Tags auth
+		// @Produce json
+		// @Success 200 {object} models.TelegramBotInfo
+		// @Router /auth/telegram/bot-info [get]
+		auth.GET("/telegram/bot-info", a.telegramHandler.BotInfo)
+
+		// @Summary Провайдеры OAuth-входа
+		// @Tags auth
+		// @Produce json
+		// @Success 200 {array} string
+		// @Router /auth/oauth/providers [get]
+		auth.GET("/oauth/providers", a.oauthHandler.Providers)
+
+		// @Summary Начать вход через OAuth-провайдера
+		// @Description Возвращает адрес авторизации с одноразовым state и PKCE code_challenge.
+		// @Description С заголовком Authorization аккаунт провайдера будет привязан к текущему пользователю.
+		// @Tags auth
+		// @Produce json
+		// @Param provider path string true "vk, yandex, google"
+		// @Success 200 {object} models.OAuthStartResponse
+		// @Failure 404 {object} map[string]string "Провайдер не настроен"
+		// @Failure 500 {object} map[string]string "Внутренняя ошибка сервера"
+		// @Router /auth/oauth/{provider}/start [get]
+		auth.GET("/oauth/:provider/start", a.authMiddleware.OptionalAuth(), a.oauthHandler.Start)
+
+		// @Summary Завершить вход через OAuth-провайдера
+		// @Description Обменивает code на токен провайдера, читает профиль и выдает пару токенов.
+		// @Description Аккаунт связывается с существующим пользователем по подтвержденному email.
+		// @Description Привязку, начатую с Authorization, нужно завершать с токеном того же пользователя.
+		// @Tags auth
+		// @Accept json
+		// @Produce json
+		// @Param provider path string true "vk, yandex, google"
+		// @Param input body models.OAuthCallbackDTO true "code и state из redirect_url"
+		// @Success 200 {object} models.AuthResponse "Пара токенов"
+		// @Failure 400 {object} map[string]string "Неверные данные запроса | Недействительный или просроченный state"
+		// @Failure 404 {object} map[string]string "Провайдер не настроен"
+		// @Failure 409 {object} map[string]string "Аккаунт провайдера привязан к другому пользователю | Email уже зарегистрирован, но не подтвержден: войдите по паролю или восстановите его"
+		// @Failure 502 {object} map[string]string "Ошибка обмена данными с провайдером"
+		// @Failure 500 {object} map[string]string "Внутренняя ошибка сервера"
+		// @Router /auth/oauth/{provider}/callback [post]
+		auth.POST("/oauth/:provider/callback", a.authMiddleware.OptionalAuth(), a.oauthHandler.Callback)
 	}
 
+	// Полнотекстовый поиск — публичный
+	// @Summary Полнотекстовый поиск отелей и комнат
+	// @Description Ищет по названию, адресу и описанию отеля и описаниям комнат с учетом русской морфологии.
+	// @Description Слова объединяются по И; поддерживаются "фразы", or и -исключение. Найденные слова в
+	// @Description name_highlight и snippet — HTML: текст экранирован, найденные слова обернуты в <mark></mark>.
+	// @Tags hotels
+	// @Produce json
+	// @Param q query string true "Поисковый запрос" example(у моря с бассейном)
+	// @Param page query int false "Номер страницы (с 1)" default(1)
+	// @Param limit query int false "Размер страницы (1-100)" default(20)
+	// @Success 200 {object} models.HotelSearchResponse
+	// @Failure 400 {object} map[string]string "invalid input"
+	// @Failure 500 {object} map[string]string "internal server error"
+	// @Router /search [get]
+	router.GET("/search", a.hotelHandler.Search)
+
+	// Справочник удобств — публичный
+	// @Summary Справочник удобств
+	// @Tags amenities
+	// @Produce json
+	// @Param scope query string false "hotel | room — только применимые (включая both)"
+	// @Success 200 {array} models.Amenity
+	// @Failure 400 {object} map[string]string "invalid input"
+	// @Failure 500 {object} map[string]string "internal server error"
+	// @Router /amenities [get]
+	router.GET("/amenities", a.amenityHandler.List)
+
 	// Публичные данные отелей (GET): список, деталь, список комнат отеля
 	hotels := router.Group("/hotels")
 	{
-		// @Summary Список отелей
+		// @Summary Получить список отелей
 		// @Tags hotels
 		// @Produce json
-		// @Success 200 {array} models.Hotel
+		// @Param page query int false "Номер страницы (с 1)" default(1)
+		// @Param limit query int false "Размер страницы (1-100)" default(20)
+		// @Param city query string false "Город"
+		// @Param minStars query int false "Минимум звёзд"
+		// @Param minPrice query int false "Минимальная цена за ночь"
+		// @Param maxPrice query int false "Максимальная цена за ночь"
+		// @Param minRating query number false "Минимальный рейтинг"
+		// @Param sortBy query string false "Сортировка: price | rating | stars"
+		// @Param sortOrder query string false "Направление: asc | desc" default(asc)
+		// @Param amenities query string false "Коды удобств через запятую; нужны все (wifi,pool)"
+		// @Success 200 {object} models.HotelListResponse
+		// @Failure 400 {object} map[string]string "invalid input"
 		// @Failure 500 {object} map[string]string "Failed to get hotels"
 		// @Router /hotels [get]
 		hotels.GET("", a.hotelHandler.List)
@@ -109,6 +328,35 @@ func (a Api) InitRoutes() *gin.Engine {
 		// @Router /hotels/search [get]
 		hotels.GET("/search", a.hotelHandler.ListByCity)
 
+		// @Summary Отели рядом с точкой
+		// @Description Расстояние считается по формуле гаверсинусов; результат отсортирован по удаленности
+		// @Tags hotels
+		// @Produce json
+		// @Param lat query number true "Широта центра"
+		// @Param lng query number true "Долгота центра"
+		// @Param radius_km query number false "Радиус в км (до 500)" default(10)
+		// @Param limit query int false "Максимум отелей (до 500)" default(50)
+		// @Success 200 {array} models.Hotel
+		// @Failure 400 {object} map[string]string "invalid lat | invalid lng | invalid radius_km | invalid limit | invalid input"
+		// @Failure 500 {object} map[string]string "internal server error"
+		// @Router /hotels/nearby [get]
+		hotels.GET("/nearby", a.hotelHandler.Nearby)
+
+		// @Summary Отели в области карты
+		// @Description Если min_lng больше max_lng, область пересекает 180-й меридиан
+		// @Tags hotels
+		// @Produce json
+		// @Param min_lat query number true "Южная граница"
+		// @Param min_lng query number true "Западная граница"
+		// @Param max_lat query number true "Северная граница"
+		// @Param max_lng query number true "Восточная граница"
+		// @Param limit query int false "Максимум отелей (до 500)" default(50)
+		// @Success 200 {array} models.Hotel
+		// @Failure 400 {object} map[string]string "invalid min_lat | invalid min_lng | invalid max_lat | invalid max_lng | invalid limit | invalid input"
+		// @Failure 500 {object} map[string]string "internal server error"
+		// @Router /hotels/bbox [get]
+		hotels.GET("/bbox", a.hotelHandler.InBoundingBox)
+
 		// @Summary Получить отель по ID
 		// @Tags hotels
 		// @Produce json
@@ -128,6 +376,29 @@ func (a Api) InitRoutes() *gin.Engine {
 		// @Failure 500 {object} map[string]string "failed to get rooms"
 		// @Router /hotels/{hotelid}/rooms [get]
 		hotels.GET("/:hotelid/rooms", a.roomHandler.ListByHotel)
+
+		// @Summary Фотографии отеля
+		// @Tags hotels
+		// @Produce json
+		// @Param hotelid path int true "ID отеля"
+		// @Success 200 {array} models.Image
+		// @Failure 400 {object} map[string]string "invalid hotelid"
+		// @Failure 404 {object} map[string]string "not found"
+		// @Failure 500 {object} map[string]string "internal server error"
+		// @Router /hotels/{hotelid}/images [get]
+		hotels.GET("/:hotelid/images", a.imageHandler.ListHotelImages)
+
+		// @Summary Сводка оценок отеля
+		// @Description Средние оценки отеля и его комнат, число одобренных отзывов и распределение оценок отеля 1..5.
+		// @Tags hotels
+		// @Produce json
+		// @Param hotelid path int true "ID отеля"
+		// @Success 200 {object} models.RatingSummary
+		// @Failure 400 {object} map[string]string "invalid hotelid"
+		// @Failure 404 {object} map[string]string "hotel not found"
+		// @Failure 500 {object} map[string]string "internal server error"
+		// @Router /hotels/{hotelid}/rating-summary [get]
+		hotels.GET("/:hotelid/rating-summary", a.hotelHandler.RatingSummary)
 	}
 
 	// Публичные данные по комнатам (GET деталь)
@@ -150,21 +421,36 @@ func (a Api) InitRoutes() *gin.Engine {
 		// @Param guests query int true "Количество гостей"
 		// @Param checkin query string false "Дата заезда (YYYY-MM-DD)"
 		// @Param checkout query string false "Дата выезда (YYYY-MM-DD)"
+		// @Param amenities query string false "Коды удобств через запятую; учитываются удобства комнаты и отеля, нужны все"
 		// @Success 200 {array} models.Room
 		// @Failure 400 {object} map[string]string "city and guests are required | invalid guests | invalid input"
 		// @Failure 500 {object} map[string]string "internal error"
 		// @Router /rooms/search [get]
 		rooms.GET("/search", a.roomHandler.Search)
 
-		// @Summary Отзывы по комнате
+		// @Summary Получить список отзывов по ID комнаты
+		// @Description Только опубликованные отзывы, вместе с ответом отеля. Авторизованный пользователь дополнительно видит свои отзывы на модерации.
 		// @Tags reviews
 		// @Produce json
 		// @Param roomid path int true "ID комнаты"
+		// @Param sort query string false "Сортировка: newest, helpful или rating; по умолчанию — в порядке создания"
 		// @Success 200 {array} models.Review
 		// @Failure 400 {object} map[string]string "invalid room id | invalid input"
+		// @Failure 401 {object} map[string]string "Invalid token"
 		// @Failure 500 {object} map[string]string "internal server error"
 		// @Router /rooms/{roomid}/reviews [get]
-		rooms.GET("/:roomid/reviews", a.reviewHandler.ListByRoomID)
+		rooms.GET("/:roomid/reviews", a.authMiddleware.OptionalAuth(), a.reviewHandler.ListByRoomID)
+
+		// @Summary Фотографии комнаты
+		// @Tags rooms
+		// @Produce json
+		// @Param roomid path int true "ID комнаты"
+		// @Success 200 {array} models.Image
+		// @Failure 400 {object} map[string]string "invalid roomid"
+		// @Failure 404 {object} map[string]string "not found"
+		// @Failure 500 {object} map[string]string "internal server error"
+		// @Router /rooms/{roomid}/images [get]
+		rooms.GET("/:roomid/images", a.imageHandler.ListRoomImages)
 	}
 
 	// Защищённые группы
@@ -188,15 +474,195 @@ func (a Api) InitRoutes() *gin.Engine {
 		// @Produce json
 		// @Param input body models.UserUpdateDTO true "Изменяемые поля профиля"
 		// @Success 204 "Обновлено"
-		// @Failure 400 {object} map[string]string "invalid body | no fields to update | invalid input | email already taken"
+		// @Failure 400 {object} map[string]any "invalid body | no fields to update | email already taken | Неверные данные запроса (fields — ошибки по полям)"
 		// @Failure 401 {object} map[string]string "user authentication required"
 		// @Failure 404 {object} map[string]string "user not found"
 		// @Failure 500 {object} map[string]string "internal server error"
 		// @Router /users/me [patch]
 		users.PATCH("/me", a.userHandler.UpdateUserInfo)
+
+		// @Summary Настройки приватности
+		// @Tags users
+		// @Security BearerAuth
+		// @Produce json
+		// @Success 200 {object} models.PrivacySettings
+		// @Failure 401 {object} map[string]string "user authentication required"
+		// @Failure 404 {object} map[string]string "user not found"
+		// @Failure 500 {object} map[string]string "internal server error"
+		// @Router /users/me/privacy [get]
+		users.GET("/me/privacy", a.userHandler.GetPrivacy)
+
+		// @Summary Обновить настройки приватности
+		// @Description Определяет, какие действия видят друзья в ленте и рекомендациях
+		// @Tags users
+		// @Security BearerAuth
+		// @Accept json
+		// @Produce json
+		// @Param input body models.UpdatePrivacyDTO true "Изменяемые настройки"
+		// @Success 200 {object} models.PrivacySettings
+		// @Failure 400 {object} map[string]string "invalid body | invalid input"
+		// @Failure 401 {object} map[string]string "user authentication required"
+		// @Failure 404 {object} map[string]string "user not found"
+		// @Failure 500 {object} map[string]string "internal server error"
+		// @Router /users/me/privacy [patch]
+		users.PATCH("/me/privacy", a.userHandler.UpdatePrivacy)
+
+		// @Summary Привязанные аккаунты провайдеров
+		// @Tags users
+		// @Security BearerAuth
+		// @Produce json
+		// @Success 200 {array} models.UserIdentity
+		// @Failure 401 {object} map[string]string "user authentication required"
+		// @Failure 500 {object} map[string]string "internal server error"
+		// @Router /users/me/identities [get]
+		users.GET("/me/identities", a.oauthHandler.ListIdentities)
+
+		// Друзья и блокировки
+		// @Summary Отправить заявку в друзья
+		// @Description Если пользователь уже прислал встречную заявку, она принимается
+		// @Tags friends
+		// @Security BearerAuth
+		// @Accept json
+		// @Produce json
+		// @Param input body models.FriendRequestDTO true "Email или ID пользователя"
+		// @Success 201 {object} models.Friend
+		// @Failure 400 {object} map[string]string "invalid body | invalid input"
+		// @Failure 401 {object} map[string]string "user authentication required"
+		// @Failure 403 {object} map[string]string "access denied"
+		// @Failure 404 {object} map[string]string "user not found"
+		// @Failure 409 {object} map[string]string "already friends or request pending"
+		// @Failure 500 {object} map[string]string "internal server error"
+		// @Router /users/friends [post]
+		users.POST("/friends", a.friendHandler.SendRequest)
+
+		// @Summary Список друзей
+		// @Tags friends
+		// @Security BearerAuth
+		// @Produce json
+		// @Success 200 {array} models.Friend
+		// @Failure 401 {object} map[string]string "user authentication required"
+		// @Failure 500 {object} map[string]string "internal server error"
+		// @Router /users/friends [get]
+		users.GET("/friends", a.friendHandler.List)
+
+		// @Summary Входящие заявки в друзья
+		// @Tags friends
+		// @Security BearerAuth
+		// @Produce json
+		// @Success 200 {array} models.Friend
+		// @Failure 401 {object} map[string]string "user authentication required"
+		// @Failure 500 {object} map[string]string "internal server error"
+		// @Router /users/friends/requests/incoming [get]
+		users.GET("/friends/requests/incoming", a.friendHandler.ListIncoming)
+
+		// @Summary Исходящие заявки в друзья
+		// @Tags friends
+		// @Security BearerAuth
+		// @Produce json
+		// @Success 200 {array} models.Friend
+		// @Failure 401 {object} map[string]string "user authentication required"
+		// @Failure 500 {object} map[string]string "internal server error"
+		// @Router /users/friends/requests/outgoing [get]
+		users.GET("/friends/requests/outgoing", a.friendHandler.ListOutgoing)
+
+		// @Summary Принять заявку в друзья
+		// @Tags friends
+		// @Security BearerAuth
+		// @Produce json
+		// @Param userid path int true "ID отправителя заявки"
+		// @Success 200 {object} models.Friend
+		// @Failure 400 {object} map[string]string "invalid userid | invalid input"
+		// @Failure 401 {object} map[string]string "user authentication required"
+		// @Failure 404 {object} map[string]string "not found"
+		// @Failure 500 {object} map[string]string "internal server error"
+		// @Router /users/friends/requests/{userid}/accept [post]
+		users.POST("/friends/requests/:userid/accept", a.friendHandler.Accept)
+
+		// @Summary Отклонить заявку в друзья
+		// @Tags friends
+		// @Security BearerAuth
+		// @Produce json
+		// @Param userid path int true "ID отправителя заявки"
+		// @Success 204 "Отклонено"
+		// @Failure 400 {object} map[string]string "invalid userid | invalid input"
+		// @Failure 401 {object} map[string]string "user authentication required"
+		// @Failure 404 {object} map[string]string "not found"
+		// @Failure 500 {object} map[string]string "internal server error"
+		// @Router /users/friends/requests/{userid}/decline [post]
+		users.POST("/friends/requests/:userid/decline", a.friendHandler.Decline)
+
+		// @Summary Удалить из друзей
+		// @Description Удаляет дружбу или заявку в любом направлении
+		// @Tags friends
+		// @Security BearerAuth
+		// @Produce json
+		// @Param userid path int true "ID пользователя"
+		// @Success 204 "Удалено"
+		// @Failure 400 {object} map[string]string "invalid userid | invalid input"
+		// @Failure 401 {object} map[string]string "user authentication required"
+		// @Failure 404 {object} map[string]string "not found"
+		// @Failure 500 {object} map[string]string "internal server error"
+		// @Router /users/friends/{userid} [delete]
+		users.DELETE("/friends/:userid", a.friendHandler.Remove)
+
+		// @Summary Заблокированные пользователи
+		// @Tags friends
+		// @Security BearerAuth
+		// @Produce json
+		// @Success 200 {array} models.Friend
+		// @Failure 401 {object} map[string]string "user authentication required"
+		// @Failure 500 {object} map[string]string "internal server error"
+		// @Router /users/blocks [get]
+		users.GET("/blocks", a.friendHandler.ListBlocked)
+
+		// @Summary Заблокировать пользователя
+		// @Description Разрывает дружбу и удаляет заявки; заблокированный не сможет отправить заявку
+		// @Tags friends
+		// @Security BearerAuth
+		// @Produce json
+		// @Param userid path int true "ID пользователя"
+		// @Success 204 "Заблокирован"
+		// @Failure 400 {object} map[string]string "invalid userid | invalid input"
+		// @Failure 401 {object} map[string]string "user authentication required"
+		// @Failure 404 {object} map[string]string "user not found"
+		// @Failure 500 {object} map[string]string "internal server error"
+		// @Router /users/blocks/{userid} [post]
+		users.POST("/blocks/:userid", a.friendHandler.Block)
+
+		// @Summary Разблокировать пользователя
+		// @Tags friends
+		// @Security BearerAuth
+		// @Produce json
+		// @Param userid path int true "ID пользователя"
+		// @Success 204 "Разблокирован"
+		// @Failure 400 {object} map[string]string "invalid userid | invalid input"
+		// @Failure 401 {object} map[string]string "user authentication required"
+		// @Failure 404 {object} map[string]string "not found"
+		// @Failure 500 {object} map[string]string "internal server error"
+		// @Router /users/blocks/{userid} [delete]
+		users.DELETE("/blocks/:userid", a.friendHandler.Unblock)
+
+		// @Summary Рекомендации от друзей
+		// @Description Комнаты и отели, которые друзья добавляли в избранное, где жили или которые высоко оценили.
+		// @Description Учитываются близость друга (общие друзья) и давность действия; уже знакомые пользователю объекты исключаются.
+		// @Tags users
+		// @Security BearerAuth
+		// @Produce json
+		// @Param type query string false "room, hotel или пусто — оба вида"
+		// @Param limit query int false "Количество (по умолчанию 10, до 50)"
+		// @Success 200 {array} models.Recommendation
+		// @Failure 400 {object} map[string]string "invalid input"
+		// @Failure 401 {object} map[string]string "user authentication required"
+		// @Failure 500 {object} map[string]string "internal server error"
+		// @Router /users/recommend [get]
+		users.GET("/recommend", a.recommendationHandler.Recommend)
 	}
 
-	admin := router.Group("/admin", a.authMiddleware.RequireAuth())
+	// Вход в админку — только для персонала; конкретные действия дополнительно проверяются по правам
+	admin := router.Group("/admin",
+		a.authMiddleware.RequireAuth(),
+		a.authMiddleware.RequireRole(models.RoleAdmin, models.RoleModerator),
+	)
 	{
 		// @Summary Создать отель
 		// @Tags admin
@@ -205,12 +671,62 @@ func (a Api) InitRoutes() *gin.Engine {
 		// @Produce json
 		// @Param input body models.Hotel true "Данные отеля"
 		// @Success 201 {object} models.Hotel
-		// @Failure 400 {object} map[string]string "Неверные данные запроса"
+		// @Failure 400 {object} map[string]string "Неверные данные запроса | invalid input"
 		// @Failure 401 {object} map[string]string "unauthorized"
 		// @Failure 403 {object} map[string]string "Access denied"
 		// @Failure 500 {object} map[string]string "failed to create hotel"
 		// @Router /admin/hotels [post]
-		admin.POST("/hotels", a.hotelHandler.Create)
+		admin.POST("/hotels", a.authMiddleware.RequirePermission(models.PermManageHotels), a.hotelHandler.Create)
+
+		// @Summary Полностью обновить отель
+		// @Description Перезаписывает все редактируемые поля: не переданные city, description, address очищаются, без latitude/longitude координаты удаляются.
+		// @Tags admin
+		// @Security BearerAuth
+		// @Accept json
+		// @Produce json
+		// @Param id path int true "ID отеля"
+		// @Param input body models.Hotel true "Новые данные отеля"
+		// @Success 200 {object} models.Hotel
+		// @Failure 400 {object} map[string]string "invalid hotel id | invalid body | invalid input"
+		// @Failure 401 {object} map[string]string "unauthorized"
+		// @Failure 403 {object} map[string]string "access denied"
+		// @Failure 404 {object} map[string]string "hotel not found"
+		// @Failure 500 {object} map[string]string "internal server error"
+		// @Router /admin/hotels/{id} [put]
+		admin.PUT("/hotels/:id", a.authMiddleware.RequirePermission(models.PermManageHotels), a.hotelHandler.Replace)
+
+		// @Summary Частично обновить отель
+		// @Tags admin
+		// @Security BearerAuth
+		// @Accept json
+		// @Produce json
+		// @Param id path int true "ID отеля"
+		// @Param input body models.UpdateHotelDTO true "Изменяемые поля"
+		// @Success 200 {object} models.Hotel
+		// @Failure 400 {object} map[string]string "invalid hotel id | invalid body | invalid input"
+		// @Failure 401 {object} map[string]string "unauthorized"
+		// @Failure 403 {object} map[string]string "access denied"
+		// @Failure 404 {object} map[string]string "hotel not found"
+		// @Failure 500 {object} map[string]string "internal server error"
+		// @Router /admin/hotels/{id} [patch]
+		admin.PATCH("/hotels/:id", a.authMiddleware.RequirePermission(models.PermManageHotels), a.hotelHandler.Update)
+
+		// @Summary Удалить отель
+		// @Description По умолчанию удаление мягкое: отель и его комнаты скрываются, история отзывов, избранного и броней сохраняется.
+		// @Description hard=true удаляет отель физически вместе со всеми связанными данными.
+		// @Tags admin
+		// @Security BearerAuth
+		// @Produce json
+		// @Param id path int true "ID отеля"
+		// @Param hard query bool false "Удалить физически"
+		// @Success 204 "Удалено"
+		// @Failure 400 {object} map[string]string "invalid hotel id | invalid input"
+		// @Failure 401 {object} map[string]string "unauthorized"
+		// @Failure 403 {object} map[string]string "access denied"
+		// @Failure 404 {object} map[string]string "hotel not found"
+		// @Failure 500 {object} map[string]string "internal server error"
+		// @Router /admin/hotels/{id} [delete]
+		admin.DELETE("/hotels/:id", a.authMiddleware.RequirePermission(models.PermManageHotels), a.hotelHandler.Delete)
 
 		// @Summary Создать комнату
 		// @Tags admin
@@ -224,52 +740,517 @@ func (a Api) InitRoutes() *gin.Engine {
 		// @Failure 403 {object} map[string]string "access denied"
 		// @Failure 500 {object} map[string]string "internal error"
 		// @Router /admin/rooms [post]
-		admin.POST("/rooms", a.roomHandler.Create)
+		admin.POST("/rooms", a.authMiddleware.RequirePermission(models.PermManageRooms), a.roomHandler.Create)
 
-		// @Summary Удалить отзыв по ID
+		// @Summary Полностью обновить комнату
 		// @Tags admin
 		// @Security BearerAuth
+		// @Accept json
 		// @Produce json
-		// @Param id path int true "ID отзыва"
-		// @Success 204 "no content"
-		// @Failure 400 {object} map[string]string "invalid review id | invalid input"
+		// @Param id path int true "ID комнаты"
+		// @Param input body models.Room true "Новые данные комнаты (hotel_id и rating игнорируются)"
+		// @Success 200 {object} models.Room
+		// @Failure 400 {object} map[string]string "invalid room id | invalid body | invalid input"
 		// @Failure 401 {object} map[string]string "unauthorized"
 		// @Failure 403 {object} map[string]string "access denied"
-		// @Failure 404 {object} map[string]string "review not found"
+		// @Failure 404 {object} map[string]string "room not found"
 		// @Failure 500 {object} map[string]string "internal server error"
-		// @Router /admin/reviews/{id} [delete]
-		admin.DELETE("/reviews/:id", a.reviewHandler.DeleteByID)
-	}
+		// @Router /admin/rooms/{id} [put]
+		admin.PUT("/rooms/:id", a.authMiddleware.RequirePermission(models.PermManageRooms), a.roomHandler.Replace)
 
-	favorites := router.Group("/favorites", a.authMiddleware.RequireAuth())
-	{
-		// @Summary Добавить комнату в избранное
-		// @Tags favorites
+		// @Summary Частично обновить комнату
+		// @Tags admin
 		// @Security BearerAuth
 		// @Accept json
 		// @Produce json
-		// @Param input body models.FavoriteRoomDTO true "Данные избранного"
-		// @Success 201 "Создано"
-		// @Failure 400 {object} map[string]string "invalid body"
-		// @Failure 401 {object} map[string]string "user authorization required"
-		// @Failure 500 {object} map[string]string "failed to add favorite"
-		// @Router /favorites [post]
-		favorites.POST("", a.favoriteRoomHandler.Add)
+		// @Param id path int true "ID комнаты"
+		// @Param input body models.UpdateRoomDTO true "Изменяемые поля"
+		// @Success 200 {object} models.Room
+		// @Failure 400 {object} map[string]string "invalid room id | invalid body | invalid input"
+		// @Failure 401 {object} map[string]string "unauthorized"
+		// @Failure 403 {object} map[string]string "access denied"
+		// @Failure 404 {object} map[string]string "room not found"
+		// @Failure 500 {object} map[string]string "internal server error"
+		// @Router /admin/rooms/{id} [patch]
+		admin.PATCH("/rooms/:id", a.authMiddleware.RequirePermission(models.PermManageRooms), a.roomHandler.Update)
 
-		// @Summary Удалить комнату из избранного
-		// @Tags favorites
+		// @Summary Удалить комнату
+		// @Description По умолчанию удаление мягкое: история отзывов, избранного и броней сохраняется.
+		// @Description hard=true удаляет комнату физически вместе со всеми связанными данными.
+		// @Tags admin
 		// @Security BearerAuth
 		// @Produce json
-		// @Param roomid path int true "ID комнаты"
-		// @Success 204 "no content"
-		// @Failure 400 {object} map[string]string "invalid roomid"
-		// @Failure 401 {object} map[string]string "user authorization required"
-		// @Failure 500 {object} map[string]string "failed to remove favorite"
-		// @Router /favorites/{roomid} [delete]
-		favorites.DELETE("/:roomid", a.favoriteRoomHandler.Remove)
+		// @Param id path int true "ID комнаты"
+		// @Param hard query bool false "Удалить физически"
+		// @Success 204 "Удалено"
+		// @Failure 400 {object} map[string]string "invalid room id | invalid input"
+		// @Failure 401 {object} map[string]string "unauthorized"
+		// @Failure 403 {object} map[string]string "access denied"
+		// @Failure 404 {object} map[string]string "room not found"
+		// @Failure 500 {object} map[string]string "internal server error"
+		// @Router /admin/rooms/{id} [delete]
+		admin.DELETE("/rooms/:id", a.authMiddleware.RequirePermission(models.PermManageRooms), a.roomHandler.Delete)
 
-		// @Summary Список избранных комнат текущего пользователя
-		// @Tags favorites
+		// @Summary Удалить отзыв по ID
+		// @Tags admin
+		// @Security BearerAuth
+		// @Produce json
+		// @Param id path int true "ID отзыва"
+		// @Success 204 "no content"
+		// @Failure 400 {object} map[string]string "invalid review id | invalid input"
+		// @Failure 401 {object} map[string]string "unauthorized"
+		// @Failure 403 {object} map[string]string "access denied"
+		// @Failure 404 {object} map[string]string "review not found"
+		// @Failure 500 {object} map[string]string "internal server error"
+		// @Router /admin/reviews/{id} [delete]
+		admin.DELETE("/reviews/:id", a.authMiddleware.RequirePermission(models.PermModerateReviews), a.reviewHandler.DeleteByID)
+
+		// @Summary Отзывы на модерации
+		// @Tags admin
+		// @Security BearerAuth
+		// @Produce json
+		// @Param status query string false "Статус: pending (по умолчанию), approved, rejected"
+		// @Param page query int false "Номер страницы (с 1)"
+		// @Param limit query int false "Размер страницы (до 100)"
+		// @Success 200 {object} models.ReviewListResponse
+		// @Failure 400 {object} map[string]string "invalid input"
+		// @Failure 401 {object} map[string]string "unauthorized"
+		// @Failure 403 {object} map[string]string "access denied"
+		// @Failure 500 {object} map[string]string "internal server error"
+		// @Router /admin/reviews [get]
+		admin.GET("/reviews", a.authMiddleware.RequirePermission(models.PermModerateReviews), a.reviewHandler.ListForModeration)
+
+		// @Summary Одобрить отзыв
+		// @Tags admin
+		// @Security BearerAuth
+		// @Produce json
+		// @Param id path int true "ID отзыва"
+		// @Success 200 {object} models.Review
+		// @Failure 400 {object} map[string]string "invalid id | invalid input"
+		// @Failure 401 {object} map[string]string "unauthorized"
+		// @Failure 403 {object} map[string]string "access denied"
+		// @Failure 404 {object} map[string]string "review not found"
+		// @Failure 500 {object} map[string]string "internal server error"
+		// @Router /admin/reviews/{id}/approve [post]
+		admin.POST("/reviews/:id/approve", a.authMiddleware.RequirePermission(models.PermModerateReviews), a.reviewHandler.Approve)
+
+		// @Summary Отклонить отзыв
+		// @Tags admin
+		// @Security BearerAuth
+		// @Accept json
+		// @Produce json
+		// @Param id path int true "ID отзыва"
+		// @Param input body models.RejectReviewDTO true "Причина отклонения"
+		// @Success 200 {object} models.Review
+		// @Failure 400 {object} map[string]string "invalid id | invalid body | invalid input"
+		// @Failure 401 {object} map[string]string "unauthorized"
+		// @Failure 403 {object} map[string]string "access denied"
+		// @Failure 404 {object} map[string]string "review not found"
+		// @Failure 500 {object} map[string]string "internal server error"
+		// @Router /admin/reviews/{id}/reject [post]
+		admin.POST("/reviews/:id/reject", a.authMiddleware.RequirePermission(models.PermModerateReviews), a.reviewHandler.Reject)
+
+		// @Summary Сменить роль пользователя
+		// @Tags admin
+		// @Security BearerAuth
+		// @Accept json
+		// @Produce json
+		// @Param id path int true "ID пользователя"
+		// @Param input body models.UpdateRoleDTO true "Новая роль"
+		// @Success 204 "Обновлено"
+		// @Failure 400 {object} map[string]string "invalid user id | invalid body | invalid input"
+		// @Failure 401 {object} map[string]string "unauthorized"
+		// @Failure 403 {object} map[string]string "access denied"
+		// @Failure 404 {object} map[string]string "user not found"
+		// @Failure 500 {object} map[string]string "internal server error"
+		// @Router /admin/users/{id}/role [patch]
+		admin.PATCH("/users/:id/role", a.authMiddleware.RequirePermission(models.PermManageUsers), a.userHandler.UpdateRole)
+
+		// @Summary Снять блокировку входа
+		// @Description Сбрасывает счетчики неудачных попыток и действующую блокировку для email и/или IP-адреса.
+		// @Description Снятие блокировки записывается в журнал безопасности.
+		// @Tags admin
+		// @Security BearerAuth
+		// @Accept json
+		// @Produce json
+		// @Param input body models.UnlockLoginDTO true "Email и/или IP-адрес"
+		// @Success 204 "Блокировка снята"
+		// @Failure 400 {object} map[string]string "invalid body | invalid input"
+		// @Failure 401 {object} map[string]string "user authentication required"
+		// @Failure 403 {object} map[string]string "access denied"
+		// @Failure 500 {object} map[string]string "internal server error"
+		// @Router /admin/security/unlock [post]
+		admin.POST("/security/unlock", a.authMiddleware.RequirePermission(models.PermManageUsers), a.securityHandler.Unlock)
+
+		// @Summary Журнал безопасности
+		// @Description Блокировки входа по email и IP и их снятие, от новых к старым.
+		// @Tags admin
+		// @Security BearerAuth
+		// @Produce json
+		// @Param type query string false "Тип: login_account_locked, login_ip_locked, login_unlocked"
+		// @Param page query int false "Номер страницы (с 1)"
+		// @Param limit query int false "Размер страницы (до 200)"
+		// @Success 200 {object} models.SecurityEventListResponse
+		// @Failure 400 {object} map[string]string "invalid input"
+		// @Failure 401 {object} map[string]string "user authentication required"
+		// @Failure 403 {object} map[string]string "access denied"
+		// @Failure 500 {object} map[string]string "internal server error"
+		// @Router /admin/security/events [get]
+		admin.GET("/security/events", a.authMiddleware.RequirePermission(models.PermManageUsers), a.securityHandler.ListEvents)
+
+		// @Summary Добавить удобство
+		// @Tags admin
+		// @Security BearerAuth
+		// @Accept json
+		// @Produce json
+		// @Param input body models.Amenity true "Код, название и область применения (id игнорируется)"
+		// @Success 201 {object} models.Amenity
+		// @Failure 400 {object} map[string]string "invalid body | invalid input"
+		// @Failure 401 {object} map[string]string "unauthorized"
+		// @Failure 403 {object} map[string]string "access denied"
+		// @Failure 409 {object} map[string]string "amenity already exists"
+		// @Failure 500 {object} map[string]string "internal server error"
+		// @Router /admin/amenities [post]
+		admin.POST("/amenities", a.authMiddleware.RequirePermission(models.PermManageHotels), a.amenityHandler.Create)
+
+		// @Summary Обновить удобство
+		// @Tags admin
+		// @Security BearerAuth
+		// @Accept json
+		// @Produce json
+		// @Param id path int true "ID удобства"
+		// @Param input body models.UpdateAmenityDTO true "Изменяемые поля"
+		// @Success 200 {object} models.Amenity
+		// @Failure 400 {object} map[string]string "invalid id | invalid body | invalid input"
+		// @Failure 401 {object} map[string]string "unauthorized"
+		// @Failure 403 {object} map[string]string "access denied"
+		// @Failure 404 {object} map[string]string "not found"
+		// @Failure 500 {object} map[string]string "internal server error"
+		// @Router /admin/amenities/{id} [patch]
+		admin.PATCH("/amenities/:id", a.authMiddleware.RequirePermission(models.PermManageHotels), a.amenityHandler.Update)
+
+		// @Summary Удалить удобство
+		// @Tags admin
+		// @Security BearerAuth
+		// @Produce json
+		// @Param id path int true "ID удобства"
+		// @Success 204 "Удалено"
+		// @Failure 400 {object} map[string]string "invalid id"
+		// @Failure 401 {object} map[string]string "unauthorized"
+		// @Failure 403 {object} map[string]string "access denied"
+		// @Failure 404 {object} map[string]string "not found"
+		// @Failure 500 {object} map[string]string "internal server error"
+		// @Router /admin/amenities/{id} [delete]
+		admin.DELETE("/amenities/:id", a.authMiddleware.RequirePermission(models.PermManageHotels), a.amenityHandler.Delete)
+
+		// @Summary Задать удобства отеля
+		// @Tags admin
+		// @Security BearerAuth
+		// @Accept json
+		// @Produce json
+		// @Param id path int true "ID отеля"
+		// @Param input body models.SetAmenitiesDTO true "Полный список кодов удобств"
+		// @Success 200 {object} models.SetAmenitiesDTO
+		// @Failure 400 {object} map[string]string "invalid id | invalid body | invalid input"
+		// @Failure 401 {object} map[string]string "unauthorized"
+		// @Failure 403 {object} map[string]string "access denied"
+		// @Failure 404 {object} map[string]string "not found"
+		// @Failure 500 {object} map[string]string "internal server error"
+		// @Router /admin/hotels/{id}/amenities [put]
+		admin.PUT("/hotels/:id/amenities", a.authMiddleware.RequirePermission(models.PermManageHotels), a.amenityHandler.SetHotelAmenities)
+
+		// @Summary Задать удобства комнаты
+		// @Tags admin
+		// @Security BearerAuth
+		// @Accept json
+		// @Produce json
+		// @Param id path int true "ID комнаты"
+		// @Param input body models.SetAmenitiesDTO true "Полный список кодов удобств"
+		// @Success 200 {object} models.SetAmenitiesDTO
+		// @Failure 400 {object} map[string]string "invalid id | invalid body | invalid input"
+		// @Failure 401 {object} map[string]string "unauthorized"
+		// @Failure 403 {object} map[string]string "access denied"
+		// @Failure 404 {object} map[string]string "not found"
+		// @Failure 500 {object} map[string]string "internal server error"
+		// @Router /admin/rooms/{id}/amenities [put]
+		admin.PUT("/rooms/:id/amenities", a.authMiddleware.RequirePermission(models.PermManageRooms), a.amenityHandler.SetRoomAmenities)
+
+		// @Summary Загрузить фотографию отеля
+		// @Description multipart/form-data: file (JPEG или PNG, не больше лимита из конфига), alt, isPrimary.
+		// @Description Создается превью; первая фотография автоматически становится главной.
+		// @Tags admin
+		// @Security BearerAuth
+		// @Accept multipart/form-data
+		// @Produce json
+		// @Param id path int true "ID отеля"
+		// @Param file formData file true "Изображение"
+		// @Param alt formData string false "Альтернативный текст"
+		// @Param isPrimary formData bool false "Сделать главной"
+		// @Success 201 {object} models.Image
+		// @Failure 400 {object} map[string]string "invalid id | file is required | invalid isPrimary | invalid input"
+		// @Failure 401 {object} map[string]string "unauthorized"
+		// @Failure 403 {object} map[string]string "access denied"
+		// @Failure 404 {object} map[string]string "not found"
+		// @Failure 413 {object} map[string]string "file too large"
+		// @Failure 415 {object} map[string]string "only jpeg and png images are allowed"
+		// @Failure 500 {object} map[string]string "internal server error"
+		// @Router /admin/hotels/{id}/images [post]
+		admin.POST("/hotels/:id/images", a.authMiddleware.RequirePermission(models.PermManageHotels), a.imageHandler.UploadHotelImage)
+
+		// @Summary Изменить порядок фотографий отеля
+		// @Tags admin
+		// @Security BearerAuth
+		// @Accept json
+		// @Produce json
+		// @Param id path int true "ID отеля"
+		// @Param input body models.ReorderImagesDTO true "Все ID фотографий в новом порядке"
+		// @Success 200 {array} models.Image
+		// @Failure 400 {object} map[string]string "invalid id | invalid body | invalid input"
+		// @Failure 401 {object} map[string]string "unauthorized"
+		// @Failure 403 {object} map[string]string "access denied"
+		// @Failure 404 {object} map[string]string "not found"
+		// @Failure 500 {object} map[string]string "internal server error"
+		// @Router /admin/hotels/{id}/images/order [put]
+		admin.PUT("/hotels/:id/images/order", a.authMiddleware.RequirePermission(models.PermManageHotels), a.imageHandler.ReorderHotelImages)
+
+		// @Summary Сделать фотографию отеля главной
+		// @Tags admin
+		// @Security BearerAuth
+		// @Produce json
+		// @Param id path int true "ID отеля"
+		// @Param imageid path int true "ID фотографии"
+		// @Success 200 {array} models.Image
+		// @Failure 400 {object} map[string]string "invalid id | invalid imageid"
+		// @Failure 401 {object} map[string]string "unauthorized"
+		// @Failure 403 {object} map[string]string "access denied"
+		// @Failure 404 {object} map[string]string "not found"
+		// @Failure 500 {object} map[string]string "internal server error"
+		// @Router /admin/hotels/{id}/images/{imageid}/primary [post]
+		admin.POST("/hotels/:id/images/:imageid/primary", a.authMiddleware.RequirePermission(models.PermManageHotels), a.imageHandler.SetHotelPrimaryImage)
+
+		// @Summary Удалить фотографию отеля
+		// @Tags admin
+		// @Security BearerAuth
+		// @Produce json
+		// @Param id path int true "ID отеля"
+		// @Param imageid path int true "ID фотографии"
+		// @Success 204 "Удалено"
+		// @Failure 400 {object} map[string]string "invalid id | invalid imageid"
+		// @Failure 401 {object} map[string]string "unauthorized"
+		// @Failure 403 {object} map[string]string "access denied"
+		// @Failure 404 {object} map[string]string "not found"
+		// @Failure 500 {object} map[string]string "internal server error"
+		// @Router /admin/hotels/{id}/images/{imageid} [delete]
+		admin.DELETE("/hotels/:id/images/:imageid", a.authMiddleware.RequirePermission(models.PermManageHotels), a.imageHandler.DeleteHotelImage)
+
+		// @Summary Загрузить фотографию комнаты
+		// @Description multipart/form-data: file (JPEG или PNG, не больше лимита из конфига), alt, isPrimary.
+		// @Description Создается превью; первая фотография автоматически становится главной.
+		// @Tags admin
+		// @Security BearerAuth
+		// @Accept multipart/form-data
+		// @Produce json
+		// @Param id path int true "ID комнаты"
+		// @Param file formData file true "Изображение"
+		// @Param alt formData string false "Альтернативный текст"
+		// @Param isPrimary formData bool false "Сделать главной"
+		// @Success 201 {object} models.Image
+		// @Failure 400 {object} map[string]string "invalid id | file is required | invalid isPrimary | invalid input"
+		// @Failure 401 {object} map[string]string "unauthorized"
+		// @Failure 403 {object} map[string]string "access denied"
+		// @Failure 404 {object} map[string]string "not found"
+		// @Failure 413 {object} map[string]string "file too large"
+		// @Failure 415 {object} map[string]string "only jpeg and png images are allowed"
+		// @Failure 500 {object} map[string]string "internal server error"
+		// @Router /admin/rooms/{id}/images [post]
+		admin.POST("/rooms/:id/images", a.authMiddleware.RequirePermission(models.PermManageRooms), a.imageHandler.UploadRoomImage)
+
+		// @Summary Изменить порядок фотографий комнаты
+		// @Tags admin
+		// @Security BearerAuth
+		// @Accept json
+		// @Produce json
+		// @Param id path int true "ID комнаты"
+		// @Param input body models.ReorderImagesDTO true "Все ID фотографий в новом порядке"
+		// @Success 200 {array} models.Image
+		// @Failure 400 {object} map[string]string "invalid id | invalid body | invalid input"
+		// @Failure 401 {object} map[string]string "unauthorized"
+		// @Failure 403 {object} map[string]string "access denied"
+		// @Failure 404 {object} map[string]string "not found"
+		// @Failure 500 {object} map[string]string "internal server error"
+		// @Router /admin/rooms/{id}/images/order [put]
+		admin.PUT("/rooms/:id/images/order", a.authMiddleware.RequirePermission(models.PermManageRooms), a.imageHandler.ReorderRoomImages)
+
+		// @Summary Сделать фотографию комнаты главной
+		// @Tags admin
+		// @Security BearerAuth
+		// @Produce json
+		// @Param id path int true "ID комнаты"
+		// @Param imageid path int true "ID фотографии"
+		// @Success 200 {array} models.Image
+		// @Failure 400 {object} map[string]string "invalid id | invalid imageid"
+		// @Failure 401 {object} map[string]string "unauthorized"
+		// @Failure 403 {object} map[string]string "access denied"
+		// @Failure 404 {object} map[string]string "not found"
+		// @Failure 500 {object} map[string]string "internal server error"
+		// @Router /admin/rooms/{id}/images/{imageid}/primary [post]
+		admin.POST("/rooms/:id/images/:imageid/primary", a.authMiddleware.RequirePermission(models.PermManageRooms), a.imageHandler.SetRoomPrimaryImage)
+
+		// @Summary Удалить фотографию комнаты
+		// @Tags admin
+		// @Security BearerAuth
+		// @Produce json
+		// @Param id path int true "ID комнаты"
+		// @Param imageid path int true "ID фотографии"
+		// @Success 204 "Удалено"
+		// @Failure 400 {object} map[string]string "invalid id | invalid imageid"
+		// @Failure 401 {object} map[string]string "unauthorized"
+		// @Failure 403 {object} map[string]string "access denied"
+		// @Failure 404 {object} map[string]string "not found"
+		// @Failure 500 {object} map[string]string "internal server error"
+		// @Router /admin/rooms/{id}/images/{imageid} [delete]
+		admin.DELETE("/rooms/:id/images/:imageid", a.authMiddleware.RequirePermission(models.PermManageRooms), a.imageHandler.DeleteRoomImage)
+	}
+
+	// Партнерский API: владельцы управляют только своими отелями (проверка владения — в сервисах)
+	partner := router.Group("/partner",
+		a.authMiddleware.RequireAuth(),
+		a.authMiddleware.RequirePermission(models.PermManageOwnHotels),
+	)
+	{
+		// @Summary Мои отели
+		// @Tags partner
+		// @Security BearerAuth
+		// @Produce json
+		// @Success 200 {array} models.Hotel
+		// @Failure 401 {object} map[string]string "user authentication required"
+		// @Failure 403 {object} map[string]string "access denied"
+		// @Failure 500 {object} map[string]string "internal server error"
+		// @Router /partner/hotels [get]
+		partner.GET("/hotels", a.partnerHandler.ListHotels)
+
+		// @Summary Создать свой отель
+		// @Tags partner
+		// @Security BearerAuth
+		// @Accept json
+		// @Produce json
+		// @Param input body models.Hotel true "Данные отеля"
+		// @Success 201 {object} models.Hotel
+		// @Failure 400 {object} map[string]string "invalid body | invalid input"
+		// @Failure 401 {object} map[string]string "user authentication required"
+		// @Failure 403 {object} map[string]string "access denied"
+		// @Failure 500 {object} map[string]string "internal server error"
+		// @Router /partner/hotels [post]
+		partner.POST("/hotels", a.partnerHandler.CreateHotel)
+
+		// @Summary Обновить свой отель
+		// @Tags partner
+		// @Security BearerAuth
+		// @Accept json
+		// @Produce json
+		// @Param hotelid path int true "ID отеля"
+		// @Param input body models.UpdateHotelDTO true "Изменяемые поля"
+		// @Success 200 {object} models.Hotel
+		// @Failure 400 {object} map[string]string "invalid hotelid | invalid body | invalid input"
+		// @Failure 401 {object} map[string]string "user authentication required"
+		// @Failure 403 {object} map[string]string "access denied"
+		// @Failure 404 {object} map[string]string "not found"
+		// @Failure 500 {object} map[string]string "internal server error"
+		// @Router /partner/hotels/{hotelid} [patch]
+		partner.PATCH("/hotels/:hotelid", a.partnerHandler.UpdateHotel)
+
+		// @Summary Удалить свой отель
+		// @Tags partner
+		// @Security BearerAuth
+		// @Produce json
+		// @Param hotelid path int true "ID отеля"
+		// @Success 204 "Удалено"
+		// @Failure 400 {object} map[string]string "invalid hotelid"
+		// @Failure 401 {object} map[string]string "user authentication required"
+		// @Failure 403 {object} map[string]string "access denied"
+		// @Failure 404 {object} map[string]string "not found"
+		// @Failure 500 {object} map[string]string "internal server error"
+		// @Router /partner/hotels/{hotelid} [delete]
+		partner.DELETE("/hotels/:hotelid", a.partnerHandler.DeleteHotel)
+
+		// @Summary Добавить комнату в свой отель
+		// @Tags partner
+		// @Security BearerAuth
+		// @Accept json
+		// @Produce json
+		// @Param hotelid path int true "ID отеля"
+		// @Param input body models.Room true "Данные комнаты (hotel_id берется из пути)"
+		// @Success 201 {object} models.Room
+		// @Failure 400 {object} map[string]string "invalid hotelid | invalid body | invalid input"
+		// @Failure 401 {object} map[string]string "user authentication required"
+		// @Failure 403 {object} map[string]string "access denied"
+		// @Failure 404 {object} map[string]string "not found"
+		// @Failure 500 {object} map[string]string "internal server error"
+		// @Router /partner/hotels/{hotelid}/rooms [post]
+		partner.POST("/hotels/:hotelid/rooms", a.partnerHandler.CreateRoom)
+
+		// @Summary Обновить комнату своего отеля
+		// @Tags partner
+		// @Security BearerAuth
+		// @Accept json
+		// @Produce json
+		// @Param hotelid path int true "ID отеля"
+		// @Param roomid path int true "ID комнаты"
+		// @Param input body models.UpdateRoomDTO true "Изменяемые поля"
+		// @Success 200 {object} models.Room
+		// @Failure 400 {object} map[string]string "invalid hotelid | invalid roomid | invalid body | invalid input"
+		// @Failure 401 {object} map[string]string "user authentication required"
+		// @Failure 403 {object} map[string]string "access denied"
+		// @Failure 404 {object} map[string]string "not found"
+		// @Failure 500 {object} map[string]string "internal server error"
+		// @Router /partner/hotels/{hotelid}/rooms/{roomid} [patch]
+		partner.PATCH("/hotels/:hotelid/rooms/:roomid", a.partnerHandler.UpdateRoom)
+
+		// @Summary Удалить комнату своего отеля
+		// @Tags partner
+		// @Security BearerAuth
+		// @Produce json
+		// @Param hotelid path int true "ID отеля"
+		// @Param roomid path int true "ID комнаты"
+		// @Success 204 "Удалено"
+		// @Failure 400 {object} map[string]string "invalid hotelid | invalid roomid"
+		// @Failure 401 {object} map[string]string "user authentication required"
+		// @Failure 403 {object} map[string]string "access denied"
+		// @Failure 404 {object} map[string]string "not found"
+		// @Failure 500 {object} map[string]string "internal server error"
+		// @Router /partner/hotels/{hotelid}/rooms/{roomid} [delete]
+		partner.DELETE("/hotels/:hotelid/rooms/:roomid", a.partnerHandler.DeleteRoom)
+	}
+
+	favorites := router.Group("/favorites", a.authMiddleware.RequireAuth())
+	{
+		// @Summary Добавить комнату в избранное
+		// @Tags favorites
+		// @Security BearerAuth
+		// @Accept json
+		// @Produce json
+		// @Param input body models.FavoriteRoomDTO true "Данные избранного"
+		// @Success 201 "Создано"
+		// @Failure 400 {object} map[string]string "invalid body"
+		// @Failure 401 {object} map[string]string "user authorization required"
+		// @Failure 500 {object} map[string]string "failed to add favorite"
+		// @Router /favorites [post]
+		favorites.POST("", a.favoriteRoomHandler.Add)
+
+		// @Summary Удалить комнату из избранного
+		// @Tags favorites
+		// @Security BearerAuth
+		// @Produce json
+		// @Param roomid path int true "ID комнаты"
+		// @Success 204 "no content"
+		// @Failure 400 {object} map[string]string "invalid roomid"
+		// @Failure 401 {object} map[string]string "user authorization required"
+		// @Failure 500 {object} map[string]string "failed to remove favorite"
+		// @Router /favorites/{roomid} [delete]
+		favorites.DELETE("/:roomid", a.favoriteRoomHandler.Remove)
+
+		// @Summary Список избранных комнат текущего пользователя
+		// @Tags favorites
 		// @Security BearerAuth
 		// @Produce json
 		// @Success 200 {array} int64 "Список ID комнат"
@@ -282,18 +1263,122 @@ func (a Api) InitRoutes() *gin.Engine {
 	reviews := router.Group("/reviews", a.authMiddleware.RequireAuth())
 	{
 		// @Summary Создать отзыв
+		// @Description Оставить отзыв может только гость с подтвержденным email и завершенным проживанием в комнате, один на комнату.
+		// @Description Отзыв попадает в очередь модерации и публикуется после одобрения; поле approved игнорируется.
 		// @Tags reviews
 		// @Security BearerAuth
 		// @Accept json
 		// @Produce json
-		// @Param input body models.Review true "Отзыв"
+		// @Param input body models.Review true "Данные отзыва"
 		// @Success 201 {object} models.Review
-		// @Failure 400 {object} map[string]string "bad request"
+		// @Failure 400 {object} map[string]string "bad request | invalid input"
 		// @Failure 401 {object} map[string]string "unauthorized"
+		// @Failure 403 {object} map[string]string "completed stay required | email not verified"
+		// @Failure 404 {object} map[string]string "room not found"
+		// @Failure 409 {object} map[string]string "review already exists"
 		// @Failure 500 {object} map[string]string "internal server error"
 		// @Router /reviews [post]
 		reviews.POST("", a.reviewHandler.Create)
 
+		// @Summary Изменить свой отзыв
+		// @Description Доступно автору в течение окна редактирования после создания (reviews.edit_window_hours). Измененный отзыв снова уходит на модерацию.
+		// @Tags reviews
+		// @Security BearerAuth
+		// @Accept json
+		// @Produce json
+		// @Param id path int true "ID отзыва"
+		// @Param input body models.UpdateReviewDTO true "Изменяемые поля"
+		// @Success 200 {object} models.Review
+		// @Failure 400 {object} map[string]string "invalid id | invalid body | invalid input"
+		// @Failure 401 {object} map[string]string "unauthorized"
+		// @Failure 403 {object} map[string]string "access denied | edit window expired | email not verified"
+		// @Failure 404 {object} map[string]string "review not found"
+		// @Failure 500 {object} map[string]string "internal server error"
+		// @Router /reviews/{id} [patch]
+		reviews.PATCH("/:id", a.reviewHandler.Update)
+
+		// @Summary Отметить отзыв полезным
+		// @Description Одна отметка от пользователя на отзыв; за свой отзыв голосовать нельзя.
+		// @Tags reviews
+		// @Security BearerAuth
+		// @Produce json
+		// @Param id path int true "ID отзыва"
+		// @Success 200 {object} models.Review
+		// @Failure 400 {object} map[string]string "invalid id | invalid input"
+		// @Failure 401 {object} map[string]string "unauthorized"
+		// @Failure 403 {object} map[string]string "access denied"
+		// @Failure 404 {object} map[string]string "review not found"
+		// @Failure 409 {object} map[string]string "already voted"
+		// @Failure 500 {object} map[string]string "internal server error"
+		// @Router /reviews/{id}/helpful [post]
+		reviews.POST("/:id/helpful", a.reviewHandler.MarkHelpful)
+
+		// @Summary Пожаловаться на отзыв
+		// @Description Одна жалоба от пользователя на отзыв. Набравший несколько жалоб отзыв снимается с публикации до решения модератора.
+		// @Tags reviews
+		// @Security BearerAuth
+		// @Accept json
+		// @Produce json
+		// @Param id path int true "ID отзыва"
+		// @Param input body models.ReportReviewDTO false "Причина жалобы"
+		// @Success 200 {object} models.Review
+		// @Failure 400 {object} map[string]string "invalid id | invalid body | invalid input"
+		// @Failure 401 {object} map[string]string "unauthorized"
+		// @Failure 403 {object} map[string]string "access denied"
+		// @Failure 404 {object} map[string]string "review not found"
+		// @Failure 409 {object} map[string]string "already reported"
+		// @Failure 500 {object} map[string]string "internal server error"
+		// @Router /reviews/{id}/report [post]
+		reviews.POST("/:id/report", a.reviewHandler.Report)
+
+		// @Summary Ответить на отзыв от имени отеля
+		// @Description Владелец отвечает на отзывы своих отелей, администратор — на любые. Один ответ на отзыв, только на опубликованные отзывы.
+		// @Tags reviews
+		// @Security BearerAuth
+		// @Accept json
+		// @Produce json
+		// @Param id path int true "ID отзыва"
+		// @Param input body models.ReviewReplyDTO true "Текст ответа"
+		// @Success 201 {object} models.ReviewReply
+		// @Failure 400 {object} map[string]string "invalid id | invalid body | invalid input"
+		// @Failure 401 {object} map[string]string "unauthorized"
+		// @Failure 403 {object} map[string]string "access denied"
+		// @Failure 404 {object} map[string]string "not found"
+		// @Failure 409 {object} map[string]string "reply already exists"
+		// @Failure 500 {object} map[string]string "internal server error"
+		// @Router /reviews/{id}/reply [post]
+		reviews.POST("/:id/reply", a.authMiddleware.RequirePermission(models.PermManageOwnHotels), a.reviewHandler.CreateReply)
+
+		// @Summary Изменить ответ на отзыв
+		// @Tags reviews
+		// @Security BearerAuth
+		// @Accept json
+		// @Produce json
+		// @Param id path int true "ID отзыва"
+		// @Param input body models.ReviewReplyDTO true "Новый текст ответа"
+		// @Success 200 {object} models.ReviewReply
+		// @Failure 400 {object} map[string]string "invalid id | invalid body | invalid input"
+		// @Failure 401 {object} map[string]string "unauthorized"
+		// @Failure 403 {object} map[string]string "access denied"
+		// @Failure 404 {object} map[string]string "not found"
+		// @Failure 500 {object} map[string]string "internal server error"
+		// @Router /reviews/{id}/reply [patch]
+		reviews.PATCH("/:id/reply", a.authMiddleware.RequirePermission(models.PermManageOwnHotels), a.reviewHandler.UpdateReply)
+
+		// @Summary Удалить ответ на отзыв
+		// @Tags reviews
+		// @Security BearerAuth
+		// @Produce json
+		// @Param id path int true "ID отзыва"
+		// @Success 204 "Удалено"
+		// @Failure 400 {object} map[string]string "invalid id | invalid input"
+		// @Failure 401 {object} map[string]string "unauthorized"
+		// @Failure 403 {object} map[string]string "access denied"
+		// @Failure 404 {object} map[string]string "not found"
+		// @Failure 500 {object} map[string]string "internal server error"
+		// @Router /reviews/{id}/reply [delete]
+		reviews.DELETE("/:id/reply", a.authMiddleware.RequirePermission(models.PermManageOwnHotels), a.reviewHandler.DeleteReply)
+
 		// @Summary Свои отзывы
 		// @Tags reviews
 		// @Security BearerAuth
@@ -315,5 +1400,109 @@ func (a Api) InitRoutes() *gin.Engine {
 		reviews.GET("/users/:userid", a.reviewHandler.ListByUserID)
 	}
 
-	return router
+	bookings := router.Group("/bookings", a.authMiddleware.RequireAuth())
+	{
+		// @Summary Забронировать комнату
+		// @Tags bookings
+		// @Security BearerAuth
+		// @Accept json
+		// @Produce json
+		// @Param input body models.CreateBookingDTO true "Комната, даты и гости"
+		// @Success 201 {object} models.Booking
+		// @Failure 400 {object} map[string]string "invalid body | invalid input"
+		// @Failure 401 {object} map[string]string "user authentication required"
+		// @Failure 409 {object} map[string]string "room is not available for the selected dates"
+		// @Failure 500 {object} map[string]string "internal server error"
+		// @Router /bookings [post]
+		bookings.POST("", a.bookingHandler.Create)
+
+		// @Summary Список броней текущего пользователя
+		// @Tags bookings
+		// @Security BearerAuth
+		// @Produce json
+		// @Success 200 {array} models.Booking
+		// @Failure 401 {object} map[string]string "user authentication required"
+		// @Failure 500 {object} map[string]string "internal server error"
+		// @Router /bookings [get]
+		bookings.GET("", a.bookingHandler.List)
+
+		// @Summary Получить бронь по ID
+		// @Tags bookings
+		// @Security BearerAuth
+		// @Produce json
+		// @Param id path int true "ID брони"
+		// @Success 200 {object} models.Booking
+		// @Failure 400 {object} map[string]string "invalid booking id"
+		// @Failure 401 {object} map[string]string "user authentication required"
+		// @Failure 404 {object} map[string]string "booking not found"
+		// @Failure 500 {object} map[string]string "internal server error"
+		// @Router /bookings/{id} [get]
+		bookings.GET("/:id", a.bookingHandler.GetByID)
+
+		// @Summary Отменить бронь
+		// @Tags bookings
+		// @Security BearerAuth
+		// @Produce json
+		// @Param id path int true "ID брони"
+		// @Success 200 {object} models.Booking
+		// @Failure 400 {object} map[string]string "invalid booking id"
+		// @Failure 401 {object} map[string]string "user authentication required"
+		// @Failure 404 {object} map[string]string "booking not found"
+		// @Failure 409 {object} map[string]string "booking cannot be cancelled"
+		// @Failure 500 {object} map[string]string "internal server error"
+		// @Router /bookings/{id}/cancel [post]
+		bookings.POST("/:id/cancel", a.bookingHandler.Cancel)
+
+		// @Summary Подтвердить бронь
+		// @Description Владелец отеля или администратор подтверждает ожидающую бронь (pending → confirmed).
+		// @Tags bookings
+		// @Security BearerAuth
+		// @Produce json
+		// @Param id path int true "ID брони"
+		// @Success 200 {object} models.Booking
+		// @Failure 400 {object} map[string]string "invalid id | invalid input"
+		// @Failure 401 {object} map[string]string "user authentication required"
+		// @Failure 403 {object} map[string]string "access denied"
+		// @Failure 404 {object} map[string]string "booking not found"
+		// @Failure 409 {object} map[string]string "booking cannot be confirmed"
+		// @Failure 500 {object} map[string]string "internal server error"
+		// @Router /bookings/{id}/confirm [post]
+		bookings.POST("/:id/confirm", a.authMiddleware.RequirePermission(models.PermManageOwnHotels), a.bookingHandler.Confirm)
+
+		// @Summary Отметить проживание завершенным
+		// @Description Владелец отеля или администратор завершает подтвержденную бронь (confirmed → completed) не раньше даты выезда.
+		// @Tags bookings
+		// @Security BearerAuth
+		// @Produce json
+		// @Param id path int true "ID брони"
+		// @Success 200 {object} models.Booking
+		// @Failure 400 {object} map[string]string "invalid id | invalid input"
+		// @Failure 401 {object} map[string]string "user authentication required"
+		// @Failure 403 {object} map[string]string "access denied"
+		// @Failure 404 {object} map[string]string "booking not found"
+		// @Failure 409 {object} map[string]string "booking cannot be completed"
+		// @Failure 500 {object} map[string]string "internal server error"
+		// @Router /bookings/{id}/complete [post]
+		bookings.POST("/:id/complete", a.authMiddleware.RequirePermission(models.PermManageOwnHotels), a.bookingHandler.Complete)
+	}
+
+	feed := router.Group("/feed", a.authMiddleware.RequireAuth())
+	{
+		// @Summary Лента друзей
+		// @Description Отзывы, избранное и завершенные проживания друзей по убыванию времени.
+		// @Description Действия, скрытые автором в настройках приватности, не показываются.
+		// @Tags feed
+		// @Security BearerAuth
+		// @Produce json
+		// @Param cursor query string false "Курсор из next_cursor предыдущей страницы"
+		// @Param limit query int false "Размер страницы (по умолчанию 20, до 50)"
+		// @Success 200 {object} models.FeedResponse
+		// @Failure 400 {object} map[string]string "invalid input"
+		// @Failure 401 {object} map[string]string "user authentication required"
+		// @Failure 500 {object} map[string]string "internal server error"
+		// @Router /feed [get]
+		feed.GET("", a.feedHandler.List)
+	}
+
+	return router, nil
 }
@@ -6,6 +6,13 @@ type Config struct {
     Database DatabaseConfig `mapstructure:"database"`
     JWT      JWTConfig      `mapstructure:"jwt"`
     App      AppConfig      `mapstructure:"app"`
+    Storage  StorageConfig  `mapstructure:"storage"`
+    Reviews  ReviewsConfig  `mapstructure:"reviews"`
+    Telegram TelegramConfig `mapstructure:"telegram"`
+    OAuth    OAuthConfig    `mapstructure:"oauth"`
+    Mail     MailConfig     `mapstructure:"mail"`
+    Account  AccountConfig  `mapstructure:"account"`
+    Security SecurityConfig `mapstructure:"security"`
 }
 
 type ServerConfig struct {
@@ -13,6 +20,9 @@ type ServerConfig struct {
     Port         string `mapstructure:"port"`
     ReadTimeout  int    `mapstructure:"read_timeout"`
     WriteTimeout int    `mapstructure:"write_timeout"`
+    // TrustedProxies адреса/подсети прокси, чьим X-Forwarded-For можно верить.
+    // Пусто — заголовок игнорируется, клиентом считается адрес TCP-соединения.
+    TrustedProxies []string `mapstructure:"trusted_proxies"`
 }
 
 type DatabaseConfig struct {
@@ -28,6 +38,7 @@ type JWTConfig struct {
     Secret         string `mapstructure:"secret"`
     AccessTokenTTL int    `mapstructure:"access_token_ttl"`
     RefreshTokenTTL int   `mapstructure:"refresh_token_ttl"`
+    SessionCacheTTL int   `mapstructure:"session_cache_ttl"`
 }
 
 type AppConfig struct {
@@ -37,3 +48,82 @@ type AppConfig struct {
     Name        string `mapstructure:"name"`
     Version     string `mapstructure:"version"`
 }
+
+// StorageConfig хранилище загружаемых файлов (фотографии отелей и комнат)
+type StorageConfig struct {
+    Driver      string `mapstructure:"driver"`        // local; S3-совместимое подключается через storage.Storage
+    Dir         string `mapstructure:"dir"`           // каталог для driver=local
+    BaseURL     string `mapstructure:"base_url"`      // публичный префикс URL файлов
+    MaxUploadMB int    `mapstructure:"max_upload_mb"` // лимит размера одного файла
+}
+
+// ReviewsConfig правила публикации отзывов
+type ReviewsConfig struct {
+    EditWindowHours int `mapstructure:"edit_window_hours"` // сколько часов автор может править отзыв
+    ReportThreshold int `mapstructure:"report_threshold"`  // после стольких жалоб отзыв возвращается на модерацию
+}
+
+// TelegramConfig вход через Telegram Login Widget; без bot_token вход отключен
+type TelegramConfig struct {
+    BotToken    string `mapstructure:"bot_token"`    // токен бота от @BotFather, ключ проверки подписи
+    BotUsername string `mapstructure:"bot_username"` // имя бота для data-telegram-login виджета
+    AuthMaxAge  int    `mapstructure:"auth_max_age"` // секунд; сколько действительны данные виджета после auth_date
+}
+
+// OAuthConfig вход через внешних OAuth2/OIDC-провайдеров (authorization code + PKCE)
+type OAuthConfig struct {
+    StateTTL  int                            `mapstructure:"state_ttl"` // секунд на прохождение авторизации у провайдера
+    Providers map[string]OAuthProviderConfig `mapstructure:"providers"` // ключ — имя провайдера в URL
+}
+
+// OAuthProviderConfig провайдер включен, если заданы client_id и client_secret.
+// Поля профиля задаются путем в JSON ответа userinfo, вложенность — через точку (user.email).
+type OAuthProviderConfig struct {
+    ClientID     string   `mapstructure:"client_id"`
+    ClientSecret string   `mapstructure:"client_secret"`
+    AuthURL      string   `mapstructure:"auth_url"`
+    TokenURL     string   `mapstructure:"token_url"`
+    UserInfoURL  string   `mapstructure:"userinfo_url"`
+    RedirectURL  string   `mapstructure:"redirect_url"`
+    Scopes       []string `mapstructure:"scopes"`
+
+    UserInfoAuth       string `mapstructure:"userinfo_auth"`        // bearer (по умолчанию), oauth или form
+    SubjectField       string `mapstructure:"subject_field"`        // по умолчанию sub
+    EmailField         string `mapstructure:"email_field"`          // по умолчанию email
+    EmailVerifiedField string `mapstructure:"email_verified_field"` // по умолчанию email_verified
+    NameField          string `mapstructure:"name_field"`           // по умолчанию name
+    TrustEmail         bool   `mapstructure:"trust_email"`          // провайдер отдает только подтвержденные адреса
+}
+
+// MailConfig исходящие письма
+type MailConfig struct {
+    Driver string `mapstructure:"driver"`  // log или file; SMTP подключается через mailer.Mailer
+    Dir    string `mapstructure:"dir"`     // каталог .eml файлов для driver=file
+    From   string `mapstructure:"from"`    // адрес отправителя
+    AppURL string `mapstructure:"app_url"` // адрес фронтенда для ссылок в письмах
+}
+
+// AccountConfig подтверждение email и восстановление пароля
+type AccountConfig struct {
+    VerifyTokenTTL int `mapstructure:"verify_token_ttl"` // секунд действует ссылка подтверждения email
+    ResetTokenTTL  int `mapstructure:"reset_token_ttl"`  // секунд действует ссылка сброса пароля
+    MinAge         int `mapstructure:"min_age"`          // минимальный возраст при регистрации (если указана дата рождения)
+}
+
+// SecurityConfig защита входа по паролю от перебора
+type SecurityConfig struct {
+    LoginStore string           `mapstructure:"login_store"` // memory — одна реплика, postgres — счетчики общие для всех реплик
+    Account    LoginLimitConfig `mapstructure:"account"`     // неудачные попытки для одного email
+    IP         LoginLimitConfig `mapstructure:"ip"`          // неудачные попытки с одного IP-адреса
+}
+
+// LoginLimitConfig после free_attempts неудач вход откладывается на base_delay, 2*base_delay, ...
+// (не больше max_delay); после lockout_after неудач — блокировка на lockout_duration
+type LoginLimitConfig struct {
+    FreeAttempts    int `mapstructure:"free_attempts"`    // неудачных попыток без задержки
+    BaseDelay       int `mapstructure:"base_delay"`       // секунд
+    MaxDelay        int `mapstructure:"max_delay"`        // секунд
+    LockoutAfter    int `mapstructure:"lockout_after"`    // неудачных попыток до блокировки
+    LockoutDuration int `mapstructure:"lockout_duration"` // секунд
+    ResetAfter      int `mapstructure:"reset_after"`      // секунд без неудач, после которых счетчик обнуляется
+}
@@ -7,8 +7,17 @@ var (
 	ErrUserAlreadyExists  = errors.New("user already exists")
 	ErrUserNotFound       = errors.New("user not found")
 	ErrInvalidCredentials = errors.New("invalid credentials")
+	ErrTooManyAttempts    = errors.New("too many failed login attempts")
 	ErrEmailTaken         = errors.New("email already taken")
 
+	// Подтверждение email
+	ErrEmailNotVerified     = errors.New("email not verified")
+	ErrEmailAlreadyVerified = errors.New("email already verified")
+
+	// Токены
+	ErrInvalidToken = errors.New("invalid token")
+	ErrTokenReused  = errors.New("refresh token reuse detected")
+
 	// Общие
 	ErrInvalidInput = errors.New("invalid input")
 	ErrForbidden    = errors.New("forbidden")
@@ -18,4 +27,30 @@ var (
 
 	// Домены комнат/отелей
 	ErrInvalidHotelID = errors.New("invalid hotel id")
+	ErrNotHotelOwner  = errors.New("hotel is owned by another user")
+
+	// Бронирования
+	ErrRoomUnavailable       = errors.New("room is not available for the selected dates")
+	ErrBookingNotCancellable = errors.New("booking cannot be cancelled")
+	ErrBookingNotConfirmable = errors.New("booking cannot be confirmed")
+	ErrBookingNotCompletable = errors.New("booking cannot be completed")
+
+	// Отзывы
+	ErrStayRequired      = errors.New("completed stay required to review this room")
+	ErrReviewEditExpired = errors.New("review edit window has expired")
+
+	// Вход через Telegram
+	ErrTelegramDisabled    = errors.New("telegram login is not configured")
+	ErrTelegramAuthInvalid = errors.New("invalid telegram auth data")
+	ErrTelegramAuthExpired = errors.New("telegram auth data expired")
+
+	// Вход через OAuth2/OIDC
+	ErrOAuthProviderDisabled = errors.New("oauth provider is not configured")
+	ErrOAuthStateInvalid     = errors.New("oauth state is invalid or expired")
+	ErrOAuthExchange         = errors.New("oauth provider request failed")
+	ErrOAuthEmailUnverified  = errors.New("email belongs to an account with unverified email")
+
+	// Файлы
+	ErrFileTooLarge         = errors.New("file too large")
+	ErrUnsupportedMediaType = errors.New("unsupported media type")
 )
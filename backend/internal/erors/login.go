@@ -0,0 +1,20 @@
+package erors
+
+import (
+	"fmt"
+	"time"
+)
+
+// LoginLockedError вход временно запрещен после серии неудачных попыток.
+// Для errors.Is это ErrTooManyAttempts.
+type LoginLockedError struct {
+	RetryAfter time.Duration
+}
+
+func (e *LoginLockedError) Error() string {
+	return fmt.Sprintf("%s: retry after %s", ErrTooManyAttempts, e.RetryAfter)
+}
+
+func (e *LoginLockedError) Unwrap() error {
+	return ErrTooManyAttempts
+}
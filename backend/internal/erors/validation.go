@@ -0,0 +1,33 @@
+package erors
+
+import "strings"
+
+// FieldError ошибка проверки одного поля запроса
+type FieldError struct {
+	// Имя поля в JSON запроса
+	Field string `json:"field" example:"password"`
+
+	// Машиночитаемый код причины
+	Code string `json:"code" example:"too_short"`
+
+	// Сообщение для пользователя
+	Message string `json:"message" example:"Пароль должен быть не короче 8 символов"`
+}
+
+// ValidationError ошибки по полям запроса. Для errors.Is это ErrInvalidInput,
+// поэтому обработчики без поддержки полей отвечают как раньше.
+type ValidationError struct {
+	Fields []FieldError
+}
+
+func (e *ValidationError) Error() string {
+	parts := make([]string, 0, len(e.Fields))
+	for _, f := range e.Fields {
+		parts = append(parts, f.Field+": "+f.Code)
+	}
+	return "invalid input: " + strings.Join(parts, ", ")
+}
+
+func (e *ValidationError) Unwrap() error {
+	return ErrInvalidInput
+}
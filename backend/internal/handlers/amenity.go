@@ -0,0 +1,237 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	"backend/internal/erors"
+	"backend/internal/models"
+	"backend/internal/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+type AmenityHandler struct {
+	amenityService services.AmenityServiceInterface
+}
+
+func NewAmenityHandler(amenityService services.AmenityServiceInterface) AmenityHandler {
+	return AmenityHandler{amenityService: amenityService}
+}
+
+// parseAmenitiesQuery разбирает ?amenities=wifi,pool (допускается и повтор параметра)
+func parseAmenitiesQuery(c *gin.Context) []string {
+	var codes []string
+	for _, v := range c.QueryArray("amenities") {
+		codes = append(codes, strings.Split(v, ",")...)
+	}
+	return codes
+}
+
+func writeAmenityError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, erors.ErrInvalidInput):
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid input"})
+	case errors.Is(err, erors.ErrNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": "not found"})
+	case errors.Is(err, erors.ErrConflict):
+		c.JSON(http.StatusConflict, gin.H{"error": "amenity already exists"})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+	}
+}
+
+// List справочник удобств
+// @Summary Справочник удобств
+// @Tags amenities
+// @Produce json
+// @Param scope query string false "hotel | room — только применимые (включая both)"
+// @Success 200 {array} models.Amenity
+// @Failure 400 {object} map[string]string "invalid input"
+// @Failure 500 {object} map[string]string "internal server error"
+// @Router /amenities [get]
+func (h AmenityHandler) List(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+	defer cancel()
+
+	amenities, err := h.amenityService.List(ctx, c.Query("scope"))
+	if err != nil {
+		writeAmenityError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, amenities)
+}
+
+// Create добавить удобство в справочник
+// @Summary Добавить удобство
+// @Tags admin
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param input body models.Amenity true "Код, название и область применения (id игнорируется)"
+// @Success 201 {object} models.Amenity
+// @Failure 400 {object} map[string]string "invalid body | invalid input"
+// @Failure 401 {object} map[string]string "unauthorized"
+// @Failure 403 {object} map[string]string "access denied"
+// @Failure 409 {object} map[string]string "amenity already exists"
+// @Failure 500 {object} map[string]string "internal server error"
+// @Router /admin/amenities [post]
+func (h AmenityHandler) Create(c *gin.Context) {
+	var amenity models.Amenity
+	if err := c.ShouldBindJSON(&amenity); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid body"})
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+	defer cancel()
+
+	if err := h.amenityService.Create(ctx, &amenity); err != nil {
+		writeAmenityError(c, err)
+		return
+	}
+	c.JSON(http.StatusCreated, amenity)
+}
+
+// Update изменить название или область применения удобства
+// @Summary Обновить удобство
+// @Tags admin
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param id path int true "ID удобства"
+// @Param input body models.UpdateAmenityDTO true "Изменяемые поля"
+// @Success 200 {object} models.Amenity
+// @Failure 400 {object} map[string]string "invalid id | invalid body | invalid input"
+// @Failure 401 {object} map[string]string "unauthorized"
+// @Failure 403 {object} map[string]string "access denied"
+// @Failure 404 {object} map[string]string "not found"
+// @Failure 500 {object} map[string]string "internal server error"
+// @Router /admin/amenities/{id} [patch]
+func (h AmenityHandler) Update(c *gin.Context) {
+	id, ok := parseIDParam(c, "id")
+	if !ok {
+		return
+	}
+	var dto models.UpdateAmenityDTO
+	if err := c.ShouldBindJSON(&dto); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid body"})
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+	defer cancel()
+
+	amenity, err := h.amenityService.Update(ctx, id, dto)
+	if err != nil {
+		writeAmenityError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, amenity)
+}
+
+// Delete удалить удобство из справочника (и у всех отелей и комнат)
+// @Summary Удалить удобство
+// @Tags admin
+// @Security BearerAuth
+// @Produce json
+// @Param id path int true "ID удобства"
+// @Success 204 "Удалено"
+// @Failure 400 {object} map[string]string "invalid id"
+// @Failure 401 {object} map[string]string "unauthorized"
+// @Failure 403 {object} map[string]string "access denied"
+// @Failure 404 {object} map[string]string "not found"
+// @Failure 500 {object} map[string]string "internal server error"
+// @Router /admin/amenities/{id} [delete]
+func (h AmenityHandler) Delete(c *gin.Context) {
+	id, ok := parseIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+	defer cancel()
+
+	if err := h.amenityService.Delete(ctx, id); err != nil {
+		writeAmenityError(c, err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// SetHotelAmenities заменить набор удобств отеля
+// @Summary Задать удобства отеля
+// @Tags admin
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param id path int true "ID отеля"
+// @Param input body models.SetAmenitiesDTO true "Полный список кодов удобств"
+// @Success 200 {object} models.SetAmenitiesDTO
+// @Failure 400 {object} map[string]string "invalid id | invalid body | invalid input"
+// @Failure 401 {object} map[string]string "unauthorized"
+// @Failure 403 {object} map[string]string "access denied"
+// @Failure 404 {object} map[string]string "not found"
+// @Failure 500 {object} map[string]string "internal server error"
+// @Router /admin/hotels/{id}/amenities [put]
+func (h AmenityHandler) SetHotelAmenities(c *gin.Context) {
+	id, ok := parseIDParam(c, "id")
+	if !ok {
+		return
+	}
+	var dto models.SetAmenitiesDTO
+	if err := c.ShouldBindJSON(&dto); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid body"})
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+	defer cancel()
+
+	codes, err := h.amenityService.SetHotelAmenities(ctx, id, dto.Codes)
+	if err != nil {
+		writeAmenityError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, models.SetAmenitiesDTO{Codes: codes})
+}
+
+// SetRoomAmenities заменить набор удобств комнаты
+// @Summary Задать удобства комнаты
+// @Tags admin
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param id path int true "ID комнаты"
+// @Param input body models.SetAmenitiesDTO true "Полный список кодов удобств"
+// @Success 200 {object} models.SetAmenitiesDTO
+// @Failure 400 {object} map[string]string "invalid id | invalid body | invalid input"
+// @Failure 401 {object} map[string]string "unauthorized"
+// @Failure 403 {object} map[string]string "access denied"
+// @Failure 404 {object} map[string]string "not found"
+// @Failure 500 {object} map[string]string "internal server error"
+// @Router /admin/rooms/{id}/amenities [put]
+func (h AmenityHandler) SetRoomAmenities(c *gin.Context) {
+	id, ok := parseIDParam(c, "id")
+	if !ok {
+		return
+	}
+	var dto models.SetAmenitiesDTO
+	if err := c.ShouldBindJSON(&dto); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid body"})
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+	defer cancel()
+
+	codes, err := h.amenityService.SetRoomAmenities(ctx, id, dto.Codes)
+	if err != nil {
+		writeAmenityError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, models.SetAmenitiesDTO{Codes: codes})
+}
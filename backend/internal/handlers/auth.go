@@ -8,38 +8,44 @@ import (
 	"errors"
 	"log"
 	"net/http"
+	"strconv"
 	"time"
 
 	"github.com/gin-gonic/gin"
 )
 
 type AuthHandler struct {
-	authService services.AuthServiceInterface
-	jwtService  *services.JWTService
+	authService    services.AuthServiceInterface
+	tokenService   services.TokenServiceInterface
+	sessionService services.SessionServiceInterface
+	accountService services.AccountServiceInterface
 }
 
-func NewAuthHandler(authService services.AuthServiceInterface, jwtService *services.JWTService) *AuthHandler {
+func NewAuthHandler(authService services.AuthServiceInterface, tokenService services.TokenServiceInterface, sessionService services.SessionServiceInterface, accountService services.AccountServiceInterface) *AuthHandler {
 	return &AuthHandler{
-		authService: authService,
-		jwtService:  jwtService,
+		authService:    authService,
+		tokenService:   tokenService,
+		sessionService: sessionService,
+		accountService: accountService,
 	}
 }
 
 // Register обработчик регистрации
 // @Summary Регистрация пользователя
+// @Description На указанный email уходит ссылка подтверждения. Пока адрес не подтвержден, нельзя оставлять отзывы.
 // @Tags auth
 // @Accept json
 // @Produce json
 // @Param input body models.CreateUserDTO true "Данные регистрации"
 // @Success 201 {integer} int64 "ID созданного пользователя"
-// @Failure 400 {object} map[string]string "Неверные данные запроса или пользователь уже существует"
+// @Failure 400 {object} map[string]any "Неверные данные запроса (fields — ошибки по полям, erors.FieldError) или пользователь уже существует"
 // @Failure 500 {object} map[string]string "Внутренняя ошибка сервера"
 // @Router /auth/register [post]
 func (h *AuthHandler) Register(c *gin.Context) {
 	var input models.CreateUserDTO
 
 	if err := c.ShouldBindJSON(&input); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Неверные данные запроса"})
+		writeValidationError(c, bindingError(err, &input))
 		return
 	}
 
@@ -52,7 +58,6 @@ func (h *AuthHandler) Register(c *gin.Context) {
 		Password:    input.Password,
 		City:        input.City,
 		DateOfBirth: input.DateOfBirth,
-		Role:        input.Role,
 	}
 
 	id, err := h.authService.RegisterUser(ctx, userDTO)
@@ -61,10 +66,19 @@ func (h *AuthHandler) Register(c *gin.Context) {
 			c.JSON(http.StatusBadRequest, gin.H{"error": "Пользователь уже существует"})
 			return
 		}
+		if errors.Is(err, erors.ErrInvalidInput) {
+			writeValidationError(c, err)
+			return
+		}
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Внутренняя ошибка сервера"})
 		return
 	}
 
+	// Аккаунт уже создан: если письмо не ушло, его можно запросить повторно
+	if err := h.accountService.SendVerification(ctx, id); err != nil {
+		log.Printf("send verification email to user %d: %v", id, err)
+	}
+
 	c.JSON(http.StatusCreated, id)
 }
 
@@ -77,6 +91,7 @@ func (h *AuthHandler) Register(c *gin.Context) {
 // @Success 200 {object} models.AuthResponse "Пара токенов"
 // @Failure 400 {object} map[string]string "Неверные данные запроса"
 // @Failure 401 {object} map[string]string "Неверные учетные данные"
+// @Failure 429 {object} map[string]any "Слишком много неудачных попыток: error и retry_after (секунд), заголовок Retry-After"
 // @Failure 500 {object} map[string]string "Внутренняя ошибка сервера"
 // @Router /auth/login [post]
 func (h *AuthHandler) Login(c *gin.Context) {
@@ -90,24 +105,270 @@ func (h *AuthHandler) Login(c *gin.Context) {
 	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
 	defer cancel()
 
-	user, err := h.authService.LoginUser(ctx, input.Email, input.Password)
+	user, err := h.authService.LoginUser(ctx, input.Email, input.Password, c.ClientIP())
 	if err != nil {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "Неверные учетные данные"})
+		var locked *erors.LoginLockedError
+		switch {
+		case errors.As(err, &locked):
+			// Округляем вверх: клиент, повторивший запрос ровно через Retry-After, уже не упрется в запрет
+			retryAfter := int((locked.RetryAfter + time.Second - 1) / time.Second)
+			c.Header("Retry-After", strconv.Itoa(retryAfter))
+			c.JSON(http.StatusTooManyRequests, gin.H{"error": "Слишком много неудачных попыток входа, повторите позже", "retry_after": retryAfter})
+		case errors.Is(err, erors.ErrInvalidCredentials):
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "Неверные учетные данные"})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Внутренняя ошибка сервера"})
+		}
 		return
 	}
 
 	log.Printf("User role: %s", user.Role)
 
-	accessToken, refreshToken, err := h.jwtService.GenerateTokenPair(user)
+	response, err := h.tokenService.IssueTokenPair(ctx, user)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Внутренняя ошибка сервера"})
 		return
 	}
 
-	response := models.AuthResponse{
-		AccessToken:  accessToken,
-		RefreshToken: refreshToken,
+	c.JSON(http.StatusOK, response)
+}
+
+// Refresh обработчик обновления токенов
+// @Summary Обновить пару токенов
+// @Description Refresh token одноразовый: в ответе выдается новая пара, старый токен больше не принимается.
+// @Description Повторное использование старого токена отзывает все токены, полученные от того же логина.
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param input body models.RefreshTokenDTO true "Refresh token"
+// @Success 200 {object} models.AuthResponse "Новая пара токенов"
+// @Failure 400 {object} map[string]string "Неверные данные запроса"
+// @Failure 401 {object} map[string]string "Недействительный refresh token"
+// @Failure 500 {object} map[string]string "Внутренняя ошибка сервера"
+// @Router /auth/refresh [post]
+func (h *AuthHandler) Refresh(c *gin.Context) {
+	var input models.RefreshTokenDTO
+
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Неверные данные запроса"})
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+	defer cancel()
+
+	response, err := h.tokenService.Refresh(ctx, input.RefreshToken)
+	if err != nil {
+		if errors.Is(err, erors.ErrInvalidToken) || errors.Is(err, erors.ErrTokenReused) {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "Недействительный refresh token"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Внутренняя ошибка сервера"})
+		return
 	}
 
 	c.JSON(http.StatusOK, response)
 }
+
+// Logout завершение текущей сессии
+// @Summary Выйти из текущей сессии
+// @Description Отзывает сессию текущего access-токена и все ее refresh-токены
+// @Tags auth
+// @Security BearerAuth
+// @Produce json
+// @Success 204 "Сессия завершена"
+// @Failure 401 {object} map[string]string "user authentication required"
+// @Failure 500 {object} map[string]string "Внутренняя ошибка сервера"
+// @Router /auth/logout [post]
+func (h *AuthHandler) Logout(c *gin.Context) {
+	userID, err := getUserId(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "user authentication required"})
+		return
+	}
+	sessionID := c.GetString("sessionid")
+	if sessionID == "" {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "user authentication required"})
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+	defer cancel()
+
+	if err := h.sessionService.Revoke(ctx, userID, sessionID); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Внутренняя ошибка сервера"})
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// LogoutAll завершение всех сессий пользователя
+// @Summary Выйти со всех устройств
+// @Description Отзывает все сессии и refresh-токены текущего пользователя
+// @Tags auth
+// @Security BearerAuth
+// @Produce json
+// @Success 204 "Все сессии завершены"
+// @Failure 401 {object} map[string]string "user authentication required"
+// @Failure 500 {object} map[string]string "Внутренняя ошибка сервера"
+// @Router /auth/logout-all [post]
+func (h *AuthHandler) LogoutAll(c *gin.Context) {
+	userID, err := getUserId(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "user authentication required"})
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+	defer cancel()
+
+	if err := h.sessionService.RevokeAll(ctx, userID); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Внутренняя ошибка сервера"})
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// VerifyEmail подтверждение email по ссылке из письма
+// @Summary Подтвердить email
+// @Description Токен одноразовый и действует ограниченное время (account.verify_token_ttl).
+// @Description После смены email ссылки, отправленные на прежний адрес, не действуют.
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param input body models.VerifyEmailDTO true "Токен из письма"
+// @Success 204 "Email подтвержден"
+// @Failure 400 {object} map[string]string "Неверные данные запроса | Ссылка недействительна или устарела"
+// @Failure 500 {object} map[string]string "Внутренняя ошибка сервера"
+// @Router /auth/verify-email [post]
+func (h *AuthHandler) VerifyEmail(c *gin.Context) {
+	var input models.VerifyEmailDTO
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Неверные данные запроса"})
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+	defer cancel()
+
+	if err := h.accountService.VerifyEmail(ctx, input.Token); err != nil {
+		if errors.Is(err, erors.ErrInvalidToken) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Ссылка недействительна или устарела"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Внутренняя ошибка сервера"})
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// ResendVerification повторная отправка ссылки подтверждения
+// @Summary Отправить ссылку подтверждения повторно
+// @Description Ссылка уходит на текущий email пользователя, ранее отправленные ссылки перестают действовать.
+// @Tags auth
+// @Security BearerAuth
+// @Produce json
+// @Success 202 "Письмо отправлено"
+// @Failure 400 {object} map[string]string "Нет адреса для подтверждения"
+// @Failure 401 {object} map[string]string "user authentication required"
+// @Failure 409 {object} map[string]string "Email уже подтвержден"
+// @Failure 500 {object} map[string]string "Внутренняя ошибка сервера"
+// @Router /auth/verify-email/resend [post]
+func (h *AuthHandler) ResendVerification(c *gin.Context) {
+	userID, err := getUserId(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "user authentication required"})
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+	defer cancel()
+
+	if err := h.accountService.SendVerification(ctx, userID); err != nil {
+		switch {
+		case errors.Is(err, erors.ErrEmailAlreadyVerified):
+			c.JSON(http.StatusConflict, gin.H{"error": "Email уже подтвержден"})
+		case errors.Is(err, erors.ErrInvalidInput):
+			// Служебный адрес входа через Telegram/OAuth: сначала нужно указать настоящий email
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Нет адреса для подтверждения"})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Внутренняя ошибка сервера"})
+		}
+		return
+	}
+
+	c.Status(http.StatusAccepted)
+}
+
+// ForgotPassword запрос ссылки для сброса пароля
+// @Summary Забыли пароль
+// @Description Если адрес зарегистрирован, на него уходит ссылка сброса пароля (account.reset_token_ttl).
+// @Description Ответ не зависит от того, существует ли учетная запись.
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param input body models.ForgotPasswordDTO true "Email"
+// @Success 202 "Запрос принят"
+// @Failure 400 {object} map[string]string "Неверные данные запроса"
+// @Failure 500 {object} map[string]string "Внутренняя ошибка сервера"
+// @Router /auth/password/forgot [post]
+func (h *AuthHandler) ForgotPassword(c *gin.Context) {
+	var input models.ForgotPasswordDTO
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Неверные данные запроса"})
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+	defer cancel()
+
+	if err := h.accountService.ForgotPassword(ctx, input.Email); err != nil {
+		if errors.Is(err, erors.ErrInvalidInput) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Неверные данные запроса"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Внутренняя ошибка сервера"})
+		return
+	}
+
+	c.Status(http.StatusAccepted)
+}
+
+// ResetPassword установка нового пароля по ссылке из письма
+// @Summary Сбросить пароль
+// @Description Токен одноразовый. После сброса все сессии пользователя завершаются, email считается подтвержденным.
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param input body models.ResetPasswordDTO true "Токен из письма и новый пароль"
+// @Success 204 "Пароль изменен"
+// @Failure 400 {object} map[string]any "Неверные данные запроса (fields — ошибки по полям) | Ссылка недействительна или устарела"
+// @Failure 500 {object} map[string]string "Внутренняя ошибка сервера"
+// @Router /auth/password/reset [post]
+func (h *AuthHandler) ResetPassword(c *gin.Context) {
+	var input models.ResetPasswordDTO
+	if err := c.ShouldBindJSON(&input); err != nil {
+		writeValidationError(c, bindingError(err, &input))
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+	defer cancel()
+
+	if err := h.accountService.ResetPassword(ctx, input.Token, input.Password); err != nil {
+		switch {
+		case errors.Is(err, erors.ErrInvalidToken):
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Ссылка недействительна или устарела"})
+		case errors.Is(err, erors.ErrInvalidInput):
+			writeValidationError(c, err)
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Внутренняя ошибка сервера"})
+		}
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
@@ -0,0 +1,242 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"backend/internal/erors"
+	"backend/internal/models"
+	"backend/internal/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+type BookingHandler struct {
+	bookingService services.BookingServiceInterface
+}
+
+func NewBookingHandler(bookingService services.BookingServiceInterface) BookingHandler {
+	return BookingHandler{bookingService: bookingService}
+}
+
+func writeBookingError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, erors.ErrInvalidInput):
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid input"})
+	case errors.Is(err, erors.ErrNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": "booking not found"})
+	case errors.Is(err, erors.ErrRoomUnavailable):
+		c.JSON(http.StatusConflict, gin.H{"error": "room is not available for the selected dates"})
+	case errors.Is(err, erors.ErrNotHotelOwner):
+		c.JSON(http.StatusForbidden, gin.H{"error": "access denied"})
+	case errors.Is(err, erors.ErrBookingNotCancellable):
+		c.JSON(http.StatusConflict, gin.H{"error": "booking cannot be cancelled"})
+	case errors.Is(err, erors.ErrBookingNotConfirmable):
+		c.JSON(http.StatusConflict, gin.H{"error": "booking cannot be confirmed"})
+	case errors.Is(err, erors.ErrBookingNotCompletable):
+		c.JSON(http.StatusConflict, gin.H{"error": "booking cannot be completed"})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+	}
+}
+
+// Create создать бронь
+// @Summary Забронировать комнату
+// @Tags bookings
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param input body models.CreateBookingDTO true "Комната, даты и гости"
+// @Success 201 {object} models.Booking
+// @Failure 400 {object} map[string]string "invalid body | invalid input"
+// @Failure 401 {object} map[string]string "user authentication required"
+// @Failure 409 {object} map[string]string "room is not available for the selected dates"
+// @Failure 500 {object} map[string]string "internal server error"
+// @Router /bookings [post]
+func (h BookingHandler) Create(c *gin.Context) {
+	userID, err := getUserId(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "user authentication required"})
+		return
+	}
+
+	var dto models.CreateBookingDTO
+	if err := c.ShouldBindJSON(&dto); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid body"})
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+	defer cancel()
+
+	booking, err := h.bookingService.CreateBooking(ctx, userID, dto)
+	if err != nil {
+		writeBookingError(c, err)
+		return
+	}
+	c.JSON(http.StatusCreated, booking)
+}
+
+// List брони текущего пользователя
+// @Summary Список броней текущего пользователя
+// @Tags bookings
+// @Security BearerAuth
+// @Produce json
+// @Success 200 {array} models.Booking
+// @Failure 401 {object} map[string]string "user authentication required"
+// @Failure 500 {object} map[string]string "internal server error"
+// @Router /bookings [get]
+func (h BookingHandler) List(c *gin.Context) {
+	userID, err := getUserId(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "user authentication required"})
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+	defer cancel()
+
+	bookings, err := h.bookingService.ListByUserID(ctx, userID)
+	if err != nil {
+		writeBookingError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, bookings)
+}
+
+// GetByID получить бронь по ID
+// @Summary Получить бронь по ID
+// @Tags bookings
+// @Security BearerAuth
+// @Produce json
+// @Param id path int true "ID брони"
+// @Success 200 {object} models.Booking
+// @Failure 400 {object} map[string]string "invalid booking id"
+// @Failure 401 {object} map[string]string "user authentication required"
+// @Failure 404 {object} map[string]string "booking not found"
+// @Failure 500 {object} map[string]string "internal server error"
+// @Router /bookings/{id} [get]
+func (h BookingHandler) GetByID(c *gin.Context) {
+	userID, err := getUserId(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "user authentication required"})
+		return
+	}
+
+	bookingID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil || bookingID <= 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid booking id"})
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+	defer cancel()
+
+	booking, err := h.bookingService.GetByID(ctx, userID, bookingID)
+	if err != nil {
+		writeBookingError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, booking)
+}
+
+// Cancel отменить бронь
+// @Summary Отменить бронь
+// @Tags bookings
+// @Security BearerAuth
+// @Produce json
+// @Param id path int true "ID брони"
+// @Success 200 {object} models.Booking
+// @Failure 400 {object} map[string]string "invalid booking id"
+// @Failure 401 {object} map[string]string "user authentication required"
+// @Failure 404 {object} map[string]string "booking not found"
+// @Failure 409 {object} map[string]string "booking cannot be cancelled"
+// @Failure 500 {object} map[string]string "internal server error"
+// @Router /bookings/{id}/cancel [post]
+func (h BookingHandler) Cancel(c *gin.Context) {
+	userID, err := getUserId(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "user authentication required"})
+		return
+	}
+
+	bookingID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil || bookingID <= 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid booking id"})
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+	defer cancel()
+
+	booking, err := h.bookingService.CancelBooking(ctx, userID, bookingID)
+	if err != nil {
+		writeBookingError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, booking)
+}
+
+// Confirm подтвердить бронь
+// @Summary Подтвердить бронь
+// @Description Владелец отеля или администратор подтверждает ожидающую бронь (pending → confirmed).
+// @Tags bookings
+// @Security BearerAuth
+// @Produce json
+// @Param id path int true "ID брони"
+// @Success 200 {object} models.Booking
+// @Failure 400 {object} map[string]string "invalid id | invalid input"
+// @Failure 401 {object} map[string]string "user authentication required"
+// @Failure 403 {object} map[string]string "access denied"
+// @Failure 404 {object} map[string]string "booking not found"
+// @Failure 409 {object} map[string]string "booking cannot be confirmed"
+// @Failure 500 {object} map[string]string "internal server error"
+// @Router /bookings/{id}/confirm [post]
+func (h BookingHandler) Confirm(c *gin.Context) {
+	h.changeStatus(c, h.bookingService.ConfirmBooking)
+}
+
+// Complete завершить проживание
+// @Summary Отметить проживание завершенным
+// @Description Владелец отеля или администратор завершает подтвержденную бронь (confirmed → completed) не раньше даты выезда.
+// @Tags bookings
+// @Security BearerAuth
+// @Produce json
+// @Param id path int true "ID брони"
+// @Success 200 {object} models.Booking
+// @Failure 400 {object} map[string]string "invalid id | invalid input"
+// @Failure 401 {object} map[string]string "user authentication required"
+// @Failure 403 {object} map[string]string "access denied"
+// @Failure 404 {object} map[string]string "booking not found"
+// @Failure 409 {object} map[string]string "booking cannot be completed"
+// @Failure 500 {object} map[string]string "internal server error"
+// @Router /bookings/{id}/complete [post]
+func (h BookingHandler) Complete(c *gin.Context) {
+	h.changeStatus(c, h.bookingService.CompleteBooking)
+}
+
+// changeStatus общая часть смены статуса брони отелем
+func (h BookingHandler) changeStatus(c *gin.Context, change func(ctx context.Context, userID int64, role string, bookingID int64) (models.Booking, error)) {
+	userID, err := getUserId(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "user authentication required"})
+		return
+	}
+	bookingID, ok := parseIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+	defer cancel()
+
+	booking, err := change(ctx, userID, c.GetString("userRole"), bookingID)
+	if err != nil {
+		writeBookingError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, booking)
+}
@@ -0,0 +1,66 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"backend/internal/erors"
+	"backend/internal/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+type FeedHandler struct {
+	feedService services.FeedServiceInterface
+}
+
+func NewFeedHandler(feedService services.FeedServiceInterface) FeedHandler {
+	return FeedHandler{feedService: feedService}
+}
+
+// List лента активности друзей
+// @Summary Лента друзей
+// @Description Отзывы, избранное и завершенные проживания друзей по убыванию времени.
+// @Description Действия, скрытые автором в настройках приватности, не показываются.
+// @Tags feed
+// @Security BearerAuth
+// @Produce json
+// @Param cursor query string false "Курсор из next_cursor предыдущей страницы"
+// @Param limit query int false "Размер страницы (по умолчанию 20, до 50)"
+// @Success 200 {object} models.FeedResponse
+// @Failure 400 {object} map[string]string "invalid input"
+// @Failure 401 {object} map[string]string "user authentication required"
+// @Failure 500 {object} map[string]string "internal server error"
+// @Router /feed [get]
+func (h FeedHandler) List(c *gin.Context) {
+	userID, err := getUserId(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "user authentication required"})
+		return
+	}
+
+	var limit int
+	if v := c.Query("limit"); v != "" {
+		if limit, err = strconv.Atoi(v); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid input"})
+			return
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+	defer cancel()
+
+	res, err := h.feedService.List(ctx, userID, c.Query("cursor"), limit)
+	if err != nil {
+		if errors.Is(err, erors.ErrInvalidInput) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid input"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+		return
+	}
+	c.JSON(http.StatusOK, res)
+}
@@ -0,0 +1,271 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	"backend/internal/erors"
+	"backend/internal/models"
+	"backend/internal/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+type FriendHandler struct {
+	friendService services.FriendServiceInterface
+}
+
+func NewFriendHandler(friendService services.FriendServiceInterface) FriendHandler {
+	return FriendHandler{friendService: friendService}
+}
+
+func writeFriendError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, erors.ErrInvalidInput):
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid input"})
+	case errors.Is(err, erors.ErrUserNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": "user not found"})
+	case errors.Is(err, erors.ErrNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": "not found"})
+	case errors.Is(err, erors.ErrForbidden):
+		c.JSON(http.StatusForbidden, gin.H{"error": "access denied"})
+	case errors.Is(err, erors.ErrConflict):
+		c.JSON(http.StatusConflict, gin.H{"error": "already friends or request pending"})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+	}
+}
+
+// SendRequest заявка в друзья по email или ID
+// @Summary Отправить заявку в друзья
+// @Description Если пользователь уже прислал встречную заявку, она принимается
+// @Tags friends
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param input body models.FriendRequestDTO true "Email или ID пользователя"
+// @Success 201 {object} models.Friend
+// @Failure 400 {object} map[string]string "invalid body | invalid input"
+// @Failure 401 {object} map[string]string "user authentication required"
+// @Failure 403 {object} map[string]string "access denied"
+// @Failure 404 {object} map[string]string "user not found"
+// @Failure 409 {object} map[string]string "already friends or request pending"
+// @Failure 500 {object} map[string]string "internal server error"
+// @Router /users/friends [post]
+func (h FriendHandler) SendRequest(c *gin.Context) {
+	userID, err := getUserId(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "user authentication required"})
+		return
+	}
+
+	var dto models.FriendRequestDTO
+	if err := c.ShouldBindJSON(&dto); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid body"})
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+	defer cancel()
+
+	friend, err := h.friendService.SendRequest(ctx, userID, dto)
+	if err != nil {
+		writeFriendError(c, err)
+		return
+	}
+	c.JSON(http.StatusCreated, friend)
+}
+
+// Accept принять входящую заявку
+// @Summary Принять заявку в друзья
+// @Tags friends
+// @Security BearerAuth
+// @Produce json
+// @Param userid path int true "ID отправителя заявки"
+// @Success 200 {object} models.Friend
+// @Failure 400 {object} map[string]string "invalid userid | invalid input"
+// @Failure 401 {object} map[string]string "user authentication required"
+// @Failure 404 {object} map[string]string "not found"
+// @Failure 500 {object} map[string]string "internal server error"
+// @Router /users/friends/requests/{userid}/accept [post]
+func (h FriendHandler) Accept(c *gin.Context) {
+	userID, err := getUserId(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "user authentication required"})
+		return
+	}
+	fromID, ok := parseIDParam(c, "userid")
+	if !ok {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+	defer cancel()
+
+	friend, err := h.friendService.AcceptRequest(ctx, userID, fromID)
+	if err != nil {
+		writeFriendError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, friend)
+}
+
+// Decline отклонить входящую заявку
+// @Summary Отклонить заявку в друзья
+// @Tags friends
+// @Security BearerAuth
+// @Produce json
+// @Param userid path int true "ID отправителя заявки"
+// @Success 204 "Отклонено"
+// @Failure 400 {object} map[string]string "invalid userid | invalid input"
+// @Failure 401 {object} map[string]string "user authentication required"
+// @Failure 404 {object} map[string]string "not found"
+// @Failure 500 {object} map[string]string "internal server error"
+// @Router /users/friends/requests/{userid}/decline [post]
+func (h FriendHandler) Decline(c *gin.Context) {
+	h.modify(c, h.friendService.DeclineRequest)
+}
+
+// Remove удалить друга или отменить заявку
+// @Summary Удалить из друзей
+// @Description Удаляет дружбу или заявку в любом направлении
+// @Tags friends
+// @Security BearerAuth
+// @Produce json
+// @Param userid path int true "ID пользователя"
+// @Success 204 "Удалено"
+// @Failure 400 {object} map[string]string "invalid userid | invalid input"
+// @Failure 401 {object} map[string]string "user authentication required"
+// @Failure 404 {object} map[string]string "not found"
+// @Failure 500 {object} map[string]string "internal server error"
+// @Router /users/friends/{userid} [delete]
+func (h FriendHandler) Remove(c *gin.Context) {
+	h.modify(c, h.friendService.RemoveFriend)
+}
+
+// Block заблокировать пользователя
+// @Summary Заблокировать пользователя
+// @Description Разрывает дружбу и удаляет заявки; заблокированный не сможет отправить заявку
+// @Tags friends
+// @Security BearerAuth
+// @Produce json
+// @Param userid path int true "ID пользователя"
+// @Success 204 "Заблокирован"
+// @Failure 400 {object} map[string]string "invalid userid | invalid input"
+// @Failure 401 {object} map[string]string "user authentication required"
+// @Failure 404 {object} map[string]string "user not found"
+// @Failure 500 {object} map[string]string "internal server error"
+// @Router /users/blocks/{userid} [post]
+func (h FriendHandler) Block(c *gin.Context) {
+	h.modify(c, h.friendService.Block)
+}
+
+// Unblock снять блокировку
+// @Summary Разблокировать пользователя
+// @Tags friends
+// @Security BearerAuth
+// @Produce json
+// @Param userid path int true "ID пользователя"
+// @Success 204 "Разблокирован"
+// @Failure 400 {object} map[string]string "invalid userid | invalid input"
+// @Failure 401 {object} map[string]string "user authentication required"
+// @Failure 404 {object} map[string]string "not found"
+// @Failure 500 {object} map[string]string "internal server error"
+// @Router /users/blocks/{userid} [delete]
+func (h FriendHandler) Unblock(c *gin.Context) {
+	h.modify(c, h.friendService.Unblock)
+}
+
+// modify общая обработка действий над связью с пользователем из пути; ответ — 204
+func (h FriendHandler) modify(c *gin.Context, action func(ctx context.Context, userID, otherID int64) error) {
+	userID, err := getUserId(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "user authentication required"})
+		return
+	}
+	otherID, ok := parseIDParam(c, "userid")
+	if !ok {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+	defer cancel()
+
+	if err := action(ctx, userID, otherID); err != nil {
+		writeFriendError(c, err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// List друзья текущего пользователя
+// @Summary Список друзей
+// @Tags friends
+// @Security BearerAuth
+// @Produce json
+// @Success 200 {array} models.Friend
+// @Failure 401 {object} map[string]string "user authentication required"
+// @Failure 500 {object} map[string]string "internal server error"
+// @Router /users/friends [get]
+func (h FriendHandler) List(c *gin.Context) {
+	h.list(c, h.friendService.ListFriends)
+}
+
+// ListIncoming входящие заявки
+// @Summary Входящие заявки в друзья
+// @Tags friends
+// @Security BearerAuth
+// @Produce json
+// @Success 200 {array} models.Friend
+// @Failure 401 {object} map[string]string "user authentication required"
+// @Failure 500 {object} map[string]string "internal server error"
+// @Router /users/friends/requests/incoming [get]
+func (h FriendHandler) ListIncoming(c *gin.Context) {
+	h.list(c, h.friendService.ListIncoming)
+}
+
+// ListOutgoing исходящие заявки
+// @Summary Исходящие заявки в друзья
+// @Tags friends
+// @Security BearerAuth
+// @Produce json
+// @Success 200 {array} models.Friend
+// @Failure 401 {object} map[string]string "user authentication required"
+// @Failure 500 {object} map[string]string "internal server error"
+// @Router /users/friends/requests/outgoing [get]
+func (h FriendHandler) ListOutgoing(c *gin.Context) {
+	h.list(c, h.friendService.ListOutgoing)
+}
+
+// ListBlocked заблокированные пользователи
+// @Summary Заблокированные пользователи
+// @Tags friends
+// @Security BearerAuth
+// @Produce json
+// @Success 200 {array} models.Friend
+// @Failure 401 {object} map[string]string "user authentication required"
+// @Failure 500 {object} map[string]string "internal server error"
+// @Router /users/blocks [get]
+func (h FriendHandler) ListBlocked(c *gin.Context) {
+	h.list(c, h.friendService.ListBlocked)
+}
+
+func (h FriendHandler) list(c *gin.Context, fetch func(ctx context.Context, userID int64) ([]models.Friend, error)) {
+	userID, err := getUserId(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "user authentication required"})
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+	defer cancel()
+
+	friends, err := fetch(ctx, userID)
+	if err != nil {
+		writeFriendError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, friends)
+}
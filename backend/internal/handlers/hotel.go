@@ -24,7 +24,7 @@ func NewHotelHandler(hotelServ services.HotelServiceInterface) HotelHandler {
 	return HotelHandler{hotelServ: hotelServ}
 }
 
-// Create создание отеля (admin)
+// Create создание отеля (доступ проверяет RequirePermission)
 // @Summary Создать отель
 // @Tags hotels
 // @Security BearerAuth
@@ -32,18 +32,11 @@ func NewHotelHandler(hotelServ services.HotelServiceInterface) HotelHandler {
 // @Produce json
 // @Param input body models.Hotel true "Данные отеля"
 // @Success 201 {object} models.Hotel
-// @Failure 400 {object} map[string]string "Неверные данные запроса"
+// @Failure 400 {object} map[string]string "Неверные данные запроса | invalid input"
 // @Failure 403 {object} map[string]string "Access denied"
 // @Failure 500 {object} map[string]string "failed to create hotel"
 // @Router /hotels [post]
 func (h HotelHandler) Create(c *gin.Context) {
-	roleVal, exists := c.Get("userRole")
-	role, ok := roleVal.(string)
-	if !exists || !ok || role != "admin" {
-		c.JSON(http.StatusForbidden, gin.H{"error": "Access denied"})
-		return
-	}
-
 	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
 	defer cancel()
 
@@ -54,6 +47,10 @@ func (h HotelHandler) Create(c *gin.Context) {
 	}
 
 	if err := h.hotelServ.CreateHotel(ctx, &hotel); err != nil {
+		if errors.Is(err, erors.ErrInvalidInput) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid input"})
+			return
+		}
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to create hotel"})
 		return
 	}
@@ -61,20 +58,68 @@ func (h HotelHandler) Create(c *gin.Context) {
 	c.JSON(http.StatusCreated, hotel)
 }
 
-// List список всех отелей
-// @Summary Получить список всех отелей
+// parseHotelFilter разбирает query-параметры списка отелей; имена совпадают с HotelSearchParams фронтенда
+func parseHotelFilter(c *gin.Context) (models.HotelFilter, error) {
+	var f models.HotelFilter
+	var err error
+	intParam := func(name string, dst *int) {
+		if v := strings.TrimSpace(c.Query(name)); v != "" && err == nil {
+			*dst, err = strconv.Atoi(v)
+		}
+	}
+	intParam("page", &f.Page)
+	intParam("limit", &f.Limit)
+	intParam("minStars", &f.MinStars)
+	intParam("minPrice", &f.MinPrice)
+	intParam("maxPrice", &f.MaxPrice)
+	if v := strings.TrimSpace(c.Query("minRating")); v != "" && err == nil {
+		f.MinRating, err = strconv.ParseFloat(v, 64)
+	}
+	f.City = strings.TrimSpace(c.Query("city"))
+	f.SortBy = strings.TrimSpace(c.Query("sortBy"))
+	f.SortOrder = strings.ToLower(strings.TrimSpace(c.Query("sortOrder")))
+	f.Amenities = parseAmenitiesQuery(c)
+	return f, err
+}
+
+// List список отелей с фильтрами и пагинацией
+// @Summary Получить список отелей
 // @Tags hotels
 // @Produce json
-// @Success 200 {array} models.Hotel
+// @Param page query int false "Номер страницы (с 1)" default(1)
+// @Param limit query int false "Размер страницы (1-100)" default(20)
+// @Param city query string false "Город"
+// @Param minStars query int false "Минимум звёзд"
+// @Param minPrice query int false "Минимальная цена за ночь"
+// @Param maxPrice query int false "Максимальная цена за ночь"
+// @Param minRating query number false "Минимальный рейтинг"
+// @Param sortBy query string false "Сортировка: price | rating | stars"
+// @Param sortOrder query string false "Направление: asc | desc" default(asc)
+// @Param amenities query string false "Коды удобств через запятую; нужны все (wifi,pool)"
+// @Success 200 {object} models.HotelListResponse
+// @Failure 400 {object} map[string]string "invalid input"
 // @Failure 500 {object} map[string]string "Failed to get hotels"
 // @Router /hotels [get]
 func (h HotelHandler) List(c *gin.Context) {
-	hotels, err := h.hotelServ.GetAll(c)
+	filter, err := parseHotelFilter(c)
 	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid input"})
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+	defer cancel()
+
+	res, err := h.hotelServ.List(ctx, filter)
+	if err != nil {
+		if errors.Is(err, erors.ErrInvalidInput) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid input"})
+			return
+		}
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get hotels"})
 		return
 	}
-	c.JSON(http.StatusOK, hotels)
+	c.JSON(http.StatusOK, res)
 }
 
 // GetByID получить отель по ID
@@ -103,6 +148,34 @@ func (h HotelHandler) GetByID(c *gin.Context) {
 	c.JSON(http.StatusOK, hotel)
 }
 
+// RatingSummary сводка оценок отеля
+// @Summary Сводка оценок отеля
+// @Description Средние оценки отеля и его комнат, число одобренных отзывов и распределение оценок отеля 1..5.
+// @Tags hotels
+// @Produce json
+// @Param hotelid path int true "ID отеля"
+// @Success 200 {object} models.RatingSummary
+// @Failure 400 {object} map[string]string "invalid hotelid"
+// @Failure 404 {object} map[string]string "hotel not found"
+// @Failure 500 {object} map[string]string "internal server error"
+// @Router /hotels/{hotelid}/rating-summary [get]
+func (h HotelHandler) RatingSummary(c *gin.Context) {
+	hotelID, ok := parseIDParam(c, "hotelid")
+	if !ok {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+	defer cancel()
+
+	summary, err := h.hotelServ.RatingSummary(ctx, hotelID)
+	if err != nil {
+		writeHotelError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, summary)
+}
+
 // ListByCity список отелей по городу
 // @Summary Получить список отелей по городу
 // @Tags hotels
@@ -134,3 +207,284 @@ func (h HotelHandler) ListByCity(c *gin.Context) {
 	}
 	c.JSON(http.StatusOK, hotels)
 }
+
+// Search полнотекстовый поиск отелей
+// @Summary Полнотекстовый поиск отелей и комнат
+// @Description Ищет по названию, адресу и описанию отеля и описаниям комнат с учетом русской морфологии.
+// @Description Слова объединяются по И; поддерживаются "фразы", or и -исключение. Найденные слова в
+// @Description name_highlight и snippet — HTML: текст экранирован, найденные слова обернуты в <mark></mark>.
+// @Tags hotels
+// @Produce json
+// @Param q query string true "Поисковый запрос" example(у моря с бассейном)
+// @Param page query int false "Номер страницы (с 1)" default(1)
+// @Param limit query int false "Размер страницы (1-100)" default(20)
+// @Success 200 {object} models.HotelSearchResponse
+// @Failure 400 {object} map[string]string "invalid input"
+// @Failure 500 {object} map[string]string "internal server error"
+// @Router /search [get]
+func (h HotelHandler) Search(c *gin.Context) {
+	var page, limit int
+	var err error
+	if v := c.Query("page"); v != "" {
+		if page, err = strconv.Atoi(v); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid input"})
+			return
+		}
+	}
+	if v := c.Query("limit"); v != "" {
+		if limit, err = strconv.Atoi(v); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid input"})
+			return
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+	defer cancel()
+
+	res, err := h.hotelServ.Search(ctx, c.Query("q"), page, limit)
+	if err != nil {
+		writeHotelError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, res)
+}
+
+// parseFloatQuery читает обязательный числовой query-параметр
+func parseFloatQuery(c *gin.Context, name string) (float64, bool) {
+	v, err := strconv.ParseFloat(strings.TrimSpace(c.Query(name)), 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid " + name})
+		return 0, false
+	}
+	return v, true
+}
+
+// parseOptionalQuery читает необязательный числовой query-параметр; пустое значение — ноль
+func parseOptionalQuery(c *gin.Context, name string) (float64, bool) {
+	if strings.TrimSpace(c.Query(name)) == "" {
+		return 0, true
+	}
+	return parseFloatQuery(c, name)
+}
+
+// parseLimitQuery читает необязательный целый параметр limit; пустое значение — ноль
+func parseLimitQuery(c *gin.Context) (int, bool) {
+	v := strings.TrimSpace(c.Query("limit"))
+	if v == "" {
+		return 0, true
+	}
+	limit, err := strconv.Atoi(v)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid limit"})
+		return 0, false
+	}
+	return limit, true
+}
+
+// Nearby отели в радиусе от точки
+// @Summary Отели рядом с точкой
+// @Description Расстояние считается по формуле гаверсинусов; результат отсортирован по удаленности
+// @Tags hotels
+// @Produce json
+// @Param lat query number true "Широта центра"
+// @Param lng query number true "Долгота центра"
+// @Param radius_km query number false "Радиус в км (до 500)" default(10)
+// @Param limit query int false "Максимум отелей (до 500)" default(50)
+// @Success 200 {array} models.Hotel
+// @Failure 400 {object} map[string]string "invalid lat | invalid lng | invalid radius_km | invalid limit | invalid input"
+// @Failure 500 {object} map[string]string "internal server error"
+// @Router /hotels/nearby [get]
+func (h HotelHandler) Nearby(c *gin.Context) {
+	var f models.NearbyFilter
+	var ok bool
+	if f.Lat, ok = parseFloatQuery(c, "lat"); !ok {
+		return
+	}
+	if f.Lng, ok = parseFloatQuery(c, "lng"); !ok {
+		return
+	}
+	if f.RadiusKm, ok = parseOptionalQuery(c, "radius_km"); !ok {
+		return
+	}
+	if f.Limit, ok = parseLimitQuery(c); !ok {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+	defer cancel()
+
+	hotels, err := h.hotelServ.ListNearby(ctx, f)
+	if err != nil {
+		writeHotelError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, hotels)
+}
+
+// InBoundingBox отели в видимой области карты
+// @Summary Отели в области карты
+// @Description Если min_lng больше max_lng, область пересекает 180-й меридиан
+// @Tags hotels
+// @Produce json
+// @Param min_lat query number true "Южная граница"
+// @Param min_lng query number true "Западная граница"
+// @Param max_lat query number true "Северная граница"
+// @Param max_lng query number true "Восточная граница"
+// @Param limit query int false "Максимум отелей (до 500)" default(50)
+// @Success 200 {array} models.Hotel
+// @Failure 400 {object} map[string]string "invalid min_lat | invalid min_lng | invalid max_lat | invalid max_lng | invalid limit | invalid input"
+// @Failure 500 {object} map[string]string "internal server error"
+// @Router /hotels/bbox [get]
+func (h HotelHandler) InBoundingBox(c *gin.Context) {
+	var box models.BoundingBox
+	var ok bool
+	if box.MinLat, ok = parseFloatQuery(c, "min_lat"); !ok {
+		return
+	}
+	if box.MinLng, ok = parseFloatQuery(c, "min_lng"); !ok {
+		return
+	}
+	if box.MaxLat, ok = parseFloatQuery(c, "max_lat"); !ok {
+		return
+	}
+	if box.MaxLng, ok = parseFloatQuery(c, "max_lng"); !ok {
+		return
+	}
+	if box.Limit, ok = parseLimitQuery(c); !ok {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+	defer cancel()
+
+	hotels, err := h.hotelServ.ListInBoundingBox(ctx, box)
+	if err != nil {
+		writeHotelError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, hotels)
+}
+
+func writeHotelError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, erors.ErrInvalidInput):
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid input"})
+	case errors.Is(err, erors.ErrNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": "hotel not found"})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+	}
+}
+
+// Update частичное обновление отеля (admin)
+// @Summary Частично обновить отель
+// @Tags admin
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param id path int true "ID отеля"
+// @Param input body models.UpdateHotelDTO true "Изменяемые поля"
+// @Success 200 {object} models.Hotel
+// @Failure 400 {object} map[string]string "invalid hotel id | invalid body | invalid input"
+// @Failure 401 {object} map[string]string "unauthorized"
+// @Failure 403 {object} map[string]string "access denied"
+// @Failure 404 {object} map[string]string "hotel not found"
+// @Failure 500 {object} map[string]string "internal server error"
+// @Router /admin/hotels/{id} [patch]
+func (h HotelHandler) Update(c *gin.Context) {
+	hotelID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil || hotelID <= 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid hotel id"})
+		return
+	}
+
+	var dto models.UpdateHotelDTO
+	if err := c.ShouldBindJSON(&dto); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid body"})
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+	defer cancel()
+
+	hotel, err := h.hotelServ.UpdateHotel(ctx, hotelID, dto)
+	if err != nil {
+		writeHotelError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, hotel)
+}
+
+// Replace полное обновление отеля (admin)
+// @Summary Полностью обновить отель
+// @Description Перезаписывает все редактируемые поля: не переданные city, description, address очищаются, без latitude/longitude координаты удаляются.
+// @Tags admin
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param id path int true "ID отеля"
+// @Param input body models.Hotel true "Новые данные отеля"
+// @Success 200 {object} models.Hotel
+// @Failure 400 {object} map[string]string "invalid hotel id | invalid body | invalid input"
+// @Failure 401 {object} map[string]string "unauthorized"
+// @Failure 403 {object} map[string]string "access denied"
+// @Failure 404 {object} map[string]string "hotel not found"
+// @Failure 500 {object} map[string]string "internal server error"
+// @Router /admin/hotels/{id} [put]
+func (h HotelHandler) Replace(c *gin.Context) {
+	hotelID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil || hotelID <= 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid hotel id"})
+		return
+	}
+
+	var input models.Hotel
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid body"})
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+	defer cancel()
+
+	hotel, err := h.hotelServ.ReplaceHotel(ctx, hotelID, input)
+	if err != nil {
+		writeHotelError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, hotel)
+}
+
+// Delete удаление отеля (admin)
+// @Summary Удалить отель
+// @Description По умолчанию удаление мягкое: отель и его комнаты скрываются, история отзывов, избранного и броней сохраняется.
+// @Description hard=true удаляет отель физически вместе со всеми связанными данными.
+// @Tags admin
+// @Security BearerAuth
+// @Produce json
+// @Param id path int true "ID отеля"
+// @Param hard query bool false "Удалить физически"
+// @Success 204 "Удалено"
+// @Failure 400 {object} map[string]string "invalid hotel id | invalid input"
+// @Failure 401 {object} map[string]string "unauthorized"
+// @Failure 403 {object} map[string]string "access denied"
+// @Failure 404 {object} map[string]string "hotel not found"
+// @Failure 500 {object} map[string]string "internal server error"
+// @Router /admin/hotels/{id} [delete]
+func (h HotelHandler) Delete(c *gin.Context) {
+	hotelID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil || hotelID <= 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid hotel id"})
+		return
+	}
+	hard := c.Query("hard") == "true"
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+	defer cancel()
+
+	if err := h.hotelServ.DeleteHotel(ctx, hotelID, hard); err != nil {
+		writeHotelError(c, err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
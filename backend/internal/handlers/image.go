@@ -0,0 +1,356 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"backend/internal/erors"
+	"backend/internal/models"
+	"backend/internal/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ImageHandler галереи отелей и комнат. Методы для отелей и комнат различаются только
+// владельцем, поэтому обработка вынесена в общие функции.
+type ImageHandler struct {
+	imageService   services.ImageServiceInterface
+	maxUploadBytes int64
+}
+
+func NewImageHandler(imageService services.ImageServiceInterface, maxUploadBytes int64) ImageHandler {
+	if maxUploadBytes <= 0 {
+		maxUploadBytes = services.DefaultMaxImageSize
+	}
+	return ImageHandler{imageService: imageService, maxUploadBytes: maxUploadBytes}
+}
+
+func writeImageError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, erors.ErrInvalidInput):
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid input"})
+	case errors.Is(err, erors.ErrNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": "not found"})
+	case errors.Is(err, erors.ErrFileTooLarge):
+		c.JSON(http.StatusRequestEntityTooLarge, gin.H{"error": "file too large"})
+	case errors.Is(err, erors.ErrUnsupportedMediaType):
+		c.JSON(http.StatusUnsupportedMediaType, gin.H{"error": "only jpeg and png images are allowed"})
+	case errors.Is(err, erors.ErrConflict):
+		c.JSON(http.StatusConflict, gin.H{"error": "concurrent update, retry"})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+	}
+}
+
+func (h ImageHandler) upload(c *gin.Context, kind string) {
+	id, ok := parseIDParam(c, "id")
+	if !ok {
+		return
+	}
+	// Запас на служебные части multipart сверх лимита самого файла
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, h.maxUploadBytes+1<<20)
+	fh, err := c.FormFile("file")
+	if err != nil {
+		var maxErr *http.MaxBytesError
+		if errors.As(err, &maxErr) {
+			c.JSON(http.StatusRequestEntityTooLarge, gin.H{"error": "file too large"})
+			return
+		}
+		c.JSON(http.StatusBadRequest, gin.H{"error": "file is required"})
+		return
+	}
+	primary := false
+	if v := c.PostForm("isPrimary"); v != "" {
+		if primary, err = strconv.ParseBool(v); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid isPrimary"})
+			return
+		}
+	}
+	file, err := fh.Open()
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "file is required"})
+		return
+	}
+	defer file.Close()
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 30*time.Second)
+	defer cancel()
+
+	img, err := h.imageService.Upload(ctx, models.ImageOwner{Kind: kind, ID: id}, file, c.PostForm("alt"), primary)
+	if err != nil {
+		writeImageError(c, err)
+		return
+	}
+	c.JSON(http.StatusCreated, img)
+}
+
+func (h ImageHandler) list(c *gin.Context, kind, param string) {
+	id, ok := parseIDParam(c, param)
+	if !ok {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+	defer cancel()
+
+	images, err := h.imageService.List(ctx, models.ImageOwner{Kind: kind, ID: id})
+	if err != nil {
+		writeImageError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, images)
+}
+
+func (h ImageHandler) reorder(c *gin.Context, kind string) {
+	id, ok := parseIDParam(c, "id")
+	if !ok {
+		return
+	}
+	var dto models.ReorderImagesDTO
+	if err := c.ShouldBindJSON(&dto); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid body"})
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+	defer cancel()
+
+	images, err := h.imageService.Reorder(ctx, models.ImageOwner{Kind: kind, ID: id}, dto.IDs)
+	if err != nil {
+		writeImageError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, images)
+}
+
+func (h ImageHandler) setPrimary(c *gin.Context, kind string) {
+	id, ok := parseIDParam(c, "id")
+	if !ok {
+		return
+	}
+	imageID, ok := parseIDParam(c, "imageid")
+	if !ok {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+	defer cancel()
+
+	images, err := h.imageService.SetPrimary(ctx, models.ImageOwner{Kind: kind, ID: id}, imageID)
+	if err != nil {
+		writeImageError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, images)
+}
+
+func (h ImageHandler) delete(c *gin.Context, kind string) {
+	id, ok := parseIDParam(c, "id")
+	if !ok {
+		return
+	}
+	imageID, ok := parseIDParam(c, "imageid")
+	if !ok {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+	defer cancel()
+
+	if err := h.imageService.Delete(ctx, models.ImageOwner{Kind: kind, ID: id}, imageID); err != nil {
+		writeImageError(c, err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// UploadHotelImage загрузить фотографию отеля
+// @Summary Загрузить фотографию отеля
+// @Description multipart/form-data: file (JPEG или PNG, не больше лимита из конфига), alt, isPrimary.
+// @Description Создается превью; первая фотография автоматически становится главной.
+// @Tags admin
+// @Security BearerAuth
+// @Accept multipart/form-data
+// @Produce json
+// @Param id path int true "ID отеля"
+// @Param file formData file true "Изображение"
+// @Param alt formData string false "Альтернативный текст"
+// @Param isPrimary formData bool false "Сделать главной"
+// @Success 201 {object} models.Image
+// @Failure 400 {object} map[string]string "invalid id | file is required | invalid isPrimary | invalid input"
+// @Failure 401 {object} map[string]string "unauthorized"
+// @Failure 403 {object} map[string]string "access denied"
+// @Failure 404 {object} map[string]string "not found"
+// @Failure 413 {object} map[string]string "file too large"
+// @Failure 415 {object} map[string]string "only jpeg and png images are allowed"
+// @Failure 500 {object} map[string]string "internal server error"
+// @Router /admin/hotels/{id}/images [post]
+func (h ImageHandler) UploadHotelImage(c *gin.Context) {
+	h.upload(c, models.ImageOwnerHotel)
+}
+
+// ListHotelImages галерея отеля
+// @Summary Фотографии отеля
+// @Tags hotels
+// @Produce json
+// @Param hotelid path int true "ID отеля"
+// @Success 200 {array} models.Image
+// @Failure 400 {object} map[string]string "invalid hotelid"
+// @Failure 404 {object} map[string]string "not found"
+// @Failure 500 {object} map[string]string "internal server error"
+// @Router /hotels/{hotelid}/images [get]
+func (h ImageHandler) ListHotelImages(c *gin.Context) {
+	h.list(c, models.ImageOwnerHotel, "hotelid")
+}
+
+// ReorderHotelImages изменить порядок фотографий отеля
+// @Summary Изменить порядок фотографий отеля
+// @Tags admin
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param id path int true "ID отеля"
+// @Param input body models.ReorderImagesDTO true "Все ID фотографий в новом порядке"
+// @Success 200 {array} models.Image
+// @Failure 400 {object} map[string]string "invalid id | invalid body | invalid input"
+// @Failure 401 {object} map[string]string "unauthorized"
+// @Failure 403 {object} map[string]string "access denied"
+// @Failure 404 {object} map[string]string "not found"
+// @Failure 500 {object} map[string]string "internal server error"
+// @Router /admin/hotels/{id}/images/order [put]
+func (h ImageHandler) ReorderHotelImages(c *gin.Context) {
+	h.reorder(c, models.ImageOwnerHotel)
+}
+
+// SetHotelPrimaryImage выбрать главную фотографию отеля
+// @Summary Сделать фотографию отеля главной
+// @Tags admin
+// @Security BearerAuth
+// @Produce json
+// @Param id path int true "ID отеля"
+// @Param imageid path int true "ID фотографии"
+// @Success 200 {array} models.Image
+// @Failure 400 {object} map[string]string "invalid id | invalid imageid"
+// @Failure 401 {object} map[string]string "unauthorized"
+// @Failure 403 {object} map[string]string "access denied"
+// @Failure 404 {object} map[string]string "not found"
+// @Failure 500 {object} map[string]string "internal server error"
+// @Router /admin/hotels/{id}/images/{imageid}/primary [post]
+func (h ImageHandler) SetHotelPrimaryImage(c *gin.Context) {
+	h.setPrimary(c, models.ImageOwnerHotel)
+}
+
+// DeleteHotelImage удалить фотографию отеля
+// @Summary Удалить фотографию отеля
+// @Tags admin
+// @Security BearerAuth
+// @Produce json
+// @Param id path int true "ID отеля"
+// @Param imageid path int true "ID фотографии"
+// @Success 204 "Удалено"
+// @Failure 400 {object} map[string]string "invalid id | invalid imageid"
+// @Failure 401 {object} map[string]string "unauthorized"
+// @Failure 403 {object} map[string]string "access denied"
+// @Failure 404 {object} map[string]string "not found"
+// @Failure 500 {object} map[string]string "internal server error"
+// @Router /admin/hotels/{id}/images/{imageid} [delete]
+func (h ImageHandler) DeleteHotelImage(c *gin.Context) {
+	h.delete(c, models.ImageOwnerHotel)
+}
+
+// UploadRoomImage загрузить фотографию комнаты
+// @Summary Загрузить фотографию комнаты
+// @Description multipart/form-data: file (JPEG или PNG, не больше лимита из конфига), alt, isPrimary.
+// @Description Создается превью; первая фотография автоматически становится главной.
+// @Tags admin
+// @Security BearerAuth
+// @Accept multipart/form-data
+// @Produce json
+// @Param id path int true "ID комнаты"
+// @Param file formData file true "Изображение"
+// @Param alt formData string false "Альтернативный текст"
+// @Param isPrimary formData bool false "Сделать главной"
+// @Success 201 {object} models.Image
+// @Failure 400 {object} map[string]string "invalid id | file is required | invalid isPrimary | invalid input"
+// @Failure 401 {object} map[string]string "unauthorized"
+// @Failure 403 {object} map[string]string "access denied"
+// @Failure 404 {object} map[string]string "not found"
+// @Failure 413 {object} map[string]string "file too large"
+// @Failure 415 {object} map[string]string "only jpeg and png images are allowed"
+// @Failure 500 {object} map[string]string "internal server error"
+// @Router /admin/rooms/{id}/images [post]
+func (h ImageHandler) UploadRoomImage(c *gin.Context) {
+	h.upload(c, models.ImageOwnerRoom)
+}
+
+// ListRoomImages галерея комнаты
+// @Summary Фотографии комнаты
+// @Tags rooms
+// @Produce json
+// @Param roomid path int true "ID комнаты"
+// @Success 200 {array} models.Image
+// @Failure 400 {object} map[string]string "invalid roomid"
+// @Failure 404 {object} map[string]string "not found"
+// @Failure 500 {object} map[string]string "internal server error"
+// @Router /rooms/{roomid}/images [get]
+func (h ImageHandler) ListRoomImages(c *gin.Context) {
+	h.list(c, models.ImageOwnerRoom, "roomid")
+}
+
+// ReorderRoomImages изменить порядок фотографий комнаты
+// @Summary Изменить порядок фотографий комнаты
+// @Tags admin
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param id path int true "ID комнаты"
+// @Param input body models.ReorderImagesDTO true "Все ID фотографий в новом порядке"
+// @Success 200 {array} models.Image
+// @Failure 400 {object} map[string]string "invalid id | invalid body | invalid input"
+// @Failure 401 {object} map[string]string "unauthorized"
+// @Failure 403 {object} map[string]string "access denied"
+// @Failure 404 {object} map[string]string "not found"
+// @Failure 500 {object} map[string]string "internal server error"
+// @Router /admin/rooms/{id}/images/order [put]
+func (h ImageHandler) ReorderRoomImages(c *gin.Context) {
+	h.reorder(c, models.ImageOwnerRoom)
+}
+
+// SetRoomPrimaryImage выбрать главную фотографию комнаты
+// @Summary Сделать фотографию комнаты главной
+// @Tags admin
+// @Security BearerAuth
+// @Produce json
+// @Param id path int true "ID комнаты"
+// @Param imageid path int true "ID фотографии"
+// @Success 200 {array} models.Image
+// @Failure 400 {object} map[string]string "invalid id | invalid imageid"
+// @Failure 401 {object} map[string]string "unauthorized"
+// @Failure 403 {object} map[string]string "access denied"
+// @Failure 404 {object} map[string]string "not found"
+// @Failure 500 {object} map[string]string "internal server error"
+// @Router /admin/rooms/{id}/images/{imageid}/primary [post]
+func (h ImageHandler) SetRoomPrimaryImage(c *gin.Context) {
+	h.setPrimary(c, models.ImageOwnerRoom)
+}
+
+// DeleteRoomImage удалить фотографию комнаты
+// @Summary Удалить фотографию комнаты
+// @Tags admin
+// @Security BearerAuth
+// @Produce json
+// @Param id path int true "ID комнаты"
+// @Param imageid path int true "ID фотографии"
+// @Success 204 "Удалено"
+// @Failure 400 {object} map[string]string "invalid id | invalid imageid"
+// @Failure 401 {object} map[string]string "unauthorized"
+// @Failure 403 {object} map[string]string "access denied"
+// @Failure 404 {object} map[string]string "not found"
+// @Failure 500 {object} map[string]string "internal server error"
+// @Router /admin/rooms/{id}/images/{imageid} [delete]
+func (h ImageHandler) DeleteRoomImage(c *gin.Context) {
+	h.delete(c, models.ImageOwnerRoom)
+}
@@ -0,0 +1,148 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	"backend/internal/erors"
+	"backend/internal/models"
+	"backend/internal/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+type OAuthHandler struct {
+	oauthService services.OAuthServiceInterface
+	tokenService services.TokenServiceInterface
+}
+
+func NewOAuthHandler(oauthService services.OAuthServiceInterface, tokenService services.TokenServiceInterface) OAuthHandler {
+	return OAuthHandler{oauthService: oauthService, tokenService: tokenService}
+}
+
+func writeOAuthError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, erors.ErrOAuthProviderDisabled):
+		c.JSON(http.StatusNotFound, gin.H{"error": "Провайдер не настроен"})
+	case errors.Is(err, erors.ErrOAuthStateInvalid):
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Недействительный или просроченный state"})
+	case errors.Is(err, erors.ErrOAuthExchange):
+		c.JSON(http.StatusBadGateway, gin.H{"error": "Ошибка обмена данными с провайдером"})
+	case errors.Is(err, erors.ErrConflict):
+		c.JSON(http.StatusConflict, gin.H{"error": "Аккаунт провайдера привязан к другому пользователю"})
+	case errors.Is(err, erors.ErrOAuthEmailUnverified):
+		c.JSON(http.StatusConflict, gin.H{"error": "Email уже зарегистрирован, но не подтвержден: войдите по паролю или восстановите его"})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Внутренняя ошибка сервера"})
+	}
+}
+
+// Providers доступные провайдеры входа
+// @Summary Провайдеры OAuth-входа
+// @Tags auth
+// @Produce json
+// @Success 200 {array} string
+// @Router /auth/oauth/providers [get]
+func (h OAuthHandler) Providers(c *gin.Context) {
+	c.JSON(http.StatusOK, h.oauthService.Providers())
+}
+
+// Start начало входа через провайдера
+// @Summary Начать вход через OAuth-провайдера
+// @Description Возвращает адрес авторизации с одноразовым state и PKCE code_challenge.
+// @Description С заголовком Authorization аккаунт провайдера будет привязан к текущему пользователю.
+// @Tags auth
+// @Produce json
+// @Param provider path string true "vk, yandex, google"
+// @Success 200 {object} models.OAuthStartResponse
+// @Failure 404 {object} map[string]string "Провайдер не настроен"
+// @Failure 500 {object} map[string]string "Внутренняя ошибка сервера"
+// @Router /auth/oauth/{provider}/start [get]
+func (h OAuthHandler) Start(c *gin.Context) {
+	// Маршрут открыт анонимно; ошибка означает лишь отсутствие входа
+	currentUserID, _ := getUserId(c)
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+	defer cancel()
+
+	res, err := h.oauthService.Start(ctx, c.Param("provider"), currentUserID)
+	if err != nil {
+		writeOAuthError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, res)
+}
+
+// Callback завершение входа через провайдера
+// @Summary Завершить вход через OAuth-провайдера
+// @Description Обменивает code на токен провайдера, читает профиль и выдает пару токенов.
+// @Description Аккаунт связывается с существующим пользователем по подтвержденному email.
+// @Description Привязку, начатую с Authorization, нужно завершать с токеном того же пользователя.
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param provider path string true "vk, yandex, google"
+// @Param input body models.OAuthCallbackDTO true "code и state из redirect_url"
+// @Success 200 {object} models.AuthResponse "Пара токенов"
+// @Failure 400 {object} map[string]string "Неверные данные запроса | Недействительный или просроченный state"
+// @Failure 404 {object} map[string]string "Провайдер не настроен"
+// @Failure 409 {object} map[string]string "Аккаунт провайдера привязан к другому пользователю | Email уже зарегистрирован, но не подтвержден: войдите по паролю или восстановите его"
+// @Failure 502 {object} map[string]string "Ошибка обмена данными с провайдером"
+// @Failure 500 {object} map[string]string "Внутренняя ошибка сервера"
+// @Router /auth/oauth/{provider}/callback [post]
+func (h OAuthHandler) Callback(c *gin.Context) {
+	// Маршрут открыт анонимно; пользователь нужен только для завершения привязки
+	currentUserID, _ := getUserId(c)
+
+	var input models.OAuthCallbackDTO
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Неверные данные запроса"})
+		return
+	}
+
+	// Запросы к провайдеру (обмен кода и userinfo) занимают больше обычного
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 20*time.Second)
+	defer cancel()
+
+	user, err := h.oauthService.Callback(ctx, c.Param("provider"), currentUserID, input)
+	if err != nil {
+		writeOAuthError(c, err)
+		return
+	}
+
+	response, err := h.tokenService.IssueTokenPair(ctx, user)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Внутренняя ошибка сервера"})
+		return
+	}
+	c.JSON(http.StatusOK, response)
+}
+
+// ListIdentities привязанные внешние аккаунты
+// @Summary Привязанные аккаунты провайдеров
+// @Tags users
+// @Security BearerAuth
+// @Produce json
+// @Success 200 {array} models.UserIdentity
+// @Failure 401 {object} map[string]string "user authentication required"
+// @Failure 500 {object} map[string]string "internal server error"
+// @Router /users/me/identities [get]
+func (h OAuthHandler) ListIdentities(c *gin.Context) {
+	userID, err := getUserId(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "user authentication required"})
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+	defer cancel()
+
+	identities, err := h.oauthService.ListIdentities(ctx, userID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+		return
+	}
+	c.JSON(http.StatusOK, identities)
+}
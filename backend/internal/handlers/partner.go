@@ -0,0 +1,318 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"backend/internal/erors"
+	"backend/internal/models"
+	"backend/internal/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+// PartnerHandler API владельцев отелей: все операции ограничены собственными отелями
+type PartnerHandler struct {
+	hotelServ   services.HotelServiceInterface
+	roomService services.RoomServiceInterface
+}
+
+func NewPartnerHandler(hotelServ services.HotelServiceInterface, roomService services.RoomServiceInterface) PartnerHandler {
+	return PartnerHandler{hotelServ: hotelServ, roomService: roomService}
+}
+
+func writePartnerError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, erors.ErrInvalidInput):
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid input"})
+	case errors.Is(err, erors.ErrNotHotelOwner):
+		c.JSON(http.StatusForbidden, gin.H{"error": "access denied"})
+	case errors.Is(err, erors.ErrNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": "not found"})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+	}
+}
+
+func parseIDParam(c *gin.Context, name string) (int64, bool) {
+	id, err := strconv.ParseInt(c.Param(name), 10, 64)
+	if err != nil || id <= 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid " + name})
+		return 0, false
+	}
+	return id, true
+}
+
+// ListHotels отели текущего владельца
+// @Summary Мои отели
+// @Tags partner
+// @Security BearerAuth
+// @Produce json
+// @Success 200 {array} models.Hotel
+// @Failure 401 {object} map[string]string "user authentication required"
+// @Failure 403 {object} map[string]string "access denied"
+// @Failure 500 {object} map[string]string "internal server error"
+// @Router /partner/hotels [get]
+func (h PartnerHandler) ListHotels(c *gin.Context) {
+	ownerID, err := getUserId(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "user authentication required"})
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+	defer cancel()
+
+	hotels, err := h.hotelServ.ListOwnedHotels(ctx, ownerID)
+	if err != nil {
+		writePartnerError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, hotels)
+}
+
+// CreateHotel создать отель от имени владельца
+// @Summary Создать свой отель
+// @Tags partner
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param input body models.Hotel true "Данные отеля"
+// @Success 201 {object} models.Hotel
+// @Failure 400 {object} map[string]string "invalid body | invalid input"
+// @Failure 401 {object} map[string]string "user authentication required"
+// @Failure 403 {object} map[string]string "access denied"
+// @Failure 500 {object} map[string]string "internal server error"
+// @Router /partner/hotels [post]
+func (h PartnerHandler) CreateHotel(c *gin.Context) {
+	ownerID, err := getUserId(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "user authentication required"})
+		return
+	}
+
+	var hotel models.Hotel
+	if err := c.ShouldBindJSON(&hotel); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid body"})
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+	defer cancel()
+
+	if err := h.hotelServ.CreateOwnedHotel(ctx, ownerID, &hotel); err != nil {
+		writePartnerError(c, err)
+		return
+	}
+	c.JSON(http.StatusCreated, hotel)
+}
+
+// UpdateHotel частично обновить свой отель
+// @Summary Обновить свой отель
+// @Tags partner
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param hotelid path int true "ID отеля"
+// @Param input body models.UpdateHotelDTO true "Изменяемые поля"
+// @Success 200 {object} models.Hotel
+// @Failure 400 {object} map[string]string "invalid hotelid | invalid body | invalid input"
+// @Failure 401 {object} map[string]string "user authentication required"
+// @Failure 403 {object} map[string]string "access denied"
+// @Failure 404 {object} map[string]string "not found"
+// @Failure 500 {object} map[string]string "internal server error"
+// @Router /partner/hotels/{hotelid} [patch]
+func (h PartnerHandler) UpdateHotel(c *gin.Context) {
+	ownerID, err := getUserId(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "user authentication required"})
+		return
+	}
+	hotelID, ok := parseIDParam(c, "hotelid")
+	if !ok {
+		return
+	}
+
+	var dto models.UpdateHotelDTO
+	if err := c.ShouldBindJSON(&dto); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid body"})
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+	defer cancel()
+
+	hotel, err := h.hotelServ.UpdateOwnedHotel(ctx, ownerID, hotelID, dto)
+	if err != nil {
+		writePartnerError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, hotel)
+}
+
+// DeleteHotel удалить свой отель
+// @Summary Удалить свой отель
+// @Tags partner
+// @Security BearerAuth
+// @Produce json
+// @Param hotelid path int true "ID отеля"
+// @Success 204 "Удалено"
+// @Failure 400 {object} map[string]string "invalid hotelid"
+// @Failure 401 {object} map[string]string "user authentication required"
+// @Failure 403 {object} map[string]string "access denied"
+// @Failure 404 {object} map[string]string "not found"
+// @Failure 500 {object} map[string]string "internal server error"
+// @Router /partner/hotels/{hotelid} [delete]
+func (h PartnerHandler) DeleteHotel(c *gin.Context) {
+	ownerID, err := getUserId(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "user authentication required"})
+		return
+	}
+	hotelID, ok := parseIDParam(c, "hotelid")
+	if !ok {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+	defer cancel()
+
+	if err := h.hotelServ.DeleteOwnedHotel(ctx, ownerID, hotelID); err != nil {
+		writePartnerError(c, err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// CreateRoom создать комнату в своем отеле
+// @Summary Добавить комнату в свой отель
+// @Tags partner
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param hotelid path int true "ID отеля"
+// @Param input body models.Room true "Данные комнаты (hotel_id берется из пути)"
+// @Success 201 {object} models.Room
+// @Failure 400 {object} map[string]string "invalid hotelid | invalid body | invalid input"
+// @Failure 401 {object} map[string]string "user authentication required"
+// @Failure 403 {object} map[string]string "access denied"
+// @Failure 404 {object} map[string]string "not found"
+// @Failure 500 {object} map[string]string "internal server error"
+// @Router /partner/hotels/{hotelid}/rooms [post]
+func (h PartnerHandler) CreateRoom(c *gin.Context) {
+	ownerID, err := getUserId(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "user authentication required"})
+		return
+	}
+	hotelID, ok := parseIDParam(c, "hotelid")
+	if !ok {
+		return
+	}
+
+	var room models.Room
+	if err := c.ShouldBindJSON(&room); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid body"})
+		return
+	}
+	room.HotelID = hotelID
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+	defer cancel()
+
+	if err := h.roomService.CreateOwnedRoom(ctx, ownerID, &room); err != nil {
+		writePartnerError(c, err)
+		return
+	}
+	c.JSON(http.StatusCreated, room)
+}
+
+// UpdateRoom частично обновить комнату своего отеля
+// @Summary Обновить комнату своего отеля
+// @Tags partner
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param hotelid path int true "ID отеля"
+// @Param roomid path int true "ID комнаты"
+// @Param input body models.UpdateRoomDTO true "Изменяемые поля"
+// @Success 200 {object} models.Room
+// @Failure 400 {object} map[string]string "invalid hotelid | invalid roomid | invalid body | invalid input"
+// @Failure 401 {object} map[string]string "user authentication required"
+// @Failure 403 {object} map[string]string "access denied"
+// @Failure 404 {object} map[string]string "not found"
+// @Failure 500 {object} map[string]string "internal server error"
+// @Router /partner/hotels/{hotelid}/rooms/{roomid} [patch]
+func (h PartnerHandler) UpdateRoom(c *gin.Context) {
+	ownerID, err := getUserId(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "user authentication required"})
+		return
+	}
+	hotelID, ok := parseIDParam(c, "hotelid")
+	if !ok {
+		return
+	}
+	roomID, ok := parseIDParam(c, "roomid")
+	if !ok {
+		return
+	}
+
+	var dto models.UpdateRoomDTO
+	if err := c.ShouldBindJSON(&dto); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid body"})
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+	defer cancel()
+
+	room, err := h.roomService.UpdateOwnedRoom(ctx, ownerID, hotelID, roomID, dto)
+	if err != nil {
+		writePartnerError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, room)
+}
+
+// DeleteRoom удалить комнату своего отеля
+// @Summary Удалить комнату своего отеля
+// @Tags partner
+// @Security BearerAuth
+// @Produce json
+// @Param hotelid path int true "ID отеля"
+// @Param roomid path int true "ID комнаты"
+// @Success 204 "Удалено"
+// @Failure 400 {object} map[string]string "invalid hotelid | invalid roomid"
+// @Failure 401 {object} map[string]string "user authentication required"
+// @Failure 403 {object} map[string]string "access denied"
+// @Failure 404 {object} map[string]string "not found"
+// @Failure 500 {object} map[string]string "internal server error"
+// @Router /partner/hotels/{hotelid}/rooms/{roomid} [delete]
+func (h PartnerHandler) DeleteRoom(c *gin.Context) {
+	ownerID, err := getUserId(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "user authentication required"})
+		return
+	}
+	hotelID, ok := parseIDParam(c, "hotelid")
+	if !ok {
+		return
+	}
+	roomID, ok := parseIDParam(c, "roomid")
+	if !ok {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+	defer cancel()
+
+	if err := h.roomService.DeleteOwnedRoom(ctx, ownerID, hotelID, roomID); err != nil {
+		writePartnerError(c, err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
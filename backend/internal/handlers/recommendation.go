@@ -0,0 +1,66 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"backend/internal/erors"
+	"backend/internal/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+type RecommendationHandler struct {
+	recommendationService services.RecommendationServiceInterface
+}
+
+func NewRecommendationHandler(recommendationService services.RecommendationServiceInterface) RecommendationHandler {
+	return RecommendationHandler{recommendationService: recommendationService}
+}
+
+// Recommend рекомендации на основе активности друзей
+// @Summary Рекомендации от друзей
+// @Description Комнаты и отели, которые друзья добавляли в избранное, где жили или которые высоко оценили.
+// @Description Учитываются близость друга (общие друзья) и давность действия; уже знакомые пользователю объекты исключаются.
+// @Tags users
+// @Security BearerAuth
+// @Produce json
+// @Param type query string false "room, hotel или пусто — оба вида"
+// @Param limit query int false "Количество (по умолчанию 10, до 50)"
+// @Success 200 {array} models.Recommendation
+// @Failure 400 {object} map[string]string "invalid input"
+// @Failure 401 {object} map[string]string "user authentication required"
+// @Failure 500 {object} map[string]string "internal server error"
+// @Router /users/recommend [get]
+func (h RecommendationHandler) Recommend(c *gin.Context) {
+	userID, err := getUserId(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "user authentication required"})
+		return
+	}
+
+	var limit int
+	if v := c.Query("limit"); v != "" {
+		if limit, err = strconv.Atoi(v); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid input"})
+			return
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+	defer cancel()
+
+	recs, err := h.recommendationService.Recommend(ctx, userID, c.Query("type"), limit)
+	if err != nil {
+		if errors.Is(err, erors.ErrInvalidInput) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid input"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+		return
+	}
+	c.JSON(http.StatusOK, recs)
+}
@@ -9,29 +9,55 @@ import (
 
 	"backend/internal/erors"
 	"backend/internal/models"
-	"backend/internal/repos"
+	"backend/internal/services"
 
 	"github.com/gin-gonic/gin"
 )
 
 type ReviewHandler struct {
-	Repo repos.ReviewRepo
+	reviewService services.ReviewServiceInterface
 }
 
-func NewReviewHandler(repo repos.ReviewRepo) ReviewHandler {
-	return ReviewHandler{Repo: repo}
+func NewReviewHandler(reviewService services.ReviewServiceInterface) ReviewHandler {
+	return ReviewHandler{reviewService: reviewService}
+}
+
+func writeReviewError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, erors.ErrInvalidInput):
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid input"})
+	case errors.Is(err, erors.ErrNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": "review not found"})
+	case errors.Is(err, erors.ErrStayRequired):
+		c.JSON(http.StatusForbidden, gin.H{"error": "completed stay required"})
+	case errors.Is(err, erors.ErrEmailNotVerified):
+		c.JSON(http.StatusForbidden, gin.H{"error": "email not verified"})
+	case errors.Is(err, erors.ErrForbidden):
+		c.JSON(http.StatusForbidden, gin.H{"error": "access denied"})
+	case errors.Is(err, erors.ErrReviewEditExpired):
+		c.JSON(http.StatusForbidden, gin.H{"error": "edit window expired"})
+	case errors.Is(err, erors.ErrConflict):
+		c.JSON(http.StatusConflict, gin.H{"error": "review already exists"})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+	}
 }
 
 // Create создать отзыв
 // @Summary Создать отзыв
+// @Description Оставить отзыв может только гость с подтвержденным email и завершенным проживанием в комнате, один на комнату.
+// @Description Отзыв попадает в очередь модерации и публикуется после одобрения; поле approved игнорируется.
 // @Tags reviews
 // @Security BearerAuth
 // @Accept json
 // @Produce json
 // @Param input body models.Review true "Данные отзыва"
 // @Success 201 {object} models.Review
-// @Failure 400 {object} map[string]string "bad request"
+// @Failure 400 {object} map[string]string "bad request | invalid input"
 // @Failure 401 {object} map[string]string "unauthorized"
+// @Failure 403 {object} map[string]string "completed stay required | email not verified"
+// @Failure 404 {object} map[string]string "room not found"
+// @Failure 409 {object} map[string]string "review already exists"
 // @Failure 500 {object} map[string]string "internal server error"
 // @Router /reviews [post]
 func (h ReviewHandler) Create(c *gin.Context) {
@@ -41,28 +67,74 @@ func (h ReviewHandler) Create(c *gin.Context) {
 		return
 	}
 
-	userIDVal, exists := c.Get("userid")
-	if !exists {
+	userID, err := getUserId(c)
+	if err != nil {
 		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
 		return
 	}
-	userID, ok := userIDVal.(int64)
-	if !ok || userID <= 0 {
+	review.UserID = userID
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+	defer cancel()
+
+	if err := h.reviewService.AddReview(ctx, &review); err != nil {
+		if errors.Is(err, erors.ErrNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "room not found"})
+			return
+		}
+		writeReviewError(c, err)
+		return
+	}
+	c.JSON(http.StatusCreated, review)
+}
+
+// Update правка собственного отзыва
+// @Summary Изменить свой отзыв
+// @Description Доступно автору в течение окна редактирования после создания (reviews.edit_window_hours). Измененный отзыв снова уходит на модерацию.
+// @Tags reviews
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param id path int true "ID отзыва"
+// @Param input body models.UpdateReviewDTO true "Изменяемые поля"
+// @Success 200 {object} models.Review
+// @Failure 400 {object} map[string]string "invalid id | invalid body | invalid input"
+// @Failure 401 {object} map[string]string "unauthorized"
+// @Failure 403 {object} map[string]string "access denied | edit window expired | email not verified"
+// @Failure 404 {object} map[string]string "review not found"
+// @Failure 500 {object} map[string]string "internal server error"
+// @Router /reviews/{id} [patch]
+func (h ReviewHandler) Update(c *gin.Context) {
+	userID, err := getUserId(c)
+	if err != nil {
 		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
 		return
 	}
+	reviewID, ok := parseIDParam(c, "id")
+	if !ok {
+		return
+	}
 
-	review.UserID = userID
+	var dto models.UpdateReviewDTO
+	if err := c.ShouldBindJSON(&dto); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid body"})
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+	defer cancel()
 
-	if err := h.Repo.Create(&review); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	review, err := h.reviewService.UpdateReview(ctx, reviewID, userID, dto)
+	if err != nil {
+		writeReviewError(c, err)
 		return
 	}
-	c.JSON(http.StatusCreated, review)
+	c.JSON(http.StatusOK, review)
 }
 
 // List отзывы текущего пользователя
 // @Summary Получить отзывы текущего пользователя
+// @Description Включая ожидающие модерации и отклоненные (с причиной).
 // @Tags reviews
 // @Security BearerAuth
 // @Produce json
@@ -72,13 +144,8 @@ func (h ReviewHandler) Create(c *gin.Context) {
 // @Router /reviews/me [get]
 func (h ReviewHandler) List(c *gin.Context) {
 	// Достаём текущего пользователя из контекста (middleware уже положил его туда)
-	userIDVal, exists := c.Get("userid")
-	if !exists {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
-		return
-	}
-	userID, ok := userIDVal.(int64)
-	if !ok || userID <= 0 {
+	userID, err := getUserId(c)
+	if err != nil {
 		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
 		return
 	}
@@ -86,7 +153,7 @@ func (h ReviewHandler) List(c *gin.Context) {
 	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
 	defer cancel()
 
-	reviews, err := h.Repo.ListByUserID(ctx, userID)
+	reviews, err := h.reviewService.ListMyReviews(ctx, userID)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
 		return
@@ -94,7 +161,7 @@ func (h ReviewHandler) List(c *gin.Context) {
 	c.JSON(http.StatusOK, reviews)
 }
 
-// DeleteByID удалить отзыв по ID (admin)
+// DeleteByID удалить отзыв по ID (доступ проверяет RequirePermission)
 // @Summary Удалить отзыв по ID (admin)
 // @Tags reviews
 // @Security BearerAuth
@@ -108,14 +175,6 @@ func (h ReviewHandler) List(c *gin.Context) {
 // @Failure 500 {object} map[string]string "internal server error"
 // @Router /reviews/{id} [delete]
 func (h ReviewHandler) DeleteByID(c *gin.Context) {
-	// Проверка роли
-	roleVal, exists := c.Get("userRole")
-	role, ok := roleVal.(string)
-	if !exists || !ok || role != "admin" {
-		c.JSON(http.StatusForbidden, gin.H{"error": "access denied"})
-		return
-	}
-
 	// Парсинг id
 	idStr := c.Param("id")
 	reviewID, err := strconv.ParseInt(idStr, 10, 64)
@@ -129,18 +188,9 @@ func (h ReviewHandler) DeleteByID(c *gin.Context) {
 	defer cancel()
 
 	// Вызов сервиса
-	if err := h.Repo.DeleteByID(ctx, reviewID); err != nil {
-		switch {
-		case errors.Is(err, erors.ErrInvalidInput):
-			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid input"})
-			return
-		case errors.Is(err, erors.ErrNotFound):
-			c.JSON(http.StatusNotFound, gin.H{"error": "review not found"})
-			return
-		default:
-			c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
-			return
-		}
+	if err := h.reviewService.DeleteByID(ctx, reviewID); err != nil {
+		writeReviewError(c, err)
+		return
 	}
 
 	c.Status(http.StatusNoContent)
@@ -148,11 +198,14 @@ func (h ReviewHandler) DeleteByID(c *gin.Context) {
 
 // ListByRoomID список отзывов по комнате
 // @Summary Получить список отзывов по ID комнаты
+// @Description Только опубликованные отзывы, вместе с ответом отеля. Авторизованный пользователь дополнительно видит свои отзывы на модерации.
 // @Tags reviews
 // @Produce json
 // @Param roomid path int true "ID комнаты"
+// @Param sort query string false "Сортировка: newest, helpful или rating; по умолчанию — в порядке создания"
 // @Success 200 {array} models.Review
 // @Failure 400 {object} map[string]string "invalid room id | invalid input"
+// @Failure 401 {object} map[string]string "Invalid token"
 // @Failure 500 {object} map[string]string "internal server error"
 // @Router /rooms/{roomid}/reviews [get]
 func (h ReviewHandler) ListByRoomID(c *gin.Context) {
@@ -164,26 +217,23 @@ func (h ReviewHandler) ListByRoomID(c *gin.Context) {
 		return
 	}
 
+	// Маршрут публичный: без токена зритель анонимный
+	viewerID, _ := getUserId(c)
+
 	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
 	defer cancel()
 
-	reviews, err := h.Repo.ListByRoomID(ctx, roomID)
+	reviews, err := h.reviewService.ListByRoomID(ctx, roomID, viewerID, c.Query("sort"))
 	if err != nil {
-		switch {
-		case errors.Is(err, erors.ErrInvalidInput):
-			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid input"})
-		default:
-			c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
-		}
+		writeReviewError(c, err)
 		return
 	}
-
-	// Возвращаем все отзывы (свои и чужие) по комнате
 	c.JSON(http.StatusOK, reviews)
 }
 
 // ListByUserID список отзывов по пользователю
 // @Summary Получить отзывы по ID пользователя
+// @Description Для чужого профиля возвращаются только опубликованные отзывы.
 // @Tags reviews
 // @Produce json
 // @Param userid path int true "ID пользователя"
@@ -198,20 +248,344 @@ func (h ReviewHandler) ListByUserID(c *gin.Context) {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid user id"})
 		return
 	}
+	viewerID, _ := getUserId(c)
 
 	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
 	defer cancel()
 
-	reviews, err := h.Repo.ListByUserID(ctx, userID)
+	reviews, err := h.reviewService.ListByUserID(ctx, userID, viewerID)
 	if err != nil {
-		switch {
-		case errors.Is(err, erors.ErrInvalidInput):
+		writeReviewError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, reviews)
+}
+
+// ListForModeration очередь модерации
+// @Summary Отзывы на модерации
+// @Tags admin
+// @Security BearerAuth
+// @Produce json
+// @Param status query string false "Статус: pending (по умолчанию), approved, rejected"
+// @Param page query int false "Номер страницы (с 1)"
+// @Param limit query int false "Размер страницы (до 100)"
+// @Success 200 {object} models.ReviewListResponse
+// @Failure 400 {object} map[string]string "invalid input"
+// @Failure 401 {object} map[string]string "unauthorized"
+// @Failure 403 {object} map[string]string "access denied"
+// @Failure 500 {object} map[string]string "internal server error"
+// @Router /admin/reviews [get]
+func (h ReviewHandler) ListForModeration(c *gin.Context) {
+	var page, limit int
+	var err error
+	if v := c.Query("page"); v != "" {
+		if page, err = strconv.Atoi(v); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid input"})
+			return
+		}
+	}
+	if v := c.Query("limit"); v != "" {
+		if limit, err = strconv.Atoi(v); err != nil {
 			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid input"})
-		default:
-			c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+			return
 		}
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+	defer cancel()
+
+	res, err := h.reviewService.ListForModeration(ctx, c.Query("status"), page, limit)
+	if err != nil {
+		writeReviewError(c, err)
 		return
 	}
+	c.JSON(http.StatusOK, res)
+}
 
-	c.JSON(http.StatusOK, reviews)
+// Approve одобрить отзыв
+// @Summary Одобрить отзыв
+// @Tags admin
+// @Security BearerAuth
+// @Produce json
+// @Param id path int true "ID отзыва"
+// @Success 200 {object} models.Review
+// @Failure 400 {object} map[string]string "invalid id | invalid input"
+// @Failure 401 {object} map[string]string "unauthorized"
+// @Failure 403 {object} map[string]string "access denied"
+// @Failure 404 {object} map[string]string "review not found"
+// @Failure 500 {object} map[string]string "internal server error"
+// @Router /admin/reviews/{id}/approve [post]
+func (h ReviewHandler) Approve(c *gin.Context) {
+	moderatorID, err := getUserId(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+	reviewID, ok := parseIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+	defer cancel()
+
+	review, err := h.reviewService.Approve(ctx, reviewID, moderatorID)
+	if err != nil {
+		writeReviewError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, review)
+}
+
+// Reject отклонить отзыв с указанием причины
+// @Summary Отклонить отзыв
+// @Tags admin
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param id path int true "ID отзыва"
+// @Param input body models.RejectReviewDTO true "Причина отклонения"
+// @Success 200 {object} models.Review
+// @Failure 400 {object} map[string]string "invalid id | invalid body | invalid input"
+// @Failure 401 {object} map[string]string "unauthorized"
+// @Failure 403 {object} map[string]string "access denied"
+// @Failure 404 {object} map[string]string "review not found"
+// @Failure 500 {object} map[string]string "internal server error"
+// @Router /admin/reviews/{id}/reject [post]
+func (h ReviewHandler) Reject(c *gin.Context) {
+	moderatorID, err := getUserId(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+	reviewID, ok := parseIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	var dto models.RejectReviewDTO
+	if err := c.ShouldBindJSON(&dto); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid body"})
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+	defer cancel()
+
+	review, err := h.reviewService.Reject(ctx, reviewID, moderatorID, dto.Reason)
+	if err != nil {
+		writeReviewError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, review)
+}
+
+func writeReplyError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, erors.ErrNotHotelOwner):
+		c.JSON(http.StatusForbidden, gin.H{"error": "access denied"})
+	case errors.Is(err, erors.ErrConflict):
+		c.JSON(http.StatusConflict, gin.H{"error": "reply already exists"})
+	case errors.Is(err, erors.ErrNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": "not found"})
+	default:
+		writeReviewError(c, err)
+	}
+}
+
+// CreateReply ответ администрации отеля на отзыв
+// @Summary Ответить на отзыв от имени отеля
+// @Description Владелец отвечает на отзывы своих отелей, администратор — на любые. Один ответ на отзыв, только на опубликованные отзывы.
+// @Tags reviews
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param id path int true "ID отзыва"
+// @Param input body models.ReviewReplyDTO true "Текст ответа"
+// @Success 201 {object} models.ReviewReply
+// @Failure 400 {object} map[string]string "invalid id | invalid body | invalid input"
+// @Failure 401 {object} map[string]string "unauthorized"
+// @Failure 403 {object} map[string]string "access denied"
+// @Failure 404 {object} map[string]string "not found"
+// @Failure 409 {object} map[string]string "reply already exists"
+// @Failure 500 {object} map[string]string "internal server error"
+// @Router /reviews/{id}/reply [post]
+func (h ReviewHandler) CreateReply(c *gin.Context) {
+	h.saveReply(c, h.reviewService.CreateReply, http.StatusCreated)
+}
+
+// UpdateReply изменить ответ на отзыв
+// @Summary Изменить ответ на отзыв
+// @Tags reviews
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param id path int true "ID отзыва"
+// @Param input body models.ReviewReplyDTO true "Новый текст ответа"
+// @Success 200 {object} models.ReviewReply
+// @Failure 400 {object} map[string]string "invalid id | invalid body | invalid input"
+// @Failure 401 {object} map[string]string "unauthorized"
+// @Failure 403 {object} map[string]string "access denied"
+// @Failure 404 {object} map[string]string "not found"
+// @Failure 500 {object} map[string]string "internal server error"
+// @Router /reviews/{id}/reply [patch]
+func (h ReviewHandler) UpdateReply(c *gin.Context) {
+	h.saveReply(c, h.reviewService.UpdateReply, http.StatusOK)
+}
+
+// saveReply общая часть создания и правки ответа
+func (h ReviewHandler) saveReply(c *gin.Context, save func(ctx context.Context, reviewID, userID int64, role, body string) (models.ReviewReply, error), status int) {
+	userID, err := getUserId(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+	reviewID, ok := parseIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	var dto models.ReviewReplyDTO
+	if err := c.ShouldBindJSON(&dto); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid body"})
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+	defer cancel()
+
+	reply, err := save(ctx, reviewID, userID, c.GetString("userRole"), dto.Body)
+	if err != nil {
+		writeReplyError(c, err)
+		return
+	}
+	c.JSON(status, reply)
+}
+
+// DeleteReply удалить ответ на отзыв
+// @Summary Удалить ответ на отзыв
+// @Tags reviews
+// @Security BearerAuth
+// @Produce json
+// @Param id path int true "ID отзыва"
+// @Success 204 "Удалено"
+// @Failure 400 {object} map[string]string "invalid id | invalid input"
+// @Failure 401 {object} map[string]string "unauthorized"
+// @Failure 403 {object} map[string]string "access denied"
+// @Failure 404 {object} map[string]string "not found"
+// @Failure 500 {object} map[string]string "internal server error"
+// @Router /reviews/{id}/reply [delete]
+func (h ReviewHandler) DeleteReply(c *gin.Context) {
+	userID, err := getUserId(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+	reviewID, ok := parseIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+	defer cancel()
+
+	if err := h.reviewService.DeleteReply(ctx, reviewID, userID, c.GetString("userRole")); err != nil {
+		writeReplyError(c, err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// MarkHelpful отметить отзыв полезным
+// @Summary Отметить отзыв полезным
+// @Description Одна отметка от пользователя на отзыв; за свой отзыв голосовать нельзя.
+// @Tags reviews
+// @Security BearerAuth
+// @Produce json
+// @Param id path int true "ID отзыва"
+// @Success 200 {object} models.Review
+// @Failure 400 {object} map[string]string "invalid id | invalid input"
+// @Failure 401 {object} map[string]string "unauthorized"
+// @Failure 403 {object} map[string]string "access denied"
+// @Failure 404 {object} map[string]string "review not found"
+// @Failure 409 {object} map[string]string "already voted"
+// @Failure 500 {object} map[string]string "internal server error"
+// @Router /reviews/{id}/helpful [post]
+func (h ReviewHandler) MarkHelpful(c *gin.Context) {
+	userID, err := getUserId(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+	reviewID, ok := parseIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+	defer cancel()
+
+	review, err := h.reviewService.MarkHelpful(ctx, reviewID, userID)
+	if err != nil {
+		if errors.Is(err, erors.ErrConflict) {
+			c.JSON(http.StatusConflict, gin.H{"error": "already voted"})
+			return
+		}
+		writeReviewError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, review)
+}
+
+// Report пожаловаться на отзыв
+// @Summary Пожаловаться на отзыв
+// @Description Одна жалоба от пользователя на отзыв. Набравший несколько жалоб отзыв снимается с публикации до решения модератора.
+// @Tags reviews
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param id path int true "ID отзыва"
+// @Param input body models.ReportReviewDTO false "Причина жалобы"
+// @Success 200 {object} models.Review
+// @Failure 400 {object} map[string]string "invalid id | invalid body | invalid input"
+// @Failure 401 {object} map[string]string "unauthorized"
+// @Failure 403 {object} map[string]string "access denied"
+// @Failure 404 {object} map[string]string "review not found"
+// @Failure 409 {object} map[string]string "already reported"
+// @Failure 500 {object} map[string]string "internal server error"
+// @Router /reviews/{id}/report [post]
+func (h ReviewHandler) Report(c *gin.Context) {
+	userID, err := getUserId(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+	reviewID, ok := parseIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	// Тело необязательно: жалоба без причины тоже принимается
+	var dto models.ReportReviewDTO
+	if c.Request.ContentLength != 0 {
+		if err := c.ShouldBindJSON(&dto); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid body"})
+			return
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+	defer cancel()
+
+	review, err := h.reviewService.Report(ctx, reviewID, userID, dto.Reason)
+	if err != nil {
+		if errors.Is(err, erors.ErrConflict) {
+			c.JSON(http.StatusConflict, gin.H{"error": "already reported"})
+			return
+		}
+		writeReviewError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, review)
 }
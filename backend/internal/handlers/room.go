@@ -22,7 +22,7 @@ func NewRoomHandler(service services.RoomServiceInterface) RoomHandler {
 	return RoomHandler{roomService: service}
 }
 
-// Create создать комнату (admin)
+// Create создать комнату (доступ проверяет RequirePermission)
 // @Summary Создать комнату
 // @Tags rooms
 // @Security BearerAuth
@@ -35,13 +35,6 @@ func NewRoomHandler(service services.RoomServiceInterface) RoomHandler {
 // @Failure 500 {object} map[string]string "internal error"
 // @Router /rooms [post]
 func (h RoomHandler) Create(c *gin.Context) {
-	roleVal, exists := c.Get("userRole")
-	role, ok := roleVal.(string)
-	if !exists || !ok || role != "admin" {
-		c.JSON(http.StatusForbidden, gin.H{"error": "access denied"})
-		return
-	}
-
 	var room models.Room
 	if err := c.ShouldBindJSON(&room); err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid parameters"})
@@ -137,6 +130,7 @@ func (h RoomHandler) GetByID(c *gin.Context) {
 // @Param guests query int true "Количество гостей"
 // @Param checkin query string false "Дата заезда (YYYY-MM-DD)"
 // @Param checkout query string false "Дата выезда (YYYY-MM-DD)"
+// @Param amenities query string false "Коды удобств через запятую; учитываются удобства комнаты и отеля, нужны все"
 // @Success 200 {array} models.Room
 // @Failure 400 {object} map[string]string "city and guests are required | invalid guests | invalid input"
 // @Failure 500 {object} map[string]string "internal error"
@@ -159,7 +153,7 @@ func (h RoomHandler) Search(c *gin.Context) {
 	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
 	defer cancel()
 
-	rooms, err := h.roomService.SearchRooms(ctx, city, guests, checkin, checkout)
+	rooms, err := h.roomService.SearchRooms(ctx, city, guests, checkin, checkout, parseAmenitiesQuery(c))
 	if err != nil {
 		if errors.Is(err, erors.ErrInvalidInput) {
 			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid input"})
@@ -170,3 +164,126 @@ func (h RoomHandler) Search(c *gin.Context) {
 	}
 	c.JSON(http.StatusOK, rooms)
 }
+
+func writeRoomError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, erors.ErrInvalidInput):
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid input"})
+	case errors.Is(err, erors.ErrNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": "room not found"})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+	}
+}
+
+// Update частичное обновление комнаты (admin)
+// @Summary Частично обновить комнату
+// @Tags admin
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param id path int true "ID комнаты"
+// @Param input body models.UpdateRoomDTO true "Изменяемые поля"
+// @Success 200 {object} models.Room
+// @Failure 400 {object} map[string]string "invalid room id | invalid body | invalid input"
+// @Failure 401 {object} map[string]string "unauthorized"
+// @Failure 403 {object} map[string]string "access denied"
+// @Failure 404 {object} map[string]string "room not found"
+// @Failure 500 {object} map[string]string "internal server error"
+// @Router /admin/rooms/{id} [patch]
+func (h RoomHandler) Update(c *gin.Context) {
+	roomID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil || roomID <= 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid room id"})
+		return
+	}
+
+	var dto models.UpdateRoomDTO
+	if err := c.ShouldBindJSON(&dto); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid body"})
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+	defer cancel()
+
+	room, err := h.roomService.UpdateRoom(ctx, roomID, dto)
+	if err != nil {
+		writeRoomError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, room)
+}
+
+// Replace полное обновление комнаты (admin)
+// @Summary Полностью обновить комнату
+// @Tags admin
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param id path int true "ID комнаты"
+// @Param input body models.Room true "Новые данные комнаты (hotel_id и rating игнорируются)"
+// @Success 200 {object} models.Room
+// @Failure 400 {object} map[string]string "invalid room id | invalid body | invalid input"
+// @Failure 401 {object} map[string]string "unauthorized"
+// @Failure 403 {object} map[string]string "access denied"
+// @Failure 404 {object} map[string]string "room not found"
+// @Failure 500 {object} map[string]string "internal server error"
+// @Router /admin/rooms/{id} [put]
+func (h RoomHandler) Replace(c *gin.Context) {
+	roomID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil || roomID <= 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid room id"})
+		return
+	}
+
+	var input models.Room
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid body"})
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+	defer cancel()
+
+	room, err := h.roomService.ReplaceRoom(ctx, roomID, input)
+	if err != nil {
+		writeRoomError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, room)
+}
+
+// Delete удаление комнаты (admin)
+// @Summary Удалить комнату
+// @Description По умолчанию удаление мягкое: история отзывов, избранного и броней сохраняется.
+// @Description hard=true удаляет комнату физически вместе со всеми связанными данными.
+// @Tags admin
+// @Security BearerAuth
+// @Produce json
+// @Param id path int true "ID комнаты"
+// @Param hard query bool false "Удалить физически"
+// @Success 204 "Удалено"
+// @Failure 400 {object} map[string]string "invalid room id | invalid input"
+// @Failure 401 {object} map[string]string "unauthorized"
+// @Failure 403 {object} map[string]string "access denied"
+// @Failure 404 {object} map[string]string "room not found"
+// @Failure 500 {object} map[string]string "internal server error"
+// @Router /admin/rooms/{id} [delete]
+func (h RoomHandler) Delete(c *gin.Context) {
+	roomID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil || roomID <= 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid room id"})
+		return
+	}
+	hard := c.Query("hard") == "true"
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+	defer cancel()
+
+	if err := h.roomService.DeleteRoom(ctx, roomID, hard); err != nil {
+		writeRoomError(c, err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
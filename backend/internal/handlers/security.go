@@ -0,0 +1,113 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"backend/internal/erors"
+	"backend/internal/models"
+	"backend/internal/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+// SecurityHandler администрирование защиты входа: снятие блокировок и журнал событий
+type SecurityHandler struct {
+	guard services.LoginGuardServiceInterface
+}
+
+func NewSecurityHandler(guard services.LoginGuardServiceInterface) SecurityHandler {
+	return SecurityHandler{guard: guard}
+}
+
+func writeSecurityError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, erors.ErrInvalidInput):
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid input"})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+	}
+}
+
+// Unlock снять блокировку входа
+// @Summary Снять блокировку входа
+// @Description Сбрасывает счетчики неудачных попыток и действующую блокировку для email и/или IP-адреса.
+// @Description Снятие блокировки записывается в журнал безопасности.
+// @Tags admin
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param input body models.UnlockLoginDTO true "Email и/или IP-адрес"
+// @Success 204 "Блокировка снята"
+// @Failure 400 {object} map[string]string "invalid body | invalid input"
+// @Failure 401 {object} map[string]string "user authentication required"
+// @Failure 403 {object} map[string]string "access denied"
+// @Failure 500 {object} map[string]string "internal server error"
+// @Router /admin/security/unlock [post]
+func (h SecurityHandler) Unlock(c *gin.Context) {
+	adminID, err := getUserId(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "user authentication required"})
+		return
+	}
+
+	var dto models.UnlockLoginDTO
+	if err := c.ShouldBindJSON(&dto); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid body"})
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+	defer cancel()
+
+	if err := h.guard.Unlock(ctx, adminID, dto); err != nil {
+		writeSecurityError(c, err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// ListEvents журнал безопасности
+// @Summary Журнал безопасности
+// @Description Блокировки входа по email и IP и их снятие, от новых к старым.
+// @Tags admin
+// @Security BearerAuth
+// @Produce json
+// @Param type query string false "Тип: login_account_locked, login_ip_locked, login_unlocked"
+// @Param page query int false "Номер страницы (с 1)"
+// @Param limit query int false "Размер страницы (до 200)"
+// @Success 200 {object} models.SecurityEventListResponse
+// @Failure 400 {object} map[string]string "invalid input"
+// @Failure 401 {object} map[string]string "user authentication required"
+// @Failure 403 {object} map[string]string "access denied"
+// @Failure 500 {object} map[string]string "internal server error"
+// @Router /admin/security/events [get]
+func (h SecurityHandler) ListEvents(c *gin.Context) {
+	var page, limit int
+	var err error
+	if v := c.Query("page"); v != "" {
+		if page, err = strconv.Atoi(v); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid input"})
+			return
+		}
+	}
+	if v := c.Query("limit"); v != "" {
+		if limit, err = strconv.Atoi(v); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid input"})
+			return
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+	defer cancel()
+
+	res, err := h.guard.ListEvents(ctx, c.Query("type"), page, limit)
+	if err != nil {
+		writeSecurityError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, res)
+}
@@ -0,0 +1,112 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"backend/internal/erors"
+	"backend/internal/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+type TelegramHandler struct {
+	telegramService services.TelegramServiceInterface
+	tokenService    services.TokenServiceInterface
+}
+
+func NewTelegramHandler(telegramService services.TelegramServiceInterface, tokenService services.TokenServiceInterface) TelegramHandler {
+	return TelegramHandler{telegramService: telegramService, tokenService: tokenService}
+}
+
+// bindTelegramData читает данные виджета как есть: подпись считается по всем
+// присланным полям, поэтому неизвестные поля нельзя отбрасывать
+func bindTelegramData(c *gin.Context) (map[string]string, bool) {
+	dec := json.NewDecoder(c.Request.Body)
+	dec.UseNumber()
+	var raw map[string]any
+	if err := dec.Decode(&raw); err != nil {
+		return nil, false
+	}
+	data := make(map[string]string, len(raw))
+	for k, v := range raw {
+		switch v := v.(type) {
+		case string:
+			data[k] = v
+		case json.Number:
+			data[k] = v.String()
+		case bool:
+			data[k] = strconv.FormatBool(v)
+		default:
+			return nil, false
+		}
+	}
+	return data, true
+}
+
+// Login вход через Telegram Login Widget
+// @Summary Вход через Telegram
+// @Description Проверяет подпись данных виджета токеном бота и их свежесть (auth_date).
+// @Description С заголовком Authorization привязывает Telegram к текущей учетной записи,
+// @Description без него — входит в привязанную учетную запись или создает новую.
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param input body models.TelegramLoginDTO true "Данные Telegram Login Widget"
+// @Success 200 {object} models.AuthResponse "Пара токенов"
+// @Failure 400 {object} map[string]string "Неверные данные запроса"
+// @Failure 401 {object} map[string]string "Недействительные данные Telegram | Данные Telegram устарели"
+// @Failure 409 {object} map[string]string "Telegram привязан к другому пользователю"
+// @Failure 500 {object} map[string]string "Внутренняя ошибка сервера"
+// @Failure 503 {object} map[string]string "Вход через Telegram не настроен"
+// @Router /auth/telegram [post]
+func (h TelegramHandler) Login(c *gin.Context) {
+	data, ok := bindTelegramData(c)
+	if !ok {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Неверные данные запроса"})
+		return
+	}
+	// Маршрут открыт анонимно; ошибка означает лишь отсутствие входа
+	currentUserID, _ := getUserId(c)
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+	defer cancel()
+
+	user, err := h.telegramService.Login(ctx, data, currentUserID)
+	if err != nil {
+		switch {
+		case errors.Is(err, erors.ErrTelegramDisabled):
+			c.JSON(http.StatusServiceUnavailable, gin.H{"error": "Вход через Telegram не настроен"})
+		case errors.Is(err, erors.ErrTelegramAuthInvalid):
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "Недействительные данные Telegram"})
+		case errors.Is(err, erors.ErrTelegramAuthExpired):
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "Данные Telegram устарели"})
+		case errors.Is(err, erors.ErrConflict):
+			c.JSON(http.StatusConflict, gin.H{"error": "Telegram привязан к другому пользователю"})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Внутренняя ошибка сервера"})
+		}
+		return
+	}
+
+	response, err := h.tokenService.IssueTokenPair(ctx, user)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Внутренняя ошибка сервера"})
+		return
+	}
+	c.JSON(http.StatusOK, response)
+}
+
+// BotInfo параметры Telegram Login Widget
+// @Summary Параметры бота для входа через Telegram
+// @Tags auth
+// @Produce json
+// @Success 200 {object} models.TelegramBotInfo
+// @Router /auth/telegram/bot-info [get]
+func (h TelegramHandler) BotInfo(c *gin.Context) {
+	c.JSON(http.StatusOK, h.telegramService.BotInfo())
+}
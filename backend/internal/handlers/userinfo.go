@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"net/http"
+	"strconv"
 	"strings"
 	"time"
 
@@ -74,7 +75,7 @@ func (u UserHandler) GetUserInfo(c *gin.Context) {
 // @Produce json
 // @Param input body models.UserUpdateDTO true "Изменяемые поля"
 // @Success 204 "Обновлено"
-// @Failure 400 {object} map[string]string "invalid body | no fields to update | invalid input | email already taken"
+// @Failure 400 {object} map[string]any "invalid body | no fields to update | email already taken | Неверные данные запроса (fields — ошибки по полям)"
 // @Failure 401 {object} map[string]string "user authentication required"
 // @Failure 404 {object} map[string]string "user not found"
 // @Failure 500 {object} map[string]string "internal server error"
@@ -106,7 +107,11 @@ func (u UserHandler) UpdateUserInfo(c *gin.Context) {
 	defer cancel()
 
 	if err := u.userServ.UpdateUserInfo(ctx, dto); err != nil {
+		var ve *erors.ValidationError
 		switch {
+		case errors.As(err, &ve):
+			writeValidationError(c, err)
+			return
 		case errors.Is(err, erors.ErrInvalidInput):
 			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid input"})
 			return
@@ -124,3 +129,127 @@ func (u UserHandler) UpdateUserInfo(c *gin.Context) {
 
 	c.Status(http.StatusNoContent)
 }
+
+// UpdateRole сменить роль пользователя (admin)
+// @Summary Сменить роль пользователя
+// @Description Все сессии пользователя отзываются, новая роль действует после повторного входа
+// @Tags admin
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param id path int true "ID пользователя"
+// @Param input body models.UpdateRoleDTO true "Новая роль"
+// @Success 204 "Обновлено"
+// @Failure 400 {object} map[string]string "invalid user id | invalid body | invalid input"
+// @Failure 401 {object} map[string]string "unauthorized"
+// @Failure 403 {object} map[string]string "access denied"
+// @Failure 404 {object} map[string]string "user not found"
+// @Failure 500 {object} map[string]string "internal server error"
+// @Router /admin/users/{id}/role [patch]
+func (u UserHandler) UpdateRole(c *gin.Context) {
+	userID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil || userID <= 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid user id"})
+		return
+	}
+
+	var dto models.UpdateRoleDTO
+	if err := c.ShouldBindJSON(&dto); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid body"})
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+	defer cancel()
+
+	if err := u.userServ.UpdateRole(ctx, userID, dto.Role); err != nil {
+		switch {
+		case errors.Is(err, erors.ErrInvalidInput):
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid input"})
+		case errors.Is(err, erors.ErrUserNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": "user not found"})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+		}
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// GetPrivacy настройки приватности текущего пользователя
+// @Summary Настройки приватности
+// @Tags users
+// @Security BearerAuth
+// @Produce json
+// @Success 200 {object} models.PrivacySettings
+// @Failure 401 {object} map[string]string "user authentication required"
+// @Failure 404 {object} map[string]string "user not found"
+// @Failure 500 {object} map[string]string "internal server error"
+// @Router /users/me/privacy [get]
+func (u UserHandler) GetPrivacy(c *gin.Context) {
+	userID, err := getUserId(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "user authentication required"})
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+	defer cancel()
+
+	settings, err := u.userServ.GetPrivacy(ctx, userID)
+	if err != nil {
+		if errors.Is(err, erors.ErrUserNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "user not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+		return
+	}
+	c.JSON(http.StatusOK, settings)
+}
+
+// UpdatePrivacy изменить настройки приватности
+// @Summary Обновить настройки приватности
+// @Description Определяет, какие действия видят друзья в ленте и рекомендациях
+// @Tags users
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param input body models.UpdatePrivacyDTO true "Изменяемые настройки"
+// @Success 200 {object} models.PrivacySettings
+// @Failure 400 {object} map[string]string "invalid body | invalid input"
+// @Failure 401 {object} map[string]string "user authentication required"
+// @Failure 404 {object} map[string]string "user not found"
+// @Failure 500 {object} map[string]string "internal server error"
+// @Router /users/me/privacy [patch]
+func (u UserHandler) UpdatePrivacy(c *gin.Context) {
+	userID, err := getUserId(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "user authentication required"})
+		return
+	}
+
+	var dto models.UpdatePrivacyDTO
+	if err := c.ShouldBindJSON(&dto); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid body"})
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+	defer cancel()
+
+	settings, err := u.userServ.UpdatePrivacy(ctx, userID, dto)
+	if err != nil {
+		switch {
+		case errors.Is(err, erors.ErrInvalidInput):
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid input"})
+		case errors.Is(err, erors.ErrUserNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": "user not found"})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+		}
+		return
+	}
+	c.JSON(http.StatusOK, settings)
+}
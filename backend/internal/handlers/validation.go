@@ -0,0 +1,53 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"reflect"
+	"strings"
+
+	"backend/internal/erors"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+)
+
+// writeValidationError 400 «Неверные данные запроса»; для *erors.ValidationError
+// в ответ добавляются ошибки по полям
+func writeValidationError(c *gin.Context, err error) {
+	var ve *erors.ValidationError
+	if errors.As(err, &ve) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Неверные данные запроса", "fields": ve.Fields})
+		return
+	}
+	c.JSON(http.StatusBadRequest, gin.H{"error": "Неверные данные запроса"})
+}
+
+// bindingError переводит ошибки тегов binding в *erors.ValidationError; имена полей
+// берутся из json-тегов obj. Ошибки разбора JSON возвращаются как есть.
+func bindingError(err error, obj any) error {
+	var verrs validator.ValidationErrors
+	if !errors.As(err, &verrs) {
+		return err
+	}
+	t := reflect.TypeOf(obj)
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	fields := make([]erors.FieldError, 0, len(verrs))
+	for _, fe := range verrs {
+		name := fe.Field()
+		if sf, ok := t.FieldByName(fe.StructField()); ok {
+			if tag, _, _ := strings.Cut(sf.Tag.Get("json"), ","); tag != "" && tag != "-" {
+				name = tag
+			}
+		}
+		message := "Некорректное значение"
+		if fe.Tag() == "required" {
+			message = "Обязательное поле"
+		}
+		fields = append(fields, erors.FieldError{Field: name, Code: fe.Tag(), Message: message})
+	}
+	return &erors.ValidationError{Fields: fields}
+}
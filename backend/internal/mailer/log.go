@@ -0,0 +1,30 @@
+package mailer
+
+import (
+	"context"
+
+	"backend/internal/logger"
+
+	"go.uber.org/zap"
+)
+
+// LogMailer пишет письма в лог вместо отправки. Только для разработки: в логе окажутся
+// одноразовые ссылки из писем.
+type LogMailer struct {
+	from   string
+	logger logger.Logger
+}
+
+func NewLogMailer(from string, logger logger.Logger) *LogMailer {
+	return &LogMailer{from: from, logger: logger}
+}
+
+func (m *LogMailer) Send(ctx context.Context, msg Message) error {
+	m.logger.Info("mail",
+		zap.String("from", m.from),
+		zap.String("to", msg.To),
+		zap.String("subject", msg.Subject),
+		zap.String("body", msg.Body),
+	)
+	return nil
+}
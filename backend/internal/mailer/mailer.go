@@ -0,0 +1,17 @@
+// Package mailer отправка писем пользователям. Сервисы работают только с интерфейсом
+// Mailer: для разработки письма пишутся в лог или в каталог, в продакшене подключается SMTP-провайдер.
+package mailer
+
+import "context"
+
+// Message текстовое письмо одному получателю
+type Message struct {
+	To      string
+	Subject string
+	Body    string
+}
+
+// Mailer доставляет письма; ошибка означает, что письмо не принято к отправке
+type Mailer interface {
+	Send(ctx context.Context, msg Message) error
+}
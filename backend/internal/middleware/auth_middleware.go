@@ -10,17 +10,19 @@ import (
 )
 
 const (
-	ctxUserIDKey = "userid"
-	ctxUserRole  = "userRole"
-	ctxUserEmail = "useremail"
+	ctxUserIDKey    = "userid"
+	ctxUserRole     = "userRole"
+	ctxUserEmail    = "useremail"
+	ctxSessionIDKey = "sessionid"
 )
 
 type AuthMiddleware struct {
-	jwtService services.JWTService
+	jwtService     services.JWTService
+	sessionService services.SessionServiceInterface
 }
 
-func NewAuthMiddleware(jwtService services.JWTService) AuthMiddleware {
-	return AuthMiddleware{jwtService: jwtService}
+func NewAuthMiddleware(jwtService services.JWTService, sessionService services.SessionServiceInterface) AuthMiddleware {
+	return AuthMiddleware{jwtService: jwtService, sessionService: sessionService}
 }
 
 func (m AuthMiddleware) RequireAuth() gin.HandlerFunc {
@@ -40,12 +42,26 @@ func (m AuthMiddleware) RequireAuth() gin.HandlerFunc {
 		token := strings.TrimSpace(parts[1])
 
 		claims, err := m.jwtService.ValidateToken(token)
-		if err != nil {
+		if err != nil || claims.TokenType == services.TokenTypeRefresh {
 			c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid token"})
 			c.Abort()
 			return
 		}
 
+		// Токены без jti выпущены до появления сессий и не могут быть отозваны — не принимаем их
+		active, err := m.sessionService.IsActive(c.Request.Context(), claims.ID)
+		if err != nil {
+			log.Printf("AuthMiddleware: session check failed: %v", err)
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+			c.Abort()
+			return
+		}
+		if !active {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "Session revoked"})
+			c.Abort()
+			return
+		}
+
 		role := claims.Role
 		if role == "" {
 			role = "unknown"
@@ -55,7 +71,21 @@ func (m AuthMiddleware) RequireAuth() gin.HandlerFunc {
 		c.Set(ctxUserIDKey, claims.UserID)
 		c.Set(ctxUserEmail, claims.Email)
 		c.Set(ctxUserRole, role)
+		c.Set(ctxSessionIDKey, claims.ID)
 
 		c.Next()
 	}
 }
+
+// OptionalAuth для публичных маршрутов, ответ которых зависит от зрителя: без заголовка
+// Authorization запрос проходит анонимно, а переданный токен проверяется так же, как в RequireAuth.
+func (m AuthMiddleware) OptionalAuth() gin.HandlerFunc {
+	requireAuth := m.RequireAuth()
+	return func(c *gin.Context) {
+		if c.GetHeader("Authorization") == "" {
+			c.Next()
+			return
+		}
+		requireAuth(c)
+	}
+}
@@ -0,0 +1,41 @@
+package middleware
+
+import (
+	"net/http"
+
+	"backend/internal/models"
+
+	"github.com/gin-gonic/gin"
+)
+
+// RequireRole пропускает запрос, только если роль пользователя входит в roles.
+// Должен стоять после RequireAuth, который кладет роль в контекст.
+func (m AuthMiddleware) RequireRole(roles ...string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		role := c.GetString(ctxUserRole)
+		for _, r := range roles {
+			if role == r {
+				c.Next()
+				return
+			}
+		}
+		c.JSON(http.StatusForbidden, gin.H{"error": "access denied"})
+		c.Abort()
+	}
+}
+
+// RequirePermission пропускает запрос, только если роли пользователя выданы все перечисленные права.
+// Должен стоять после RequireAuth.
+func (m AuthMiddleware) RequirePermission(permissions ...string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		role := c.GetString(ctxUserRole)
+		for _, p := range permissions {
+			if !models.RoleHasPermission(role, p) {
+				c.JSON(http.StatusForbidden, gin.H{"error": "access denied"})
+				c.Abort()
+				return
+			}
+		}
+		c.Next()
+	}
+}
@@ -0,0 +1,45 @@
+package models
+
+import "time"
+
+// Назначение одноразовых токенов из писем
+const (
+    AccountTokenVerifyEmail   = "verify_email"
+    AccountTokenResetPassword = "reset_password"
+)
+
+// AccountToken одноразовый токен из письма (во внешних ответах не отдается)
+type AccountToken struct {
+    UserID    int64
+    Purpose   string
+    Email     string // адрес, на который ушло письмо
+    ExpiresAt time.Time
+}
+
+// VerifyEmailDTO подтверждение email по ссылке из письма
+// @Description Токен из ссылки подтверждения
+type VerifyEmailDTO struct {
+    // Токен из письма
+    // required: true
+    Token string `json:"token" binding:"required" example:"9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08"`
+}
+
+// ForgotPasswordDTO запрос письма для сброса пароля
+// @Description Email учетной записи
+type ForgotPasswordDTO struct {
+    // Email пользователя
+    // required: true
+    Email string `json:"email" binding:"required" example:"alice@example.com"`
+}
+
+// ResetPasswordDTO установка нового пароля по ссылке из письма
+// @Description Токен из письма и новый пароль
+type ResetPasswordDTO struct {
+    // Токен из письма
+    // required: true
+    Token string `json:"token" binding:"required" example:"2c26b46b68ffc68ff99b453c1d30413413422d706483bfa0f98a5e886266e7ae"`
+
+    // Новый пароль по тем же правилам, что и при регистрации
+    // required: true
+    Password string `json:"password" binding:"required" example:"N3w-Tr4vel-Pass"`
+}
@@ -0,0 +1,49 @@
+package models
+
+// Области применения удобства
+const (
+    AmenityScopeHotel = "hotel"
+    AmenityScopeRoom  = "room"
+    AmenityScopeBoth  = "both"
+)
+
+// Amenity удобство из справочника
+// @Description Удобство отеля или комнаты; в Hotel/Room возвращаются только коды
+type Amenity struct {
+    // Уникальный идентификатор
+    ID int64 `db:"id" json:"id" example:"1"`
+
+    // Код удобства (латиница, цифры, подчеркивание; регистр значим, как в типе Amenity фронтенда)
+    Code string `db:"code" json:"code" example:"wifi"`
+
+    // Отображаемое название
+    Name string `db:"name" json:"name" example:"Бесплатный Wi-Fi"`
+
+    // Область применения: hotel, room или both
+    Scope string `db:"scope" json:"scope" example:"both"`
+}
+
+// UpdateAmenityDTO частичное обновление удобства; код не меняется
+// @Description Изменяются только переданные поля
+type UpdateAmenityDTO struct {
+    // Новое название
+    Name *string `json:"name,omitempty" example:"Wi-Fi"`
+
+    // Новая область применения
+    Scope *string `json:"scope,omitempty" example:"room"`
+}
+
+// SetAmenitiesDTO полный набор удобств отеля или комнаты
+// @Description Заменяет текущий набор; пустой список удаляет все удобства
+type SetAmenitiesDTO struct {
+    Codes []string `json:"codes" example:"wifi,pool"`
+}
+
+// IsValidAmenityScope проверяет, что область применения известна
+func IsValidAmenityScope(scope string) bool {
+    switch scope {
+    case AmenityScopeHotel, AmenityScopeRoom, AmenityScopeBoth:
+        return true
+    }
+    return false
+}
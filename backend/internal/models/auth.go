@@ -3,19 +3,20 @@ package models
 // CreateUserDTO входные данные для регистрации пользователя
 // @Description Данные, необходимые для создания нового пользователя
 type CreateUserDTO struct {
-    // Имя пользователя
+    // Имя пользователя (2–100 символов)
     // required: true
-    Name string `db:"name" json:"name" example:"Alice"`
+    Name string `db:"name" json:"name" binding:"required" example:"Alice"`
 
     // Email пользователя
     // required: true
-    Email string `db:"email" json:"email" example:"alice@example.com"`
+    Email string `db:"email" json:"email" binding:"required" example:"alice@example.com"`
 
-    // Пароль пользователя (минимум 6 символов)
+    // Пароль: 8–72 байт, минимум три вида символов из четырех (строчные, заглавные, цифры, другие),
+    // не из списка распространенных и без имени или email
     // required: true
-    Password string `db:"password" json:"password" example:"Passw0rd!"`
+    Password string `db:"password" json:"password" binding:"required" example:"Tr4vel-Moscow"`
 
-    // Дата рождения в формате YYYY-MM-DD
+    // Дата рождения в формате YYYY-MM-DD; если указана, возраст не меньше account.min_age
     // required: false
     DateOfBirth string `db:"date_of_birth" json:"date_of_birth" example:"1998-07-15"`
 
@@ -23,9 +24,8 @@ type CreateUserDTO struct {
     // required: false
     City string `db:"city" json:"city" example:"Moscow"`
 
-    // Роль пользователя (user/admin)
-    // required: false
-    Role string `json:"role" example:"user"`
+    // Роль пользователя; из запроса не принимается, при регистрации всегда user
+    Role string `json:"-"`
 }
 
 // LoginUserDTO входные данные для логина
@@ -49,3 +49,11 @@ type AuthResponse struct {
     // JWT refresh token
     RefreshToken string `json:"refresh_token" example:"eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9..."`
 }
+
+// RefreshTokenDTO входные данные для обновления пары токенов
+// @Description Действующий refresh token, полученный при логине или предыдущем обновлении
+type RefreshTokenDTO struct {
+    // JWT refresh token
+    // required: true
+    RefreshToken string `json:"refresh_token" binding:"required" example:"eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9..."`
+}
@@ -0,0 +1,66 @@
+package models
+
+// Статусы бронирования
+const (
+	BookingStatusPending   = "pending"
+	BookingStatusConfirmed = "confirmed"
+	BookingStatusCancelled = "cancelled"
+	BookingStatusCompleted = "completed"
+)
+
+// Booking модель бронирования
+// @Description Бронирование комнаты пользователем на диапазон дат
+type Booking struct {
+	// Уникальный идентификатор бронирования
+	ID int64 `db:"id" json:"id" example:"501"`
+
+	// Идентификатор забронированной комнаты
+	RoomID int64 `db:"room_id" json:"room_id" example:"2001"`
+
+	// Идентификатор отеля, к которому относится комната
+	HotelID int64 `db:"hotel_id" json:"hotel_id" example:"101"`
+
+	// Идентификатор пользователя, создавшего бронь
+	UserID int64 `db:"user_id" json:"user_id" example:"7"`
+
+	// Дата заезда (YYYY-MM-DD)
+	CheckIn string `db:"checkin" json:"checkin" example:"2025-11-01"`
+
+	// Дата выезда (YYYY-MM-DD), не входит в период проживания
+	CheckOut string `db:"checkout" json:"checkout" example:"2025-11-05"`
+
+	// Количество гостей
+	Guests int `db:"guests" json:"guests" example:"2"`
+
+	// Итоговая стоимость за весь период
+	TotalPrice int `db:"total_price" json:"total_price" example:"18000"`
+
+	// Статус брони (pending/confirmed/cancelled/completed)
+	Status string `db:"status" json:"status" example:"pending"`
+
+	// Дата создания (ISO8601)
+	CreatedAt string `db:"created_at" json:"created_at" example:"2025-10-01T18:30:00Z"`
+
+	// Дата последнего изменения (ISO8601)
+	UpdatedAt string `db:"updated_at" json:"updated_at" example:"2025-10-01T18:30:00Z"`
+}
+
+// CreateBookingDTO входные данные для создания брони
+// @Description Комната, даты и количество гостей; user_id берется из JWT контекста
+type CreateBookingDTO struct {
+	// Идентификатор комнаты
+	// required: true
+	RoomID int64 `json:"room_id" binding:"required,min=1" example:"2001"`
+
+	// Дата заезда (YYYY-MM-DD)
+	// required: true
+	CheckIn string `json:"checkin" binding:"required" example:"2025-11-01"`
+
+	// Дата выезда (YYYY-MM-DD)
+	// required: true
+	CheckOut string `json:"checkout" binding:"required" example:"2025-11-05"`
+
+	// Количество гостей
+	// required: true
+	Guests int `json:"guests" binding:"required,min=1" example:"2"`
+}
@@ -0,0 +1,60 @@
+package models
+
+import "time"
+
+// Типы событий ленты
+const (
+    FeedItemReview   = "review"
+    FeedItemFavorite = "favorite"
+    FeedItemStay     = "stay"
+)
+
+// FeedActor друг, совершивший действие
+type FeedActor struct {
+    ID   int64  `json:"id" example:"12"`
+    Name string `json:"name" example:"Bob"`
+}
+
+// FeedReview отзыв в событии ленты
+type FeedReview struct {
+    ID          int64  `json:"id" example:"501"`
+    RoomRating  int    `json:"room_rating" example:"5"`
+    HotelRating int    `json:"hotel_rating" example:"4"`
+    Description string `json:"description" example:"Отличный вид из окна"`
+}
+
+// FeedItem событие ленты друзей
+// @Description Отзыв, добавление в избранное или завершенное проживание друга
+type FeedItem struct {
+    // Стабильный идентификатор события
+    ID string `json:"id" example:"review:501"`
+
+    // Тип: review, favorite или stay
+    Type string `json:"type" example:"review"`
+
+    // Автор события
+    Actor FeedActor `json:"actor"`
+
+    RoomID    int64  `json:"room_id" example:"2001"`
+    HotelID   int64  `json:"hotel_id" example:"101"`
+    HotelName string `json:"hotel_name" example:"Grand Plaza"`
+    City      string `json:"city" example:"Moscow"`
+
+    // Время события (ISO8601)
+    OccurredAt string `json:"occurred_at" example:"2025-10-01T18:30:00Z"`
+
+    // Отзыв (только для type=review)
+    Review *FeedReview `json:"review,omitempty"`
+
+    // Время события для курсора пагинации
+    Time time.Time `json:"-"`
+}
+
+// FeedResponse страница ленты
+// @Description События по убыванию времени и курсор следующей страницы
+type FeedResponse struct {
+    Data []FeedItem `json:"data"`
+
+    // Передать в ?cursor= для следующей страницы; пусто — событий больше нет
+    NextCursor string `json:"next_cursor,omitempty" example:"MjAyNS0xMC0wMVQxODozMDowMHxyZXZpZXc6NTAx"`
+}
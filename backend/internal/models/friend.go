@@ -0,0 +1,45 @@
+package models
+
+// Статусы связи между пользователями
+const (
+    FriendStatusPending  = "pending"
+    FriendStatusAccepted = "accepted"
+    FriendStatusBlocked  = "blocked"
+)
+
+// Friendship связь двух пользователей: заявка, дружба или блокировка.
+// UserID — отправитель заявки (или тот, кто заблокировал), FriendID — вторая сторона.
+type Friendship struct {
+    UserID   int64  `db:"user_id"`
+    FriendID int64  `db:"friend_id"`
+    Status   string `db:"status"`
+}
+
+// Friend другой пользователь в списке друзей или заявок
+// @Description Публичные данные второй стороны связи и ее статус
+type Friend struct {
+    // Идентификатор пользователя
+    ID int64 `json:"id" example:"12"`
+
+    // Имя
+    Name string `json:"name" example:"Bob"`
+
+    // Город
+    City string `json:"city,omitempty" example:"Kazan"`
+
+    // Статус связи: pending или accepted
+    Status string `json:"status" example:"accepted"`
+
+    // Когда связь получила текущий статус (ISO8601)
+    Since string `json:"since" example:"2025-10-01T18:30:00Z"`
+}
+
+// FriendRequestDTO заявка в друзья по email или ID пользователя
+// @Description Нужно указать одно из полей
+type FriendRequestDTO struct {
+    // Email пользователя
+    Email string `json:"email" example:"friend@example.com"`
+
+    // ID пользователя
+    UserID int64 `json:"user_id" example:"12"`
+}
@@ -26,4 +26,183 @@ type Hotel struct {
 
     // Список идентификаторов комнат, относящихся к отелю
     Rooms []int64 `db:"rooms" json:"rooms" example:"[2001,2002,2003]"`
+
+    // Идентификатор владельца (партнера); пусто для отелей, заведенных администрацией
+    OwnerID int64 `db:"owner_id" json:"owner_id,omitempty" example:"15"`
+
+    // Минимальная цена за ночь среди комнат отеля
+    MinPrice int `db:"min_price" json:"min_price" example:"3500"`
+
+    // Рейтинг отеля (только в списке /hotels; совпадает с averageRating)
+    Rating float64 `db:"rating" json:"rating" example:"4.5"`
+
+    // Средняя оценка отеля по одобренным отзывам на его комнаты; 0, если отзывов нет
+    AverageRating float64 `db:"average_rating" json:"averageRating" example:"4.5"`
+
+    // Количество одобренных отзывов на комнаты отеля
+    ReviewCount int `db:"review_count" json:"reviewCount" example:"37"`
+
+    // Широта; null, если координаты отеля не заданы
+    Latitude *float64 `db:"latitude" json:"latitude" example:"55.7602"`
+
+    // Долгота; null, если координаты отеля не заданы
+    Longitude *float64 `db:"longitude" json:"longitude" example:"37.6085"`
+
+    // Коды удобств отеля
+    Amenities []string `db:"amenities" json:"amenities" example:"wifi,pool"`
+
+    // Фотографии в порядке галереи
+    Images ImageList `db:"images" json:"images"`
+
+    // Расстояние до точки поиска в километрах (только в ответе /hotels/nearby)
+    DistanceKm *float64 `json:"distance_km,omitempty" example:"1.27"`
+}
+
+// UpdateHotelDTO частичное обновление отеля
+// @Description Изменяются только переданные поля; отсутствующие остаются прежними
+type UpdateHotelDTO struct {
+    // Новое название
+    Name *string `json:"name,omitempty" example:"Grand Plaza Deluxe"`
+
+    // Новый город
+    City *string `json:"city,omitempty" example:"Moscow"`
+
+    // Новое описание
+    Description *string `json:"description,omitempty" example:"Renovated in 2025"`
+
+    // Новое количество звёзд (1-5)
+    Stars *int `json:"stars,omitempty" example:"5"`
+
+    // Новый адрес
+    Address *string `json:"address,omitempty" example:"Tverskaya St, 9"`
+
+    // Новая широта (передается вместе с долготой)
+    Latitude *float64 `json:"latitude,omitempty" example:"55.7602"`
+
+    // Новая долгота (передается вместе с широтой)
+    Longitude *float64 `json:"longitude,omitempty" example:"37.6085"`
+}
+
+// HotelFilter параметры выборки списка отелей
+// @Description Фильтры, сортировка и пагинация для GET /hotels
+type HotelFilter struct {
+    // Номер страницы, начиная с 1
+    Page int `json:"page" example:"1"`
+
+    // Размер страницы
+    Limit int `json:"limit" example:"20"`
+
+    // Город (подстрока, без учета регистра)
+    City string `json:"city,omitempty" example:"Moscow"`
+
+    // Минимальное количество звёзд
+    MinStars int `json:"min_stars,omitempty" example:"3"`
+
+    // Нижняя граница цены "от" (минимальной цены за ночь в отеле)
+    MinPrice int `json:"min_price,omitempty" example:"2000"`
+
+    // Верхняя граница цены "от"; 0 — без ограничения
+    MaxPrice int `json:"max_price,omitempty" example:"8000"`
+
+    // Минимальный рейтинг
+    MinRating float64 `json:"min_rating,omitempty" example:"4"`
+
+    // Поле сортировки: price, rating или stars
+    SortBy string `json:"sort_by,omitempty" example:"price"`
+
+    // Направление сортировки: asc или desc
+    SortOrder string `json:"sort_order,omitempty" example:"asc"`
+
+    // Коды удобств; отель должен иметь их все
+    Amenities []string `json:"amenities,omitempty" example:"wifi,pool"`
+}
+
+// Pagination метаданные страницы
+// @Description Общее количество элементов и параметры текущей страницы
+type Pagination struct {
+    Total      int `json:"total" example:"135"`
+    Page       int `json:"page" example:"1"`
+    Limit      int `json:"limit" example:"20"`
+    TotalPages int `json:"totalPages" example:"7"`
+}
+
+// HotelListResponse страница списка отелей
+// @Description Отели текущей страницы и метаданные пагинации
+type HotelListResponse struct {
+    Data       []Hotel    `json:"data"`
+    Pagination Pagination `json:"pagination"`
+}
+
+// RoomSearchHit комната, описание которой совпало с поисковым запросом
+// @Description Фрагмент описания комнаты с подсветкой найденных слов
+type RoomSearchHit struct {
+    // Идентификатор комнаты
+    RoomID int64 `json:"room_id" example:"2001"`
+
+    // Фрагмент описания (HTML-экранирован); найденные слова обернуты в <mark></mark>
+    Snippet string `json:"snippet" example:"Номер с выходом к <mark>бассейну</mark>"`
+}
+
+// HotelSearchHit результат полнотекстового поиска
+// @Description Отель, его релевантность и фрагменты текста с подсветкой
+type HotelSearchHit struct {
+    // Найденный отель
+    Hotel Hotel `json:"hotel"`
+
+    // Релевантность (чем больше, тем выше в выдаче)
+    Rank float64 `json:"rank" example:"0.42"`
+
+    // Название отеля с подсветкой (HTML-экранировано)
+    NameHighlight string `json:"name_highlight" example:"Отель <mark>Море</mark>"`
+
+    // Фрагмент описания отеля с подсветкой (HTML-экранирован)
+    Snippet string `json:"snippet" example:"Пять минут до <mark>моря</mark>, открытый <mark>бассейн</mark>"`
+
+    // Совпавшие комнаты отеля (не больше трех)
+    Rooms []RoomSearchHit `json:"rooms"`
+}
+
+// HotelSearchResponse страница результатов поиска
+// @Description Результаты поиска по убыванию релевантности и метаданные пагинации
+type HotelSearchResponse struct {
+    Data       []HotelSearchHit `json:"data"`
+    Pagination Pagination       `json:"pagination"`
+}
+
+// NearbyFilter поиск отелей в радиусе от точки
+// @Description Центр поиска и радиус в километрах
+type NearbyFilter struct {
+    Lat      float64 `json:"lat" example:"55.7558"`
+    Lng      float64 `json:"lng" example:"37.6173"`
+    RadiusKm float64 `json:"radius_km" example:"5"`
+    Limit    int     `json:"limit" example:"50"`
+}
+
+// BoundingBox прямоугольная область карты
+// @Description Если MinLng больше MaxLng, область пересекает 180-й меридиан
+type BoundingBox struct {
+    MinLat float64 `json:"min_lat" example:"55.70"`
+    MinLng float64 `json:"min_lng" example:"37.50"`
+    MaxLat float64 `json:"max_lat" example:"55.80"`
+    MaxLng float64 `json:"max_lng" example:"37.70"`
+    Limit  int     `json:"limit" example:"200"`
+}
+
+// RatingSummary сводка оценок отеля по одобренным отзывам
+// @Description Средние оценки, число отзывов и распределение оценок отеля по звездам
+type RatingSummary struct {
+    // Идентификатор отеля
+    HotelID int64 `json:"hotel_id" example:"101"`
+
+    // Средняя оценка отеля (hotel_rating)
+    AverageRating float64 `json:"averageRating" example:"4.5"`
+
+    // Средняя оценка комнат отеля (room_rating)
+    AverageRoomRating float64 `json:"averageRoomRating" example:"4.3"`
+
+    // Количество одобренных отзывов
+    ReviewCount int `json:"reviewCount" example:"37"`
+
+    // Сколько отзывов поставили отелю оценку 1..5; ключ — оценка
+    Distribution map[int]int `json:"distribution" swaggertype:"object,integer" example:"1:0,2:1,3:4,4:12,5:20"`
 }
@@ -0,0 +1,81 @@
+package models
+
+import (
+    "encoding/json"
+    "fmt"
+)
+
+// Владельцы фотографий
+const (
+    ImageOwnerHotel = "hotel"
+    ImageOwnerRoom  = "room"
+)
+
+// Image фотография отеля или комнаты
+// @Description Поля названы как в типе Image фронтенда
+type Image struct {
+    // Уникальный идентификатор
+    ID int64 `db:"id" json:"id" example:"17"`
+
+    // URL оригинала
+    URL string `db:"url" json:"url" example:"/uploads/hotels/101/5f2c9a.jpg"`
+
+    // URL превью
+    ThumbnailURL string `db:"thumbnail_url" json:"thumbnailUrl" example:"/uploads/hotels/101/5f2c9a_thumb.jpg"`
+
+    // Альтернативный текст
+    Alt string `db:"alt" json:"alt" example:"Вид из лобби"`
+
+    // Главная фотография (обложка)
+    IsPrimary bool `db:"is_primary" json:"isPrimary" example:"true"`
+
+    // Порядок в галерее, начиная с 0
+    Position int `db:"position" json:"position" example:"0"`
+
+    // Ширина и высота оригинала в пикселях
+    Width  int `db:"width" json:"width" example:"1920"`
+    Height int `db:"height" json:"height" example:"1080"`
+
+    // Служебные поля хранилища, наружу не отдаются
+    StorageKey   string `db:"storage_key" json:"-"`
+    ThumbnailKey string `db:"thumbnail_key" json:"-"`
+    ContentType  string `db:"content_type" json:"-"`
+    SizeBytes    int    `db:"size_bytes" json:"-"`
+}
+
+// ImageList галерея; сканируется из json_agg в запросах отелей и комнат
+type ImageList []Image
+
+// Scan реализует sql.Scanner для JSON-массива фотографий
+func (l *ImageList) Scan(src any) error {
+    var data []byte
+    switch v := src.(type) {
+    case nil:
+        *l = ImageList{}
+        return nil
+    case []byte:
+        data = v
+    case string:
+        data = []byte(v)
+    default:
+        return fmt.Errorf("image list: unsupported type %T", src)
+    }
+    res := ImageList{}
+    if err := json.Unmarshal(data, &res); err != nil {
+        return fmt.Errorf("image list: %w", err)
+    }
+    *l = res
+    return nil
+}
+
+// ImageOwner отель или комната, которой принадлежат фотографии
+type ImageOwner struct {
+    Kind string
+    ID   int64
+}
+
+// ReorderImagesDTO новый порядок фотографий
+// @Description Полный список ID фотографий владельца в нужном порядке
+type ReorderImagesDTO struct {
+    IDs []int64 `json:"ids" example:"18,17,19"`
+}
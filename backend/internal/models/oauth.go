@@ -0,0 +1,49 @@
+package models
+
+import "time"
+
+// OAuthStartResponse адрес авторизации у провайдера
+// @Description Перенаправьте пользователя на authorization_url; state вернется в callback
+type OAuthStartResponse struct {
+    AuthorizationURL string `json:"authorization_url" example:"https://oauth.yandex.ru/authorize?client_id=...&state=..."`
+    State            string `json:"state" example:"5f0c9a..."`
+}
+
+// OAuthCallbackDTO параметры, с которыми провайдер вернул пользователя
+// @Description code и state из redirect_url провайдера
+type OAuthCallbackDTO struct {
+    Code  string `json:"code" binding:"required" example:"4/0AX4XfWh..."`
+    State string `json:"state" binding:"required" example:"5f0c9a..."`
+
+    // VK ID передает device_id вместе с code; он нужен для обмена кода на токен
+    DeviceID string `json:"device_id,omitempty" example:"abc123"`
+}
+
+// OAuthState незавершенный вход, сохраненный до возврата от провайдера
+type OAuthState struct {
+    Provider     string
+    CodeVerifier string
+
+    // Пользователь, привязывающий аккаунт провайдера; 0 — обычный вход
+    UserID    int64
+    ExpiresAt time.Time
+}
+
+// OAuthProfile данные пользователя из userinfo провайдера
+type OAuthProfile struct {
+    Subject       string
+    Email         string
+    EmailVerified bool
+    Name          string
+}
+
+// UserIdentity привязанная внешняя учетная запись
+// @Description Провайдер и данные аккаунта, через который можно войти
+type UserIdentity struct {
+    ID            int64   `json:"id" example:"3"`
+    Provider      string  `json:"provider" example:"yandex"`
+    Email         string  `json:"email,omitempty" example:"alice@yandex.ru"`
+    EmailVerified bool    `json:"email_verified" example:"true"`
+    CreatedAt     string  `json:"created_at" example:"2025-10-01T18:30:00Z"`
+    LastLoginAt   *string `json:"last_login_at,omitempty" example:"2025-10-05T09:00:00Z"`
+}
@@ -0,0 +1,75 @@
+package models
+
+// Виды рекомендаций
+const (
+    RecommendationTypeRoom  = "room"
+    RecommendationTypeHotel = "hotel"
+)
+
+// Источники сигналов от друзей
+const (
+    SignalFavorite = "favorite"
+    SignalVisit    = "visit"
+    SignalReview   = "review"
+)
+
+// FriendSignal одно действие друга с комнатой: добавил в избранное, жил или высоко оценил.
+// Служебная структура для расчета рекомендаций, наружу не отдается.
+type FriendSignal struct {
+    FriendID   int64
+    FriendName string
+
+    // Количество общих друзей с пользователем — мера близости
+    MutualFriends int
+
+    Kind    string
+    AgeDays float64
+
+    RoomID             int64
+    RoomPrice          int
+    RoomAverageRating  float64
+    HotelID            int64
+    HotelName          string
+    City               string
+    HotelAverageRating float64
+
+    // Пользователь уже знаком с отелем (жил, бронировал, оценивал или добавлял в избранное его комнаты)
+    HotelKnown bool
+}
+
+// Recommendation рекомендованная комната или отель
+// @Description Объект рекомендации, его оценка и объяснение на основе активности друзей
+type Recommendation struct {
+    // Тип: room или hotel
+    Type string `json:"type" example:"room"`
+
+    // ID комнаты или отеля (в зависимости от типа)
+    ID int64 `json:"id" example:"2001"`
+
+    // ID отеля
+    HotelID int64 `json:"hotel_id" example:"101"`
+
+    // Название отеля
+    HotelName string `json:"hotel_name" example:"Grand Plaza"`
+
+    // Город
+    City string `json:"city" example:"Moscow"`
+
+    // Цена за ночь (только для комнат)
+    Price int `json:"price,omitempty" example:"4500"`
+
+    // Средний рейтинг комнаты или отеля
+    AverageRating float64 `json:"averageRating" example:"4.5"`
+
+    // Итоговая оценка: чем больше, тем выше в выдаче
+    Score float64 `json:"score" example:"3.72"`
+
+    // Сколько друзей проявили интерес
+    FriendCount int `json:"friend_count" example:"3"`
+
+    // Имена самых близких из них (не больше трех)
+    Friends []string `json:"friends" example:"Bob,Carol"`
+
+    // Короткое объяснение
+    Reason string `json:"reason" example:"3 friends liked this"`
+}
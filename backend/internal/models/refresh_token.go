@@ -0,0 +1,20 @@
+package models
+
+import (
+	"database/sql"
+	"time"
+)
+
+// RefreshToken запись о выданном refresh-токене.
+// Хранится только хэш токена; все токены, полученные цепочкой ротаций
+// от одного логина, объединены общим FamilyID.
+type RefreshToken struct {
+	ID        int64        `db:"id"`
+	UserID    int64        `db:"user_id"`
+	FamilyID  string       `db:"family_id"`
+	TokenHash string       `db:"token_hash"`
+	ExpiresAt time.Time    `db:"expires_at"`
+	UsedAt    sql.NullTime `db:"used_at"`
+	RevokedAt sql.NullTime `db:"revoked_at"`
+	CreatedAt time.Time    `db:"created_at"`
+}
@@ -1,5 +1,21 @@
 package models
 
+// Статусы модерации отзыва
+const (
+    ReviewStatusPending  = "pending"
+    ReviewStatusApproved = "approved"
+    ReviewStatusRejected = "rejected"
+)
+
+// IsValidReviewStatus проверяет значение статуса модерации
+func IsValidReviewStatus(status string) bool {
+    switch status {
+    case ReviewStatusPending, ReviewStatusApproved, ReviewStatusRejected:
+        return true
+    }
+    return false
+}
+
 // Review модель отзыва
 // @Description Отзыв пользователя о комнате/отеле с оценками и статусом модерации
 type Review struct {
@@ -24,8 +40,93 @@ type Review struct {
     // Оценка отеля (1-5)
     HotelRating int `db:"hotel_rating" json:"hotel_rating" example:"4"`
 
-    // Статус модерации
+    // Опубликован ли отзыв. Выставляется только модератором, значение от клиента игнорируется
     Approved bool `db:"approved" json:"approved" example:"true"`
+
+    // Статус модерации: pending, approved или rejected
+    Status string `json:"status" example:"approved"`
+
+    // Причина отклонения (только для отклоненных отзывов)
+    RejectionReason string `db:"rejection_reason" json:"rejection_reason,omitempty" example:"Нецензурная лексика"`
+
+    // Дата последнего редактирования автором (ISO8601)
+    UpdatedAt *string `db:"updated_at" json:"updated_at,omitempty" example:"2025-10-02T09:00:00Z"`
+
+    // Сколько пользователей отметили отзыв полезным
+    HelpfulCount int `db:"helpful_count" json:"helpful_count" example:"12"`
+
+    // Жалобы с момента последнего одобрения модератором
+    ReportCount int `db:"report_count" json:"report_count" example:"0"`
+
+    // Ответ администрации отеля, если есть
+    Reply *ReviewReply `json:"reply,omitempty"`
+}
+
+// ReportReviewDTO жалоба на отзыв
+// @Description Причина необязательна, но помогает модератору
+type ReportReviewDTO struct {
+    Reason string `json:"reason" example:"Реклама другого отеля"`
+}
+
+// Порядок сортировки отзывов о комнате
+const (
+    ReviewSortNewest  = "newest"
+    ReviewSortHelpful = "helpful"
+    ReviewSortRating  = "rating"
+)
+
+// ReviewReply публичный ответ владельца или администратора отеля на отзыв
+// @Description Не больше одного ответа на отзыв
+type ReviewReply struct {
+    // Уникальный идентификатор ответа
+    ID int64 `db:"id" json:"id" example:"55"`
+
+    // Идентификатор отзыва
+    ReviewID int64 `db:"review_id" json:"review_id" example:"1001"`
+
+    // Кто последним написал ответ; пусто, если пользователь удален
+    AuthorID *int64 `db:"author_id" json:"author_id,omitempty" example:"15"`
+
+    // Текст ответа
+    Body string `db:"body" json:"body" example:"Спасибо за отзыв! Завтраки уже расширили."`
+
+    // Дата создания (ISO8601)
+    CreatedAt string `db:"created_at" json:"created_at" example:"2025-10-03T10:00:00Z"`
+
+    // Дата последнего изменения (ISO8601)
+    UpdatedAt *string `db:"updated_at" json:"updated_at,omitempty" example:"2025-10-03T12:00:00Z"`
+}
+
+// ReviewReplyDTO текст ответа на отзыв
+// @Description Используется и для создания, и для правки ответа
+type ReviewReplyDTO struct {
+    Body string `json:"body" binding:"required" example:"Спасибо за отзыв! Завтраки уже расширили."`
+}
+
+// UpdateReviewDTO правка отзыва автором; переданы могут быть только изменяемые поля
+// @Description После правки отзыв снова уходит на модерацию
+type UpdateReviewDTO struct {
+    // Текст отзыва
+    Description *string `json:"description" example:"Тихо, чисто; завтрак мог быть разнообразнее"`
+
+    // Оценка комнаты (1-5)
+    RoomRating *int `json:"room_rating" example:"4"`
+
+    // Оценка отеля (1-5)
+    HotelRating *int `json:"hotel_rating" example:"4"`
+}
+
+// RejectReviewDTO причина отклонения отзыва
+// @Description Причина обязательна — ее увидит автор отзыва
+type RejectReviewDTO struct {
+    Reason string `json:"reason" binding:"required" example:"Отзыв не относится к проживанию"`
+}
+
+// ReviewListResponse страница очереди модерации
+// @Description Отзывы текущей страницы и метаданные пагинации
+type ReviewListResponse struct {
+    Data       []Review   `json:"data"`
+    Pagination Pagination `json:"pagination"`
 }
 
 // CreateReviewDTO входные данные для создания отзыва
@@ -0,0 +1,52 @@
+package models
+
+// Роли пользователей
+const (
+	RoleAdmin      = "admin"
+	RoleModerator  = "moderator"
+	RoleHotelOwner = "hotel_owner"
+	RoleUser       = "user"
+)
+
+// Права доступа, которые проверяет middleware RequirePermission
+const (
+	PermManageHotels    = "hotels:manage"
+	PermManageRooms     = "rooms:manage"
+	PermModerateReviews = "reviews:moderate"
+	PermManageUsers     = "users:manage"
+	PermManageOwnHotels = "hotels:manage_own"
+)
+
+// rolePermissions права каждой роли; admin получает все права
+var rolePermissions = map[string][]string{
+	RoleAdmin: {
+		PermManageHotels,
+		PermManageRooms,
+		PermModerateReviews,
+		PermManageUsers,
+		PermManageOwnHotels,
+	},
+	RoleModerator: {
+		PermModerateReviews,
+	},
+	RoleHotelOwner: {
+		PermManageOwnHotels,
+	},
+	RoleUser: {},
+}
+
+// IsValidRole проверяет, что роль известна системе
+func IsValidRole(role string) bool {
+	_, ok := rolePermissions[role]
+	return ok
+}
+
+// RoleHasPermission проверяет, выдано ли роли указанное право
+func RoleHasPermission(role, permission string) bool {
+	for _, p := range rolePermissions[role] {
+		if p == permission {
+			return true
+		}
+	}
+	return false
+}
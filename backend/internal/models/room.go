@@ -12,14 +12,39 @@ type Room struct {
     // Цена за ночь (в целых единицах, например, рублях)
     Price int `db:"price" json:"price" example:"4500"`
 
-    // Текущий рейтинг комнаты (1-5)
+    // Округленная средняя оценка комнаты (1-5); считается из одобренных отзывов
     Rating int `db:"rating" json:"rating" example:"4"`
 
+    // Средняя оценка комнаты по одобренным отзывам; 0, если отзывов нет
+    AverageRating float64 `db:"average_rating" json:"averageRating" example:"4.33"`
+
+    // Количество одобренных отзывов
+    ReviewCount int `db:"review_count" json:"reviewCount" example:"12"`
+
     // Описание комнаты
     Description string `db:"description" json:"description" example:"Уютный номер с видом на город"`
 
     // Идентификатор отеля, к которому относится комната
     HotelID int64 `db:"hotel_id" json:"hotel_id" example:"101"`
+
+    // Коды удобств комнаты
+    Amenities []string `db:"amenities" json:"amenities" example:"wifi,tv"`
+
+    // Фотографии в порядке галереи
+    Images ImageList `db:"images" json:"images"`
+}
+
+// UpdateRoomDTO частичное обновление комнаты
+// @Description Изменяются только переданные поля; рейтинг считается из отзывов и здесь не меняется
+type UpdateRoomDTO struct {
+    // Новое количество спальных мест
+    Beds *int `json:"beds,omitempty" example:"3"`
+
+    // Новая цена за ночь
+    Price *int `json:"price,omitempty" example:"5200"`
+
+    // Новое описание
+    Description *string `json:"description,omitempty" example:"Номер после ремонта"`
 }
 
 // CreateRoomDTO входные данные для создания комнаты
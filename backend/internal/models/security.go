@@ -0,0 +1,66 @@
+package models
+
+import "time"
+
+// Типы событий безопасности
+const (
+    SecurityEventAccountLocked = "login_account_locked" // вход в аккаунт заблокирован после серии неудачных попыток
+    SecurityEventIPLocked      = "login_ip_locked"      // вход с IP-адреса заблокирован
+    SecurityEventLoginUnlocked = "login_unlocked"       // блокировку снял администратор
+)
+
+// IsValidSecurityEventType проверяет тип события для фильтра журнала
+func IsValidSecurityEventType(t string) bool {
+    switch t {
+    case SecurityEventAccountLocked, SecurityEventIPLocked, SecurityEventLoginUnlocked:
+        return true
+    }
+    return false
+}
+
+// LoginAttempt счетчик неудачных входов по одному ключу (email или IP); во внешних ответах не отдается
+type LoginAttempt struct {
+    Failures      int
+    LastFailureAt time.Time
+    LockedUntil   time.Time // нулевое значение — вход не ограничен
+}
+
+// SecurityEvent запись журнала безопасности
+// @Description Блокировка входа или ее снятие
+type SecurityEvent struct {
+    ID int64 `json:"id" example:"12"`
+
+    // login_account_locked, login_ip_locked или login_unlocked
+    Type string `json:"type" example:"login_account_locked"`
+
+    // Владелец аккаунта, если email зарегистрирован
+    UserID *int64 `json:"user_id,omitempty" example:"7"`
+
+    Email string `json:"email,omitempty" example:"alice@example.com"`
+    IP    string `json:"ip,omitempty" example:"203.0.113.7"`
+
+    // Неудачных попыток подряд на момент блокировки
+    Failures int `json:"failures,omitempty" example:"10"`
+
+    // До какого момента вход заблокирован
+    LockedUntil *time.Time `json:"locked_until,omitempty" example:"2025-10-05T09:15:00Z"`
+
+    // Администратор, снявший блокировку
+    ActorID *int64 `json:"actor_id,omitempty" example:"1"`
+
+    CreatedAt string `json:"created_at" example:"2025-10-05T09:00:00Z"`
+}
+
+// SecurityEventListResponse страница журнала безопасности
+// @Description События текущей страницы и метаданные пагинации
+type SecurityEventListResponse struct {
+    Data       []SecurityEvent `json:"data"`
+    Pagination Pagination      `json:"pagination"`
+}
+
+// UnlockLoginDTO снятие блокировки входа администратором
+// @Description Нужно указать email, IP-адрес или оба
+type UnlockLoginDTO struct {
+    Email string `json:"email,omitempty" example:"alice@example.com"`
+    IP    string `json:"ip,omitempty" example:"203.0.113.7"`
+}
@@ -0,0 +1,17 @@
+package models
+
+import (
+	"database/sql"
+	"time"
+)
+
+// Session серверная сессия, открываемая при логине.
+// JTI попадает в claim jti каждого access-токена сессии и совпадает
+// с FamilyID refresh-токенов, выданных в ней.
+type Session struct {
+	JTI       string       `db:"jti"`
+	UserID    int64        `db:"user_id"`
+	CreatedAt time.Time    `db:"created_at"`
+	ExpiresAt time.Time    `db:"expires_at"`
+	RevokedAt sql.NullTime `db:"revoked_at"`
+}
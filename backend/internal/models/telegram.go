@@ -0,0 +1,38 @@
+package models
+
+// TelegramLoginDTO данные Telegram Login Widget в том виде, в каком их передает виджет
+// @Description Все поля из callback виджета без изменений; hash проверяется по токену бота
+type TelegramLoginDTO struct {
+    ID        int64  `json:"id" example:"123456789"`
+    FirstName string `json:"first_name" example:"Alice"`
+    LastName  string `json:"last_name,omitempty" example:"Smith"`
+    Username  string `json:"username,omitempty" example:"alice_dev"`
+    PhotoURL  string `json:"photo_url,omitempty" example:"https://t.me/i/userpic/320/alice.jpg"`
+    AuthDate  int64  `json:"auth_date" example:"1735689600"`
+    Hash      string `json:"hash" example:"c0ffee..."`
+}
+
+// TelegramUser проверенные данные пользователя Telegram
+type TelegramUser struct {
+    ID        int64
+    FirstName string
+    LastName  string
+    Username  string
+    AuthDate  int64
+}
+
+// TelegramBotInfo параметры для встраивания Telegram Login Widget
+// @Description Имя бота для data-telegram-login и адрес проверки данных
+type TelegramBotInfo struct {
+    // Вход через Telegram настроен на сервере
+    Enabled bool `json:"enabled" example:"true"`
+
+    // Имя бота без @
+    BotUsername string `json:"bot_username,omitempty" example:"staygo_bot"`
+
+    // Числовой ID бота
+    BotID int64 `json:"bot_id,omitempty" example:"7000000000"`
+
+    // Куда отправлять данные виджета
+    AuthURL string `json:"auth_url" example:"/auth/telegram"`
+}
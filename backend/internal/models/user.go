@@ -29,6 +29,9 @@ type User struct {
     // Роль пользователя (user/admin)
     Role string `db:"role" json:"role" example:"user"`
 
+    // Email подтвержден по ссылке из письма
+    EmailVerified bool `db:"email_verified" json:"email_verified" example:"true"`
+
     // Список ID отзывов пользователя
     ReviewIDs []int64 `db:"review_ids" json:"review_ids" example:"[1001,1002]"`
 
@@ -54,6 +57,9 @@ type UserInfoDTO struct {
     // Email пользователя
     Email string `json:"email" example:"alice@example.com"`
 
+    // Email подтвержден; без подтверждения нельзя оставлять отзывы
+    EmailVerified bool `json:"email_verified" example:"true"`
+
     // Дата рождения (может отсутствовать)
     DateOfBirth string `json:"date_of_birth,omitempty" example:"1998-07-15"`
 
@@ -64,6 +70,14 @@ type UserInfoDTO struct {
     CreatedAt string `json:"created_at" example:"2025-09-01T10:20:30Z"`
 }
 
+// UpdateRoleDTO DTO для смены роли пользователя администратором
+// @Description Новая роль пользователя: admin, moderator, hotel_owner или user
+type UpdateRoleDTO struct {
+    // Новая роль
+    // required: true
+    Role string `json:"role" binding:"required" example:"moderator"`
+}
+
 // UserUpdateDTO DTO для обновления данных пользователя
 // @Description Поля, которые можно изменить в профиле
 type UserUpdateDTO struct {
@@ -73,9 +87,30 @@ type UserUpdateDTO struct {
     // Новое имя пользователя
     Name string `json:"name" example:"Alice"`
 
-    // Новый email пользователя
+    // Новый email пользователя; после смены его нужно подтвердить заново
     Email string `json:"email" example:"alice.new@example.com"`
 
     // Новый город (опционально)
     City string `json:"city,omitempty" example:"Saint Petersburg"`
 }
+
+// PrivacySettings что пользователь показывает друзьям
+// @Description Видимость активности в ленте друзей и рекомендациях
+type PrivacySettings struct {
+    // Показывать опубликованные отзывы
+    ShareReviews bool `json:"share_reviews" example:"true"`
+
+    // Показывать избранные комнаты
+    ShareFavorites bool `json:"share_favorites" example:"true"`
+
+    // Показывать завершенные проживания
+    ShareStays bool `json:"share_stays" example:"false"`
+}
+
+// UpdatePrivacyDTO изменяемые настройки приватности
+// @Description Передаются только изменяемые поля
+type UpdatePrivacyDTO struct {
+    ShareReviews   *bool `json:"share_reviews,omitempty" example:"true"`
+    ShareFavorites *bool `json:"share_favorites,omitempty" example:"false"`
+    ShareStays     *bool `json:"share_stays,omitempty" example:"false"`
+}
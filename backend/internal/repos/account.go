@@ -0,0 +1,175 @@
+package repos
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"backend/internal/erors"
+	"backend/internal/models"
+)
+
+type AccountRepoInterface interface {
+	GetEmailStatus(ctx context.Context, userID int64) (email string, verified bool, err error)
+	CreateToken(ctx context.Context, tokenHash string, token models.AccountToken) error
+	VerifyEmail(ctx context.Context, tokenHash string) (int64, error)
+	ResetPassword(ctx context.Context, tokenHash, passwordHash string) (int64, error)
+}
+
+type AccountRepo struct {
+	DB *sql.DB
+}
+
+func NewAccountRepo(db *sql.DB) AccountRepoInterface {
+	return AccountRepo{DB: db}
+}
+
+// GetEmailStatus текущий email пользователя и подтвержден ли он
+func (r AccountRepo) GetEmailStatus(ctx context.Context, userID int64) (string, bool, error) {
+	var (
+		email    string
+		verified bool
+	)
+	err := r.DB.QueryRowContext(ctx,
+		`SELECT email, email_verified_at IS NOT NULL FROM users WHERE id = $1`, userID,
+	).Scan(&email, &verified)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return "", false, erors.ErrUserNotFound
+		}
+		return "", false, fmt.Errorf("get email status: %w", err)
+	}
+	return email, verified, nil
+}
+
+// CreateToken сохраняет новый токен; прежние неиспользованные токены того же назначения
+// перестают действовать, просроченные удаляются
+func (r AccountRepo) CreateToken(ctx context.Context, tokenHash string, token models.AccountToken) error {
+	tx, err := r.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("create account token: begin: %w", err)
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM account_tokens WHERE expires_at < now()`); err != nil {
+		return fmt.Errorf("create account token: cleanup: %w", err)
+	}
+	if err := revokeTokens(ctx, tx, token.UserID, token.Purpose); err != nil {
+		return fmt.Errorf("create account token: %w", err)
+	}
+	_, err = tx.ExecContext(ctx, `
+		INSERT INTO account_tokens (token_hash, user_id, purpose, email, expires_at)
+		VALUES ($1, $2, $3, $4, $5)
+	`, tokenHash, token.UserID, token.Purpose, token.Email, token.ExpiresAt)
+	if err != nil {
+		return fmt.Errorf("create account token: insert: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("create account token: commit: %w", err)
+	}
+	return nil
+}
+
+// VerifyEmail гасит токен и подтверждает адрес, на который он был отправлен.
+// Неизвестный, использованный или просроченный токен, как и токен для прежнего
+// email — ErrInvalidToken.
+func (r AccountRepo) VerifyEmail(ctx context.Context, tokenHash string) (int64, error) {
+	tx, err := r.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, fmt.Errorf("verify email: begin: %w", err)
+	}
+	defer tx.Rollback()
+
+	token, err := consumeToken(ctx, tx, tokenHash, models.AccountTokenVerifyEmail)
+	if err != nil {
+		return 0, fmt.Errorf("verify email: %w", err)
+	}
+	res, err := tx.ExecContext(ctx, `
+		UPDATE users SET email_verified_at = COALESCE(email_verified_at, now())
+		WHERE id = $1 AND email = $2
+	`, token.UserID, token.Email)
+	if err := checkTokenApplied(res, err); err != nil {
+		return 0, fmt.Errorf("verify email: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, fmt.Errorf("verify email: commit: %w", err)
+	}
+	return token.UserID, nil
+}
+
+// ResetPassword гасит токен и меняет пароль. Письмо со ссылкой дошло до владельца адреса,
+// поэтому email заодно считается подтвержденным. Остальные ссылки сброса перестают действовать.
+func (r AccountRepo) ResetPassword(ctx context.Context, tokenHash, passwordHash string) (int64, error) {
+	tx, err := r.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, fmt.Errorf("reset password: begin: %w", err)
+	}
+	defer tx.Rollback()
+
+	token, err := consumeToken(ctx, tx, tokenHash, models.AccountTokenResetPassword)
+	if err != nil {
+		return 0, fmt.Errorf("reset password: %w", err)
+	}
+	res, err := tx.ExecContext(ctx, `
+		UPDATE users
+		SET password = $3, email_verified_at = COALESCE(email_verified_at, now())
+		WHERE id = $1 AND email = $2
+	`, token.UserID, token.Email, passwordHash)
+	if err := checkTokenApplied(res, err); err != nil {
+		return 0, fmt.Errorf("reset password: %w", err)
+	}
+	if err := revokeTokens(ctx, tx, token.UserID, models.AccountTokenResetPassword); err != nil {
+		return 0, fmt.Errorf("reset password: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, fmt.Errorf("reset password: commit: %w", err)
+	}
+	return token.UserID, nil
+}
+
+// consumeToken отмечает токен использованным; повторно он не сработает
+func consumeToken(ctx context.Context, tx *sql.Tx, tokenHash, purpose string) (models.AccountToken, error) {
+	t := models.AccountToken{Purpose: purpose}
+	err := tx.QueryRowContext(ctx, `
+		UPDATE account_tokens SET used_at = now()
+		WHERE token_hash = $1 AND purpose = $2 AND used_at IS NULL AND expires_at > now()
+		RETURNING user_id, email, expires_at
+	`, tokenHash, purpose).Scan(&t.UserID, &t.Email, &t.ExpiresAt)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return models.AccountToken{}, erors.ErrInvalidToken
+		}
+		return models.AccountToken{}, fmt.Errorf("consume token: %w", err)
+	}
+	return t, nil
+}
+
+// checkTokenApplied пользователь мог сменить email после отправки письма — тогда токен недействителен
+func checkTokenApplied(res sql.Result, err error) error {
+	if err != nil {
+		return fmt.Errorf("update user: %w", err)
+	}
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("update user: affected: %w", err)
+	}
+	if affected == 0 {
+		return erors.ErrInvalidToken
+	}
+	return nil
+}
+
+func revokeTokens(ctx context.Context, tx *sql.Tx, userID int64, purpose string) error {
+	_, err := tx.ExecContext(ctx, `
+		UPDATE account_tokens SET used_at = now()
+		WHERE user_id = $1 AND purpose = $2 AND used_at IS NULL
+	`, userID, purpose)
+	if err != nil {
+		return fmt.Errorf("revoke tokens: %w", err)
+	}
+	return nil
+}
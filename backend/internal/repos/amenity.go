@@ -0,0 +1,184 @@
+package repos
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"backend/internal/erors"
+	"backend/internal/models"
+
+	"github.com/lib/pq"
+)
+
+// Подзапросы кодов удобств для SELECT отелей (алиас h) и комнат (алиас r)
+const (
+	hotelAmenitiesColumn = `ARRAY(
+		SELECT a.code FROM hotel_amenities ha JOIN amenities a ON a.id = ha.amenity_id
+		WHERE ha.hotel_id = h.id ORDER BY a.code
+	)`
+	roomAmenitiesColumn = `ARRAY(
+		SELECT a.code FROM room_amenities ra JOIN amenities a ON a.id = ra.amenity_id
+		WHERE ra.room_id = r.id ORDER BY a.code
+	)`
+)
+
+type AmenityRepoInterface interface {
+	List(ctx context.Context, scope string) ([]models.Amenity, error)
+	Create(ctx context.Context, amenity *models.Amenity) error
+	Update(ctx context.Context, amenityID int64, dto models.UpdateAmenityDTO) (models.Amenity, error)
+	Delete(ctx context.Context, amenityID int64) error
+	SetHotelAmenities(ctx context.Context, hotelID int64, codes []string) error
+	SetRoomAmenities(ctx context.Context, roomID int64, codes []string) error
+}
+
+type AmenityRepo struct {
+	DB *sql.DB
+}
+
+func NewAmenityRepo(db *sql.DB) AmenityRepoInterface {
+	return AmenityRepo{DB: db}
+}
+
+// List справочник удобств; при непустом scope — только применимые к нему (включая both)
+func (r AmenityRepo) List(ctx context.Context, scope string) ([]models.Amenity, error) {
+	const q = `
+		SELECT id, code, name, scope
+		FROM amenities
+		WHERE $1 = '' OR scope = $1 OR scope = 'both'
+		ORDER BY code ASC
+	`
+	rows, err := r.DB.QueryContext(ctx, q, scope)
+	if err != nil {
+		return nil, fmt.Errorf("list amenities: query: %w", err)
+	}
+	defer rows.Close()
+
+	res := []models.Amenity{}
+	for rows.Next() {
+		var a models.Amenity
+		if err := rows.Scan(&a.ID, &a.Code, &a.Name, &a.Scope); err != nil {
+			return nil, fmt.Errorf("list amenities: scan: %w", err)
+		}
+		res = append(res, a)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("list amenities: rows: %w", err)
+	}
+	return res, nil
+}
+
+func (r AmenityRepo) Create(ctx context.Context, amenity *models.Amenity) error {
+	const q = `
+		INSERT INTO amenities (code, name, scope)
+		VALUES ($1, $2, $3)
+		RETURNING id
+	`
+	err := r.DB.QueryRowContext(ctx, q, amenity.Code, amenity.Name, amenity.Scope).Scan(&amenity.ID)
+	if err != nil {
+		var pqErr *pq.Error
+		if errors.As(err, &pqErr) && pqErr.Code == "23505" {
+			return erors.ErrConflict
+		}
+		return fmt.Errorf("create amenity: %w", err)
+	}
+	return nil
+}
+
+// Update меняет только переданные (не nil) поля
+func (r AmenityRepo) Update(ctx context.Context, amenityID int64, dto models.UpdateAmenityDTO) (models.Amenity, error) {
+	const q = `
+		UPDATE amenities
+		SET name  = COALESCE($1, name),
+		    scope = COALESCE($2, scope)
+		WHERE id = $3
+		RETURNING id, code, name, scope
+	`
+	var a models.Amenity
+	err := r.DB.QueryRowContext(ctx, q, dto.Name, dto.Scope, amenityID).Scan(&a.ID, &a.Code, &a.Name, &a.Scope)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return models.Amenity{}, erors.ErrNotFound
+		}
+		return models.Amenity{}, fmt.Errorf("update amenity: %w", err)
+	}
+	return a, nil
+}
+
+// Delete удаляет удобство из справочника; привязки к отелям и комнатам удаляются каскадно
+func (r AmenityRepo) Delete(ctx context.Context, amenityID int64) error {
+	res, err := r.DB.ExecContext(ctx, `DELETE FROM amenities WHERE id = $1`, amenityID)
+	if err != nil {
+		return fmt.Errorf("delete amenity: exec: %w", err)
+	}
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("delete amenity: affected: %w", err)
+	}
+	if affected == 0 {
+		return erors.ErrNotFound
+	}
+	return nil
+}
+
+func (r AmenityRepo) SetHotelAmenities(ctx context.Context, hotelID int64, codes []string) error {
+	return r.setAmenities(ctx, "hotels", "hotel_amenities", "hotel_id", models.AmenityScopeHotel, hotelID, codes)
+}
+
+func (r AmenityRepo) SetRoomAmenities(ctx context.Context, roomID int64, codes []string) error {
+	return r.setAmenities(ctx, "rooms", "room_amenities", "room_id", models.AmenityScopeRoom, roomID, codes)
+}
+
+// setAmenities заменяет набор удобств владельца одной транзакцией. Неизвестный код или
+// удобство с неподходящей областью применения дают ErrInvalidInput, удаленный владелец — ErrNotFound.
+// Имена таблиц передаются только константами из методов выше.
+func (r AmenityRepo) setAmenities(ctx context.Context, ownerTable, linkTable, ownerColumn, scope string, ownerID int64, codes []string) error {
+	tx, err := r.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("set amenities: begin: %w", err)
+	}
+	defer tx.Rollback()
+
+	var exists bool
+	err = tx.QueryRowContext(ctx,
+		`SELECT EXISTS (SELECT 1 FROM `+ownerTable+` WHERE id = $1 AND deleted_at IS NULL)`, ownerID,
+	).Scan(&exists)
+	if err != nil {
+		return fmt.Errorf("set amenities: owner: %w", err)
+	}
+	if !exists {
+		return erors.ErrNotFound
+	}
+
+	var ids []int64
+	err = tx.QueryRowContext(ctx, `
+		SELECT COALESCE(array_agg(id), '{}')
+		FROM amenities
+		WHERE code = ANY($1) AND (scope = $2 OR scope = 'both')
+	`, pq.Array(codes), scope).Scan(pq.Array(&ids))
+	if err != nil {
+		return fmt.Errorf("set amenities: resolve codes: %w", err)
+	}
+	if len(ids) != len(codes) {
+		return erors.ErrInvalidInput
+	}
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM `+linkTable+` WHERE `+ownerColumn+` = $1`, ownerID); err != nil {
+		return fmt.Errorf("set amenities: clear: %w", err)
+	}
+	if len(ids) > 0 {
+		_, err := tx.ExecContext(ctx,
+			`INSERT INTO `+linkTable+` (`+ownerColumn+`, amenity_id) SELECT $1, unnest($2::int[])`,
+			ownerID, pq.Array(ids),
+		)
+		if err != nil {
+			return fmt.Errorf("set amenities: insert: %w", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("set amenities: commit: %w", err)
+	}
+	return nil
+}
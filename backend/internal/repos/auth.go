@@ -16,7 +16,7 @@ type authRepo struct {
 type AuthRepoInterface interface {
 	CreateUser(ctx context.Context, user models.CreateUserDTO) (int64, error)
 	GetUserByEmail(ctx context.Context, email string) (models.User, error)
-
+	GetUserByID(ctx context.Context, userID int64) (models.User, error)
 }
 
 func NewAuthRepo(db *sql.DB) AuthRepoInterface {
@@ -30,7 +30,7 @@ func (r *authRepo) CreateUser(ctx context.Context, user models.CreateUserDTO) (i
 
     err := r.db.QueryRowContext(
         ctx, `INSERT INTO users (name, email, password, city, date_of_birth, role)
-         VALUES ($1, $2, $3, $4, $5, $6) RETURNING id`,
+         VALUES ($1, $2, $3, NULLIF($4, ''), NULLIF($5, '')::date, $6) RETURNING id`,
         user.Name, user.Email, user.Password, user.City, user.DateOfBirth, user.Role,
     ).Scan(&ID)
 
@@ -51,7 +51,8 @@ func (r *authRepo) GetUserByEmail(ctx context.Context, email string) (models.Use
     var user models.User
     err := r.db.QueryRowContext(
         ctx,
-        `SELECT id, name, email, password, date_of_birth, created_at, city, role, refresh 
+        `SELECT id, name, email, password, COALESCE(to_char(date_of_birth, 'YYYY-MM-DD'), ''), created_at, COALESCE(city, ''), role, refresh,
+                email_verified_at IS NOT NULL
          FROM users WHERE email = $1`,
         email,
     ).Scan(
@@ -64,6 +65,7 @@ func (r *authRepo) GetUserByEmail(ctx context.Context, email string) (models.Use
         &user.City,
         &user.Role,
         &user.Refresh,
+        &user.EmailVerified,
     )
     if err != nil {
         if errors.Is(err, sql.ErrNoRows) {
@@ -73,3 +75,19 @@ func (r *authRepo) GetUserByEmail(ctx context.Context, email string) (models.Use
     }
     return user, nil
 }
+
+func (r *authRepo) GetUserByID(ctx context.Context, userID int64) (models.User, error) {
+    var user models.User
+    err := r.db.QueryRowContext(
+        ctx,
+        `SELECT id, name, email, role FROM users WHERE id = $1`,
+        userID,
+    ).Scan(&user.ID, &user.Name, &user.Email, &user.Role)
+    if err != nil {
+        if errors.Is(err, sql.ErrNoRows) {
+            return user, erors.ErrUserNotFound
+        }
+        return user, err
+    }
+    return user, nil
+}
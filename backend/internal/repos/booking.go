@@ -0,0 +1,142 @@
+package repos
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"backend/internal/erors"
+	"backend/internal/models"
+
+	"github.com/lib/pq"
+)
+
+type BookingRepoInterface interface {
+	Create(ctx context.Context, booking *models.Booking) error
+	GetByID(ctx context.Context, bookingID int64) (models.Booking, error)
+	ListByUserID(ctx context.Context, userID int64) ([]models.Booking, error)
+	UpdateStatus(ctx context.Context, bookingID int64, from []string, to string) (models.Booking, error)
+}
+
+type BookingRepo struct {
+	DB *sql.DB
+}
+
+func NewBookingRepo(db *sql.DB) BookingRepoInterface {
+	return BookingRepo{DB: db}
+}
+
+const bookingColumns = `
+	b.id, b.room_id, r.hotel_id, b.user_id, b.checkin::text, b.checkout::text,
+	b.guests, b.total_price, b.status, b.created_at, b.updated_at
+`
+
+func scanBooking(row interface{ Scan(...any) error }, b *models.Booking) error {
+	return row.Scan(
+		&b.ID, &b.RoomID, &b.HotelID, &b.UserID, &b.CheckIn, &b.CheckOut,
+		&b.Guests, &b.TotalPrice, &b.Status, &b.CreatedAt, &b.UpdatedAt,
+	)
+}
+
+// Create сохраняет бронь. Пересечение дат с другой активной бронью той же комнаты
+// отсекается exclusion-констрейнтом bookings_no_overlap, поэтому гонка двух
+// одновременных запросов невозможна: второй получит ErrRoomUnavailable.
+func (r BookingRepo) Create(ctx context.Context, booking *models.Booking) error {
+	const q = `
+		INSERT INTO bookings (room_id, user_id, checkin, checkout, guests, total_price, status)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		RETURNING id, checkin::text, checkout::text, created_at, updated_at
+	`
+	err := r.DB.QueryRowContext(ctx, q,
+		booking.RoomID, booking.UserID, booking.CheckIn, booking.CheckOut,
+		booking.Guests, booking.TotalPrice, booking.Status,
+	).Scan(&booking.ID, &booking.CheckIn, &booking.CheckOut, &booking.CreatedAt, &booking.UpdatedAt)
+	if err != nil {
+		var pqErr *pq.Error
+		if errors.As(err, &pqErr) {
+			switch pqErr.Code {
+			case "23P01": // exclusion_violation
+				return erors.ErrRoomUnavailable
+			case "23503", "23514": // foreign_key_violation, check_violation
+				return erors.ErrInvalidInput
+			}
+		}
+		return fmt.Errorf("create booking: %w", err)
+	}
+	return nil
+}
+
+func (r BookingRepo) GetByID(ctx context.Context, bookingID int64) (models.Booking, error) {
+	q := `SELECT ` + bookingColumns + `
+		FROM bookings b
+		JOIN rooms r ON r.id = b.room_id
+		WHERE b.id = $1
+	`
+	var b models.Booking
+	if err := scanBooking(r.DB.QueryRowContext(ctx, q, bookingID), &b); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return models.Booking{}, erors.ErrNotFound
+		}
+		return models.Booking{}, fmt.Errorf("booking by id: %w", err)
+	}
+	return b, nil
+}
+
+func (r BookingRepo) ListByUserID(ctx context.Context, userID int64) ([]models.Booking, error) {
+	q := `SELECT ` + bookingColumns + `
+		FROM bookings b
+		JOIN rooms r ON r.id = b.room_id
+		WHERE b.user_id = $1
+		ORDER BY b.checkin DESC, b.id DESC
+	`
+	rows, err := r.DB.QueryContext(ctx, q, userID)
+	if err != nil {
+		return nil, fmt.Errorf("list bookings by user: query: %w", err)
+	}
+	defer rows.Close()
+
+	var res []models.Booking
+	for rows.Next() {
+		var b models.Booking
+		if err := scanBooking(rows, &b); err != nil {
+			return nil, fmt.Errorf("list bookings by user: scan: %w", err)
+		}
+		res = append(res, b)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("list bookings by user: rows: %w", err)
+	}
+	return res, nil
+}
+
+// UpdateStatus переводит бронь в статус to, только если текущий статус входит в from.
+// Возвращает ErrConflict, если бронь существует, но находится в другом статусе.
+func (r BookingRepo) UpdateStatus(ctx context.Context, bookingID int64, from []string, to string) (models.Booking, error) {
+	const q = `
+		UPDATE bookings
+		SET status = $1, updated_at = now()
+		WHERE id = $2 AND status = ANY($3)
+	`
+	res, err := r.DB.ExecContext(ctx, q, to, bookingID, pq.Array(from))
+	if err != nil {
+		var pqErr *pq.Error
+		if errors.As(err, &pqErr) && pqErr.Code == "23P01" {
+			return models.Booking{}, erors.ErrRoomUnavailable
+		}
+		return models.Booking{}, fmt.Errorf("update booking status: exec: %w", err)
+	}
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return models.Booking{}, fmt.Errorf("update booking status: affected: %w", err)
+	}
+
+	b, err := r.GetByID(ctx, bookingID)
+	if err != nil {
+		return models.Booking{}, err
+	}
+	if affected == 0 {
+		return b, erors.ErrConflict
+	}
+	return b, nil
+}
@@ -54,7 +54,7 @@ func (r *favoriteRoomRepo) GetFavoriteRooms(ctx context.Context, userID int64) (
 		SELECT r.id, r.beds, r.price, r.rating, r.description, r.hotel_id
 		FROM rooms r
 		INNER JOIN user_favorite_rooms uf ON r.id = uf.room_id
-		WHERE uf.user_id = $1
+		WHERE uf.user_id = $1 AND r.deleted_at IS NULL
 	`, userID)
 	if err != nil {
 		return nil, err
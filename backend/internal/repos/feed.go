@@ -0,0 +1,122 @@
+package repos
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"backend/internal/models"
+)
+
+type FeedRepoInterface interface {
+	ListFriendActivity(ctx context.Context, userID int64, before sql.NullString, beforeKey string, limit int) ([]models.FeedItem, error)
+}
+
+type FeedRepo struct {
+	DB *sql.DB
+}
+
+func NewFeedRepo(db *sql.DB) FeedRepoInterface {
+	return FeedRepo{DB: db}
+}
+
+// ListFriendActivity события друзей по убыванию (время, ключ), строго раньше курсора (before, beforeKey).
+// Учитываются настройки приватности авторов; удаленные комнаты и отели не показываются.
+// Проживание — завершенная бронь (по дате выезда) или отметка о посещении без такой брони.
+func (r FeedRepo) ListFriendActivity(ctx context.Context, userID int64, before sql.NullString, beforeKey string, limit int) ([]models.FeedItem, error) {
+	const q = `
+		WITH friends AS (
+			SELECT CASE WHEN user_id = $1 THEN friend_id ELSE user_id END AS id
+			FROM user_friends
+			WHERE (user_id = $1 OR friend_id = $1) AND status = 'accepted'
+		),
+		events AS (
+			SELECT 'review:' || rv.id AS key, 'review' AS kind, rv.user_id, rv.room_id, rv.created_at AS occurred_at,
+			       rv.id AS review_id, rv.room_rating, rv.hotel_rating, rv.description
+			FROM reviews rv
+			JOIN friends f ON f.id = rv.user_id
+			LEFT JOIN user_privacy_settings p ON p.user_id = rv.user_id
+			WHERE rv.approved AND COALESCE(p.share_reviews, TRUE)
+
+			UNION ALL
+
+			SELECT 'favorite:' || uf.user_id || ':' || uf.room_id, 'favorite', uf.user_id, uf.room_id, uf.created_at,
+			       NULL, NULL, NULL, NULL
+			FROM user_favorite_rooms uf
+			JOIN friends f ON f.id = uf.user_id
+			LEFT JOIN user_privacy_settings p ON p.user_id = uf.user_id
+			WHERE COALESCE(p.share_favorites, TRUE)
+
+			UNION ALL
+
+			SELECT 'stay:booking:' || b.id, 'stay', b.user_id, b.room_id, b.checkout::timestamp,
+			       NULL, NULL, NULL, NULL
+			FROM bookings b
+			JOIN friends f ON f.id = b.user_id
+			LEFT JOIN user_privacy_settings p ON p.user_id = b.user_id
+			WHERE (b.status = 'completed' OR (b.status = 'confirmed' AND b.checkout <= CURRENT_DATE))
+			  AND COALESCE(p.share_stays, TRUE)
+
+			UNION ALL
+
+			SELECT 'stay:visit:' || uv.user_id || ':' || uv.room_id, 'stay', uv.user_id, uv.room_id, uv.created_at,
+			       NULL, NULL, NULL, NULL
+			FROM user_visited_rooms uv
+			JOIN friends f ON f.id = uv.user_id
+			LEFT JOIN user_privacy_settings p ON p.user_id = uv.user_id
+			WHERE COALESCE(p.share_stays, TRUE)
+			  AND NOT EXISTS (
+				SELECT 1 FROM bookings b
+				WHERE b.user_id = uv.user_id AND b.room_id = uv.room_id
+				  AND (b.status = 'completed' OR (b.status = 'confirmed' AND b.checkout <= CURRENT_DATE))
+			  )
+		)
+		SELECT e.key, e.kind, e.user_id, u.name, e.room_id, h.id, h.name, COALESCE(h.city, ''), e.occurred_at,
+		       e.review_id, e.room_rating, e.hotel_rating, e.description
+		FROM events e
+		JOIN users u ON u.id = e.user_id
+		JOIN rooms r ON r.id = e.room_id AND r.deleted_at IS NULL
+		JOIN hotels h ON h.id = r.hotel_id AND h.deleted_at IS NULL
+		WHERE e.occurred_at IS NOT NULL
+		  AND ($2::timestamp IS NULL OR (e.occurred_at, e.key) < ($2::timestamp, $3::text))
+		ORDER BY e.occurred_at DESC, e.key DESC
+		LIMIT $4
+	`
+	rows, err := r.DB.QueryContext(ctx, q, userID, before, beforeKey, limit)
+	if err != nil {
+		return nil, fmt.Errorf("list friend activity: query: %w", err)
+	}
+	defer rows.Close()
+
+	res := []models.FeedItem{}
+	for rows.Next() {
+		var (
+			item                    models.FeedItem
+			reviewID                sql.NullInt64
+			roomRating, hotelRating sql.NullInt64
+			description             sql.NullString
+		)
+		if err := rows.Scan(
+			&item.ID, &item.Type, &item.Actor.ID, &item.Actor.Name,
+			&item.RoomID, &item.HotelID, &item.HotelName, &item.City, &item.Time,
+			&reviewID, &roomRating, &hotelRating, &description,
+		); err != nil {
+			return nil, fmt.Errorf("list friend activity: scan: %w", err)
+		}
+		item.OccurredAt = item.Time.Format(time.RFC3339)
+		if reviewID.Valid {
+			item.Review = &models.FeedReview{
+				ID:          reviewID.Int64,
+				RoomRating:  int(roomRating.Int64),
+				HotelRating: int(hotelRating.Int64),
+				Description: description.String,
+			}
+		}
+		res = append(res, item)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("list friend activity: rows: %w", err)
+	}
+	return res, nil
+}
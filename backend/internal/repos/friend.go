@@ -0,0 +1,252 @@
+package repos
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"backend/internal/erors"
+	"backend/internal/models"
+
+	"github.com/lib/pq"
+)
+
+// friendColumns данные второй стороны связи для пользователя $1 (алиасы f — user_friends, u — users)
+const friendColumns = `u.id, u.name, COALESCE(u.city, ''), f.status, f.updated_at`
+
+// friendJoin присоединяет вторую сторону связи независимо от направления строки
+const friendJoin = `
+	FROM user_friends f
+	JOIN users u ON u.id = CASE WHEN f.user_id = $1 THEN f.friend_id ELSE f.user_id END
+`
+
+type FriendRepoInterface interface {
+	FindUserIDByEmail(ctx context.Context, email string) (int64, error)
+	GetRelations(ctx context.Context, userID, otherID int64) ([]models.Friendship, error)
+	GetFriend(ctx context.Context, userID, otherID int64) (models.Friend, error)
+	CreateRequest(ctx context.Context, fromID, toID int64) error
+	Accept(ctx context.Context, fromID, toID int64) error
+	DeletePending(ctx context.Context, fromID, toID int64) error
+	DeleteRelation(ctx context.Context, userID, otherID int64) error
+	Block(ctx context.Context, userID, otherID int64) error
+	Unblock(ctx context.Context, userID, otherID int64) error
+	ListFriends(ctx context.Context, userID int64) ([]models.Friend, error)
+	ListIncoming(ctx context.Context, userID int64) ([]models.Friend, error)
+	ListOutgoing(ctx context.Context, userID int64) ([]models.Friend, error)
+	ListBlocked(ctx context.Context, userID int64) ([]models.Friend, error)
+}
+
+type FriendRepo struct {
+	DB *sql.DB
+}
+
+func NewFriendRepo(db *sql.DB) FriendRepoInterface {
+	return FriendRepo{DB: db}
+}
+
+func (r FriendRepo) FindUserIDByEmail(ctx context.Context, email string) (int64, error) {
+	var id int64
+	err := r.DB.QueryRowContext(ctx, `SELECT id FROM users WHERE lower(email) = lower($1)`, email).Scan(&id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return 0, erors.ErrUserNotFound
+		}
+		return 0, fmt.Errorf("find user by email: %w", err)
+	}
+	return id, nil
+}
+
+// GetRelations все строки между двумя пользователями в обоих направлениях, включая блокировки
+func (r FriendRepo) GetRelations(ctx context.Context, userID, otherID int64) ([]models.Friendship, error) {
+	const q = `
+		SELECT user_id, friend_id, status
+		FROM user_friends
+		WHERE (user_id = $1 AND friend_id = $2) OR (user_id = $2 AND friend_id = $1)
+	`
+	rows, err := r.DB.QueryContext(ctx, q, userID, otherID)
+	if err != nil {
+		return nil, fmt.Errorf("get relations: query: %w", err)
+	}
+	defer rows.Close()
+
+	var res []models.Friendship
+	for rows.Next() {
+		var f models.Friendship
+		if err := rows.Scan(&f.UserID, &f.FriendID, &f.Status); err != nil {
+			return nil, fmt.Errorf("get relations: scan: %w", err)
+		}
+		res = append(res, f)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("get relations: rows: %w", err)
+	}
+	return res, nil
+}
+
+// GetFriend связь (заявка или дружба) с пользователем otherID глазами userID
+func (r FriendRepo) GetFriend(ctx context.Context, userID, otherID int64) (models.Friend, error) {
+	q := `SELECT ` + friendColumns + friendJoin + `
+		WHERE (f.user_id = $1 OR f.friend_id = $1) AND u.id = $2 AND f.status <> 'blocked'
+	`
+	var f models.Friend
+	err := r.DB.QueryRowContext(ctx, q, userID, otherID).Scan(&f.ID, &f.Name, &f.City, &f.Status, &f.Since)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return models.Friend{}, erors.ErrNotFound
+		}
+		return models.Friend{}, fmt.Errorf("get friend: %w", err)
+	}
+	return f, nil
+}
+
+// CreateRequest заявка от fromID к toID. Существующая связь пары — ErrConflict,
+// несуществующий получатель — ErrUserNotFound.
+func (r FriendRepo) CreateRequest(ctx context.Context, fromID, toID int64) error {
+	_, err := r.DB.ExecContext(ctx,
+		`INSERT INTO user_friends (user_id, friend_id, status) VALUES ($1, $2, 'pending')`,
+		fromID, toID,
+	)
+	if err != nil {
+		var pqErr *pq.Error
+		if errors.As(err, &pqErr) {
+			switch pqErr.Code {
+			case "23505":
+				return erors.ErrConflict
+			case "23503":
+				return erors.ErrUserNotFound
+			}
+		}
+		return fmt.Errorf("create friend request: %w", err)
+	}
+	return nil
+}
+
+// Accept принимает входящую заявку fromID -> toID
+func (r FriendRepo) Accept(ctx context.Context, fromID, toID int64) error {
+	const q = `
+		UPDATE user_friends
+		SET status = 'accepted', updated_at = now()
+		WHERE user_id = $1 AND friend_id = $2 AND status = 'pending'
+	`
+	return r.execAffected(ctx, "accept friend request", q, fromID, toID)
+}
+
+// DeletePending удаляет заявку fromID -> toID, пока она не принята
+func (r FriendRepo) DeletePending(ctx context.Context, fromID, toID int64) error {
+	const q = `DELETE FROM user_friends WHERE user_id = $1 AND friend_id = $2 AND status = 'pending'`
+	return r.execAffected(ctx, "delete friend request", q, fromID, toID)
+}
+
+// DeleteRelation удаляет дружбу или заявку в любом направлении; блокировки не трогает
+func (r FriendRepo) DeleteRelation(ctx context.Context, userID, otherID int64) error {
+	const q = `
+		DELETE FROM user_friends
+		WHERE ((user_id = $1 AND friend_id = $2) OR (user_id = $2 AND friend_id = $1))
+		  AND status <> 'blocked'
+	`
+	return r.execAffected(ctx, "delete friend", q, userID, otherID)
+}
+
+// Block блокирует otherID: дружба и заявки пары удаляются одной транзакцией
+func (r FriendRepo) Block(ctx context.Context, userID, otherID int64) error {
+	tx, err := r.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("block user: begin: %w", err)
+	}
+	defer tx.Rollback()
+
+	_, err = tx.ExecContext(ctx, `
+		DELETE FROM user_friends
+		WHERE ((user_id = $1 AND friend_id = $2) OR (user_id = $2 AND friend_id = $1))
+		  AND status <> 'blocked'
+	`, userID, otherID)
+	if err != nil {
+		return fmt.Errorf("block user: clear: %w", err)
+	}
+
+	_, err = tx.ExecContext(ctx, `
+		INSERT INTO user_friends (user_id, friend_id, status)
+		VALUES ($1, $2, 'blocked')
+		ON CONFLICT (user_id, friend_id) DO NOTHING
+	`, userID, otherID)
+	if err != nil {
+		var pqErr *pq.Error
+		if errors.As(err, &pqErr) && pqErr.Code == "23503" {
+			return erors.ErrUserNotFound
+		}
+		return fmt.Errorf("block user: insert: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("block user: commit: %w", err)
+	}
+	return nil
+}
+
+func (r FriendRepo) Unblock(ctx context.Context, userID, otherID int64) error {
+	const q = `DELETE FROM user_friends WHERE user_id = $1 AND friend_id = $2 AND status = 'blocked'`
+	return r.execAffected(ctx, "unblock user", q, userID, otherID)
+}
+
+// ListFriends принятые связи в обоих направлениях
+func (r FriendRepo) ListFriends(ctx context.Context, userID int64) ([]models.Friend, error) {
+	return r.listFriends(ctx, "list friends",
+		`(f.user_id = $1 OR f.friend_id = $1) AND f.status = 'accepted'`, userID)
+}
+
+// ListIncoming заявки, ожидающие решения пользователя
+func (r FriendRepo) ListIncoming(ctx context.Context, userID int64) ([]models.Friend, error) {
+	return r.listFriends(ctx, "list incoming requests",
+		`f.friend_id = $1 AND f.status = 'pending'`, userID)
+}
+
+// ListOutgoing отправленные пользователем и еще не принятые заявки
+func (r FriendRepo) ListOutgoing(ctx context.Context, userID int64) ([]models.Friend, error) {
+	return r.listFriends(ctx, "list outgoing requests",
+		`f.user_id = $1 AND f.status = 'pending'`, userID)
+}
+
+// ListBlocked пользователи, заблокированные userID
+func (r FriendRepo) ListBlocked(ctx context.Context, userID int64) ([]models.Friend, error) {
+	return r.listFriends(ctx, "list blocked users",
+		`f.user_id = $1 AND f.status = 'blocked'`, userID)
+}
+
+// listFriends общий SELECT списков; where передается только константами из методов выше
+func (r FriendRepo) listFriends(ctx context.Context, op, where string, userID int64) ([]models.Friend, error) {
+	q := `SELECT ` + friendColumns + friendJoin + ` WHERE ` + where + ` ORDER BY f.updated_at DESC, u.id`
+	rows, err := r.DB.QueryContext(ctx, q, userID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: query: %w", op, err)
+	}
+	defer rows.Close()
+
+	res := []models.Friend{}
+	for rows.Next() {
+		var f models.Friend
+		if err := rows.Scan(&f.ID, &f.Name, &f.City, &f.Status, &f.Since); err != nil {
+			return nil, fmt.Errorf("%s: scan: %w", op, err)
+		}
+		res = append(res, f)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: rows: %w", op, err)
+	}
+	return res, nil
+}
+
+func (r FriendRepo) execAffected(ctx context.Context, op, q string, args ...any) error {
+	res, err := r.DB.ExecContext(ctx, q, args...)
+	if err != nil {
+		return fmt.Errorf("%s: exec: %w", op, err)
+	}
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("%s: affected: %w", op, err)
+	}
+	if affected == 0 {
+		return erors.ErrNotFound
+	}
+	return nil
+}
@@ -1,11 +1,15 @@
 package repos
 
 import (
+	"backend/internal/erors"
 	"backend/internal/models"
 	"context"
 	"database/sql"
+	"errors"
 	"fmt"
 	"strings"
+
+	"github.com/lib/pq"
 )
 
 type HotelRepo struct {
@@ -14,9 +18,18 @@ type HotelRepo struct {
 
 type HotelRepoInterface interface {
 	Create(ctx context.Context, hotel *models.Hotel) error
-	GetAll(ctx context.Context) ([]models.Hotel, error)
+	List(ctx context.Context, filter models.HotelFilter) ([]models.Hotel, int, error)
 	GetByID(ctx context.Context, hotelID int64) (models.Hotel, error)
+	RatingSummary(ctx context.Context, hotelID int64) (models.RatingSummary, error)
 	ListByCity(ctx context.Context, city string) ([]models.Hotel, error)
+	Search(ctx context.Context, query string, limit, offset int) ([]models.HotelSearchHit, int, error)
+	ListByOwner(ctx context.Context, ownerID int64) ([]models.Hotel, error)
+	ListNearby(ctx context.Context, filter models.NearbyFilter) ([]models.Hotel, error)
+	ListInBoundingBox(ctx context.Context, box models.BoundingBox) ([]models.Hotel, error)
+	Update(ctx context.Context, hotelID int64, dto models.UpdateHotelDTO) (models.Hotel, error)
+	Replace(ctx context.Context, hotelID int64, hotel models.Hotel) (models.Hotel, error)
+	Delete(ctx context.Context, hotelID int64) error
+	HardDelete(ctx context.Context, hotelID int64) error
 }
 
 func NewHotelRepo(db *sql.DB) HotelRepoInterface {
@@ -24,56 +37,170 @@ func NewHotelRepo(db *sql.DB) HotelRepoInterface {
 }
 
 func (r HotelRepo) Create(ctx context.Context, hotel *models.Hotel) error {
+	// Удобства и фотографии задаются отдельными запросами, у нового отеля их нет
+	hotel.Amenities = []string{}
+	hotel.Images = models.ImageList{}
+	hotel.Rating, hotel.AverageRating, hotel.ReviewCount = 0, 0, 0
 	const q = `
-		INSERT INTO hotels (name, city, description, stars, address)
-		VALUES ($1, $2, $3, $4, $5)
+		INSERT INTO hotels (name, city, description, stars, address, owner_id, latitude, longitude)
+		VALUES ($1, $2, $3, $4, $5, NULLIF($6, 0), $7, $8)
 		RETURNING id
 	`
 	return r.DB.QueryRowContext(
 		ctx, q,
-		hotel.Name, hotel.City, hotel.Description, hotel.Stars, hotel.Address,
+		hotel.Name, hotel.City, hotel.Description, hotel.Stars, hotel.Address, hotel.OwnerID,
+		hotel.Latitude, hotel.Longitude,
 	).Scan(&hotel.ID)
 }
 
-func (r HotelRepo) GetAll(ctx context.Context) ([]models.Hotel, error) {
-	const q = `SELECT id, name, city, description, stars, address FROM hotels`
-	rows, err := r.DB.QueryContext(ctx, q)
+// hotelSortColumns белый список полей сортировки, чтобы не подставлять ввод в SQL
+var hotelSortColumns = map[string]string{
+	"price":  "p.min_price",
+	"rating": "h.average_rating",
+	"stars":  "h.stars",
+}
+
+// List возвращает страницу отелей по фильтру и общее количество подходящих отелей.
+// Цена отеля — минимальная цена его комнат, рейтинг — средняя оценка по одобренным отзывам.
+func (r HotelRepo) List(ctx context.Context, f models.HotelFilter) ([]models.Hotel, int, error) {
+	from := `
+		FROM hotels h
+		LEFT JOIN LATERAL (
+			SELECT COALESCE(MIN(rm.price), 0) AS min_price
+			FROM rooms rm
+			WHERE rm.hotel_id = h.id AND rm.deleted_at IS NULL
+		) p ON true
+		WHERE h.deleted_at IS NULL
+	`
+	var args []any
+	arg := func(v any) string {
+		args = append(args, v)
+		return fmt.Sprintf("$%d", len(args))
+	}
+	if f.City != "" {
+		from += ` AND h.city ILIKE ` + arg("%"+strings.TrimSpace(f.City)+"%")
+	}
+	if f.MinStars > 0 {
+		from += ` AND h.stars >= ` + arg(f.MinStars)
+	}
+	if f.MinPrice > 0 {
+		from += ` AND p.min_price >= ` + arg(f.MinPrice)
+	}
+	if f.MaxPrice > 0 {
+		from += ` AND p.min_price <= ` + arg(f.MaxPrice)
+	}
+	if f.MinRating > 0 {
+		from += ` AND h.average_rating >= ` + arg(f.MinRating)
+	}
+	if len(f.Amenities) > 0 {
+		from += ` AND (
+			SELECT COUNT(*) FROM hotel_amenities ha JOIN amenities a ON a.id = ha.amenity_id
+			WHERE ha.hotel_id = h.id AND a.code = ANY(` + arg(pq.Array(f.Amenities)) + `)
+		) = ` + arg(len(f.Amenities))
+	}
+
+	var total int
+	if err := r.DB.QueryRowContext(ctx, `SELECT COUNT(*) `+from, args...).Scan(&total); err != nil {
+		return nil, 0, fmt.Errorf("list hotels: count: %w", err)
+	}
+
+	order := "h.id ASC"
+	if col, ok := hotelSortColumns[f.SortBy]; ok {
+		dir := "ASC"
+		if f.SortOrder == "desc" {
+			dir = "DESC"
+		}
+		order = col + " " + dir + ", h.id ASC"
+	}
+	q := `SELECT h.id, h.name, h.city, h.description, h.stars, h.address, h.latitude, h.longitude, p.min_price, h.average_rating, h.review_count, ` +
+		hotelAmenitiesColumn + `, ` + hotelImagesColumn + ` ` +
+		from + ` ORDER BY ` + order +
+		` LIMIT ` + arg(f.Limit) + ` OFFSET ` + arg((f.Page-1)*f.Limit)
+
+	rows, err := r.DB.QueryContext(ctx, q, args...)
 	if err != nil {
-		return nil, err
+		return nil, 0, fmt.Errorf("list hotels: query: %w", err)
 	}
 	defer rows.Close()
 
-	var hotels []models.Hotel
+	hotels := []models.Hotel{}
 	for rows.Next() {
 		var h models.Hotel
-		if err := rows.Scan(&h.ID, &h.Name, &h.City, &h.Description, &h.Stars, &h.Address); err != nil {
-			return nil, err
+		if err := rows.Scan(&h.ID, &h.Name, &h.City, &h.Description, &h.Stars, &h.Address, &h.Latitude, &h.Longitude, &h.MinPrice, &h.AverageRating, &h.ReviewCount, pq.Array(&h.Amenities), &h.Images); err != nil {
+			return nil, 0, fmt.Errorf("list hotels: scan: %w", err)
 		}
+		h.Rating = h.AverageRating
 		hotels = append(hotels, h)
 	}
-	return hotels, rows.Err()
+	if err := rows.Err(); err != nil {
+		return nil, 0, fmt.Errorf("list hotels: rows: %w", err)
+	}
+	return hotels, total, nil
 }
 
-func (r HotelRepo) GetByID(ctx context.Context, hotelID int64) (models.Hotel, error) {
+// RatingSummary сводка оценок отеля по одобренным отзывам на его неудаленные комнаты. Средние
+// значения берутся из агрегатов, которые поддерживает триггер на reviews, распределение
+// считается на лету.
+func (r HotelRepo) RatingSummary(ctx context.Context, hotelID int64) (models.RatingSummary, error) {
 	const q = `
-		SELECT id, name, city, description, stars, address
-		FROM hotels
-		WHERE id = $1
+		SELECT h.id, h.average_rating, h.review_count,
+		       COALESCE(AVG(rv.room_rating), 0)::float8,
+		       COUNT(*) FILTER (WHERE rv.hotel_rating = 1),
+		       COUNT(*) FILTER (WHERE rv.hotel_rating = 2),
+		       COUNT(*) FILTER (WHERE rv.hotel_rating = 3),
+		       COUNT(*) FILTER (WHERE rv.hotel_rating = 4),
+		       COUNT(*) FILTER (WHERE rv.hotel_rating = 5)
+		FROM hotels h
+		LEFT JOIN rooms r ON r.hotel_id = h.id AND r.deleted_at IS NULL
+		LEFT JOIN reviews rv ON rv.room_id = r.id AND rv.approved
+		WHERE h.id = $1 AND h.deleted_at IS NULL
+		GROUP BY h.id
+	`
+	var s models.RatingSummary
+	var dist [5]int
+	err := r.DB.QueryRowContext(ctx, q, hotelID).Scan(
+		&s.HotelID, &s.AverageRating, &s.ReviewCount, &s.AverageRoomRating,
+		&dist[0], &dist[1], &dist[2], &dist[3], &dist[4],
+	)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return models.RatingSummary{}, erors.ErrNotFound
+		}
+		return models.RatingSummary{}, fmt.Errorf("hotel rating summary: %w", err)
+	}
+	s.Distribution = make(map[int]int, len(dist))
+	for i, n := range dist {
+		s.Distribution[i+1] = n
+	}
+	return s, nil
+}
+
+func (r HotelRepo) GetByID(ctx context.Context, hotelID int64) (models.Hotel, error) {
+	q := `
+		SELECT h.id, h.name, h.city, h.description, h.stars, h.address, COALESCE(h.owner_id, 0),
+		       h.latitude, h.longitude, h.average_rating, h.review_count, ` + hotelAmenitiesColumn + `, ` + hotelImagesColumn + `
+		FROM hotels h
+		WHERE h.id = $1 AND h.deleted_at IS NULL
 	`
 	var h models.Hotel
 	err := r.DB.QueryRowContext(ctx, q, hotelID).Scan(
-		&h.ID, &h.Name, &h.City, &h.Description, &h.Stars, &h.Address,
+		&h.ID, &h.Name, &h.City, &h.Description, &h.Stars, &h.Address, &h.OwnerID, &h.Latitude, &h.Longitude,
+		&h.AverageRating, &h.ReviewCount, pq.Array(&h.Amenities), &h.Images,
 	)
+	if errors.Is(err, sql.ErrNoRows) {
+		return h, erors.ErrNotFound
+	}
 	return h, err
 }
 
 
 func (r HotelRepo) ListByCity(ctx context.Context, city string) ([]models.Hotel, error) {
-	const q = `
-		SELECT id, name, city, address, description, stars
-		FROM hotels
-		WHERE city ILIKE $1
-		ORDER BY stars DESC, id ASC
+	q := `
+		SELECT h.id, h.name, h.city, h.address, h.description, h.stars, h.latitude, h.longitude,
+		       h.average_rating, h.review_count, ` + hotelAmenitiesColumn + `, ` + hotelImagesColumn + `
+		FROM hotels h
+		WHERE h.city ILIKE $1 AND h.deleted_at IS NULL
+		ORDER BY h.stars DESC, h.id ASC
 	`
 	needle := "%" + strings.TrimSpace(city) + "%"
 	rows, err := r.DB.QueryContext(ctx, q, needle)
@@ -85,7 +212,7 @@ func (r HotelRepo) ListByCity(ctx context.Context, city string) ([]models.Hotel,
 	var res []models.Hotel
 	for rows.Next() {
 		var h models.Hotel
-		if err := rows.Scan(&h.ID, &h.Name, &h.City, &h.Address, &h.Description, &h.Stars); err != nil {
+		if err := rows.Scan(&h.ID, &h.Name, &h.City, &h.Address, &h.Description, &h.Stars, &h.Latitude, &h.Longitude, &h.AverageRating, &h.ReviewCount, pq.Array(&h.Amenities), &h.Images); err != nil {
 			return nil, fmt.Errorf("hotels by city: scan: %w", err)
 		}
 		res = append(res, h)
@@ -96,4 +223,336 @@ func (r HotelRepo) ListByCity(ctx context.Context, city string) ([]models.Hotel,
 	return res, nil
 }
 
+// searchHeadlineOptions параметры ts_headline: найденные слова оборачиваются в <mark>
+const searchHeadlineOptions = `StartSel=<mark>, StopSel=</mark>, MaxWords=35, MinWords=15, MaxFragments=2, FragmentDelimiter=" … "`
+
+// htmlEscapedSQL экранирует HTML в SQL-выражении. Текст отелей и комнат задают партнеры,
+// поэтому перед ts_headline его нужно экранировать: иначе в ответе их разметка
+// окажется рядом с нашей <mark> и будет выполнена на клиенте.
+func htmlEscapedSQL(expr string) string {
+	return `replace(replace(replace(replace(replace(` + expr +
+		`, '&', '&amp;'), '<', '&lt;'), '>', '&gt;'), '"', '&quot;'), '''', '&#39;')`
+}
+
+// Search полнотекстовый поиск отелей (словарь russian) по названию, адресу, описанию и
+// описаниям комнат. Запрос разбирается websearch_to_tsquery: слова объединяются по И,
+// поддерживаются "фразы в кавычках", or и -исключение. Результаты упорядочены по релевантности.
+func (r HotelRepo) Search(ctx context.Context, query string, limit, offset int) ([]models.HotelSearchHit, int, error) {
+	const countQ = `
+		SELECT COUNT(*)
+		FROM hotels h
+		WHERE h.deleted_at IS NULL AND h.search_vector @@ websearch_to_tsquery('russian', $1)
+	`
+	var total int
+	if err := r.DB.QueryRowContext(ctx, countQ, query).Scan(&total); err != nil {
+		return nil, 0, fmt.Errorf("search hotels: count: %w", err)
+	}
+	if total == 0 {
+		return []models.HotelSearchHit{}, 0, nil
+	}
+
+	q := `
+		WITH q AS (SELECT websearch_to_tsquery('russian', $1) AS query)
+		SELECT h.id, h.name, h.city, h.description, h.stars, h.address, h.latitude, h.longitude,
+		       h.average_rating, h.review_count, ` + hotelAmenitiesColumn + `, ` + hotelImagesColumn + `,
+		       ts_rank_cd(h.search_vector, q.query)::float8 AS rank,
+		       ts_headline('russian', ` + htmlEscapedSQL("h.name") + `, q.query, 'StartSel=<mark>, StopSel=</mark>, HighlightAll=true'),
+		       ts_headline('russian', ` + htmlEscapedSQL("coalesce(h.description, '')") + `, q.query, $4)
+		FROM hotels h, q
+		WHERE h.deleted_at IS NULL AND h.search_vector @@ q.query
+		ORDER BY rank DESC, h.id ASC
+		LIMIT $2 OFFSET $3
+	`
+	rows, err := r.DB.QueryContext(ctx, q, query, limit, offset, searchHeadlineOptions)
+	if err != nil {
+		return nil, 0, fmt.Errorf("search hotels: query: %w", err)
+	}
+	defer rows.Close()
+
+	hits := []models.HotelSearchHit{}
+	index := make(map[int64]int)
+	for rows.Next() {
+		var hit models.HotelSearchHit
+		h := &hit.Hotel
+		if err := rows.Scan(&h.ID, &h.Name, &h.City, &h.Description, &h.Stars, &h.Address, &h.Latitude, &h.Longitude, &h.AverageRating, &h.ReviewCount, pq.Array(&h.Amenities), &h.Images,
+			&hit.Rank, &hit.NameHighlight, &hit.Snippet); err != nil {
+			return nil, 0, fmt.Errorf("search hotels: scan: %w", err)
+		}
+		hit.Rooms = []models.RoomSearchHit{}
+		index[h.ID] = len(hits)
+		hits = append(hits, hit)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, 0, fmt.Errorf("search hotels: rows: %w", err)
+	}
+	if len(hits) == 0 {
+		return hits, total, nil
+	}
+
+	ids := make([]int64, 0, len(hits))
+	for _, hit := range hits {
+		ids = append(ids, hit.Hotel.ID)
+	}
+	// Комната подходит, если в ее описании есть хотя бы одно слово запроса:
+	// остальные слова могли найтись в описании самого отеля
+	roomsQ := `
+		WITH q AS (
+			SELECT to_tsquery('simple', coalesce((
+				SELECT string_agg(quote_literal(l), ' | ')
+				FROM unnest(tsvector_to_array(to_tsvector('russian', $1))) AS l
+			), '')) AS any_word
+		)
+		SELECT id, hotel_id, snippet FROM (
+			SELECT r.id, r.hotel_id,
+			       ts_headline('russian', ` + htmlEscapedSQL("r.description") + `, q.any_word, $3) AS snippet,
+			       ROW_NUMBER() OVER (PARTITION BY r.hotel_id ORDER BY ts_rank_cd(r.search_vector, q.any_word) DESC, r.id) AS n
+			FROM rooms r, q
+			WHERE r.hotel_id = ANY($2) AND r.deleted_at IS NULL AND r.search_vector @@ q.any_word
+		) matched
+		WHERE n <= 3
+		ORDER BY hotel_id, n
+	`
+	roomRows, err := r.DB.QueryContext(ctx, roomsQ, query, pq.Array(ids), searchHeadlineOptions)
+	if err != nil {
+		return nil, 0, fmt.Errorf("search hotels: rooms query: %w", err)
+	}
+	defer roomRows.Close()
+
+	for roomRows.Next() {
+		var hotelID int64
+		var room models.RoomSearchHit
+		if err := roomRows.Scan(&room.RoomID, &hotelID, &room.Snippet); err != nil {
+			return nil, 0, fmt.Errorf("search hotels: rooms scan: %w", err)
+		}
+		i := index[hotelID]
+		hits[i].Rooms = append(hits[i].Rooms, room)
+	}
+	if err := roomRows.Err(); err != nil {
+		return nil, 0, fmt.Errorf("search hotels: rooms rows: %w", err)
+	}
+	return hits, total, nil
+}
+
+func (r HotelRepo) ListByOwner(ctx context.Context, ownerID int64) ([]models.Hotel, error) {
+	q := `
+		SELECT h.id, h.name, h.city, h.description, h.stars, h.address, h.owner_id, h.latitude, h.longitude,
+		       h.average_rating, h.review_count, ` + hotelAmenitiesColumn + `, ` + hotelImagesColumn + `
+		FROM hotels h
+		WHERE h.owner_id = $1 AND h.deleted_at IS NULL
+		ORDER BY h.id ASC
+	`
+	rows, err := r.DB.QueryContext(ctx, q, ownerID)
+	if err != nil {
+		return nil, fmt.Errorf("hotels by owner: query: %w", err)
+	}
+	defer rows.Close()
 
+	var res []models.Hotel
+	for rows.Next() {
+		var h models.Hotel
+		if err := rows.Scan(&h.ID, &h.Name, &h.City, &h.Description, &h.Stars, &h.Address, &h.OwnerID, &h.Latitude, &h.Longitude, &h.AverageRating, &h.ReviewCount, pq.Array(&h.Amenities), &h.Images); err != nil {
+			return nil, fmt.Errorf("hotels by owner: scan: %w", err)
+		}
+		res = append(res, h)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("hotels by owner: rows: %w", err)
+	}
+	return res, nil
+}
+
+// kmPerDegreeLat длина одного градуса широты в километрах
+const kmPerDegreeLat = 111.045
+
+// ListNearby отели в радиусе от точки, ближайшие первыми. Расстояние считается по
+// формуле гаверсинусов в SQL; предварительный отбор по широте использует индекс координат.
+func (r HotelRepo) ListNearby(ctx context.Context, f models.NearbyFilter) ([]models.Hotel, error) {
+	q := `
+		SELECT id, name, city, description, stars, address, latitude, longitude, average_rating, review_count, amenities, images, distance_km
+		FROM (
+			SELECT h.*,
+			       ` + hotelAmenitiesColumn + ` AS amenities,
+			       ` + hotelImagesColumn + ` AS images,
+			       6371 * 2 * asin(LEAST(1, sqrt(
+			           power(sin(radians(h.latitude - $1::float8) / 2), 2) +
+			           cos(radians($1)) * cos(radians(h.latitude)) *
+			           power(sin(radians(h.longitude - $2::float8) / 2), 2)
+			       ))) AS distance_km
+			FROM hotels h
+			WHERE h.deleted_at IS NULL
+			  AND h.latitude IS NOT NULL
+			  AND h.latitude BETWEEN $1 - $4::float8 AND $1 + $4
+		) d
+		WHERE distance_km <= $3::float8
+		ORDER BY distance_km ASC, id ASC
+		LIMIT $5
+	`
+	latDelta := f.RadiusKm / kmPerDegreeLat
+	rows, err := r.DB.QueryContext(ctx, q, f.Lat, f.Lng, f.RadiusKm, latDelta, f.Limit)
+	if err != nil {
+		return nil, fmt.Errorf("hotels nearby: query: %w", err)
+	}
+	defer rows.Close()
+
+	res := []models.Hotel{}
+	for rows.Next() {
+		var h models.Hotel
+		var distance float64
+		if err := rows.Scan(&h.ID, &h.Name, &h.City, &h.Description, &h.Stars, &h.Address, &h.Latitude, &h.Longitude, &h.AverageRating, &h.ReviewCount, pq.Array(&h.Amenities), &h.Images, &distance); err != nil {
+			return nil, fmt.Errorf("hotels nearby: scan: %w", err)
+		}
+		h.DistanceKm = &distance
+		res = append(res, h)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("hotels nearby: rows: %w", err)
+	}
+	return res, nil
+}
+
+// ListInBoundingBox отели в прямоугольной области карты. Если MinLng > MaxLng,
+// область пересекает 180-й меридиан и долгота проверяется по двум диапазонам.
+func (r HotelRepo) ListInBoundingBox(ctx context.Context, box models.BoundingBox) ([]models.Hotel, error) {
+	q := `
+		SELECT h.id, h.name, h.city, h.description, h.stars, h.address, h.latitude, h.longitude,
+		       h.average_rating, h.review_count, ` + hotelAmenitiesColumn + `, ` + hotelImagesColumn + `
+		FROM hotels h
+		WHERE h.deleted_at IS NULL
+		  AND h.latitude BETWEEN $1::float8 AND $3::float8
+		  AND CASE WHEN $2::float8 <= $4::float8
+		           THEN h.longitude BETWEEN $2 AND $4
+		           ELSE h.longitude >= $2 OR h.longitude <= $4
+		      END
+		ORDER BY h.id ASC
+		LIMIT $5
+	`
+	rows, err := r.DB.QueryContext(ctx, q, box.MinLat, box.MinLng, box.MaxLat, box.MaxLng, box.Limit)
+	if err != nil {
+		return nil, fmt.Errorf("hotels in bbox: query: %w", err)
+	}
+	defer rows.Close()
+
+	res := []models.Hotel{}
+	for rows.Next() {
+		var h models.Hotel
+		if err := rows.Scan(&h.ID, &h.Name, &h.City, &h.Description, &h.Stars, &h.Address, &h.Latitude, &h.Longitude, &h.AverageRating, &h.ReviewCount, pq.Array(&h.Amenities), &h.Images); err != nil {
+			return nil, fmt.Errorf("hotels in bbox: scan: %w", err)
+		}
+		res = append(res, h)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("hotels in bbox: rows: %w", err)
+	}
+	return res, nil
+}
+
+// Update меняет только переданные (не nil) поля
+func (r HotelRepo) Update(ctx context.Context, hotelID int64, dto models.UpdateHotelDTO) (models.Hotel, error) {
+	q := `
+		UPDATE hotels h
+		SET name        = COALESCE($1, name),
+		    city        = COALESCE($2, city),
+		    description = COALESCE($3, description),
+		    stars       = COALESCE($4, stars),
+		    address     = COALESCE($5, address),
+		    latitude    = COALESCE($6, latitude),
+		    longitude   = COALESCE($7, longitude)
+		WHERE h.id = $8 AND h.deleted_at IS NULL
+		RETURNING ` + hotelUpdateReturning + `
+	`
+	h, err := r.scanUpdatedHotel(r.DB.QueryRowContext(ctx, q,
+		dto.Name, dto.City, dto.Description, dto.Stars, dto.Address, dto.Latitude, dto.Longitude, hotelID,
+	))
+	if err != nil {
+		return models.Hotel{}, fmt.Errorf("update hotel: %w", err)
+	}
+	return h, nil
+}
+
+// Replace перезаписывает все редактируемые поля (PUT): пустые строки и отсутствующие
+// координаты сохраняются как есть, а не оставляют прежние значения
+func (r HotelRepo) Replace(ctx context.Context, hotelID int64, hotel models.Hotel) (models.Hotel, error) {
+	q := `
+		UPDATE hotels h
+		SET name        = $1,
+		    city        = $2,
+		    description = $3,
+		    stars       = $4,
+		    address     = $5,
+		    latitude    = $6,
+		    longitude   = $7
+		WHERE h.id = $8 AND h.deleted_at IS NULL
+		RETURNING ` + hotelUpdateReturning + `
+	`
+	h, err := r.scanUpdatedHotel(r.DB.QueryRowContext(ctx, q,
+		hotel.Name, hotel.City, hotel.Description, hotel.Stars, hotel.Address, hotel.Latitude, hotel.Longitude, hotelID,
+	))
+	if err != nil {
+		return models.Hotel{}, fmt.Errorf("replace hotel: %w", err)
+	}
+	return h, nil
+}
+
+const hotelUpdateReturning = `h.id, h.name, h.city, h.description, h.stars, h.address, COALESCE(h.owner_id, 0),
+		          h.latitude, h.longitude, h.average_rating, h.review_count, ` + hotelAmenitiesColumn + `, ` + hotelImagesColumn
+
+// scanUpdatedHotel читает отель из RETURNING hotelUpdateReturning; отсутствующий — ErrNotFound
+func (r HotelRepo) scanUpdatedHotel(row *sql.Row) (models.Hotel, error) {
+	var h models.Hotel
+	err := row.Scan(&h.ID, &h.Name, &h.City, &h.Description, &h.Stars, &h.Address, &h.OwnerID, &h.Latitude, &h.Longitude,
+		&h.AverageRating, &h.ReviewCount, pq.Array(&h.Amenities), &h.Images)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return models.Hotel{}, erors.ErrNotFound
+		}
+		return models.Hotel{}, err
+	}
+	return h, nil
+}
+
+// Delete мягко удаляет отель вместе с его комнатами: строки остаются в БД,
+// поэтому отзывы, избранное и брони сохраняют историю
+func (r HotelRepo) Delete(ctx context.Context, hotelID int64) error {
+	tx, err := r.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("delete hotel: begin: %w", err)
+	}
+	defer tx.Rollback()
+
+	res, err := tx.ExecContext(ctx,
+		`UPDATE hotels SET deleted_at = now() WHERE id = $1 AND deleted_at IS NULL`, hotelID,
+	)
+	if err != nil {
+		return fmt.Errorf("delete hotel: exec: %w", err)
+	}
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("delete hotel: affected: %w", err)
+	}
+	if affected == 0 {
+		return erors.ErrNotFound
+	}
+
+	if _, err := tx.ExecContext(ctx,
+		`UPDATE rooms SET deleted_at = now() WHERE hotel_id = $1 AND deleted_at IS NULL`, hotelID,
+	); err != nil {
+		return fmt.Errorf("delete hotel: rooms: %w", err)
+	}
+	return tx.Commit()
+}
+
+// HardDelete физически удаляет отель; комнаты, отзывы, избранное и брони удаляются каскадно
+func (r HotelRepo) HardDelete(ctx context.Context, hotelID int64) error {
+	res, err := r.DB.ExecContext(ctx, `DELETE FROM hotels WHERE id = $1`, hotelID)
+	if err != nil {
+		return fmt.Errorf("hard delete hotel: exec: %w", err)
+	}
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("hard delete hotel: affected: %w", err)
+	}
+	if affected == 0 {
+		return erors.ErrNotFound
+	}
+	return nil
+}
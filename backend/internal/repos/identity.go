@@ -0,0 +1,181 @@
+package repos
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"backend/internal/erors"
+	"backend/internal/models"
+
+	"github.com/lib/pq"
+)
+
+type IdentityRepoInterface interface {
+	SaveState(ctx context.Context, stateHash string, state models.OAuthState) error
+	ConsumeState(ctx context.Context, stateHash string) (models.OAuthState, error)
+	FindUserID(ctx context.Context, provider, subject string) (int64, error)
+	Link(ctx context.Context, userID int64, provider string, profile models.OAuthProfile) error
+	CreateUserWithIdentity(ctx context.Context, user models.CreateUserDTO, provider string, profile models.OAuthProfile) (int64, error)
+	ListByUserID(ctx context.Context, userID int64) ([]models.UserIdentity, error)
+}
+
+type IdentityRepo struct {
+	DB *sql.DB
+}
+
+func NewIdentityRepo(db *sql.DB) IdentityRepoInterface {
+	return IdentityRepo{DB: db}
+}
+
+// SaveState сохраняет незавершенный вход; заодно удаляет просроченные
+func (r IdentityRepo) SaveState(ctx context.Context, stateHash string, state models.OAuthState) error {
+	if _, err := r.DB.ExecContext(ctx, `DELETE FROM oauth_states WHERE expires_at < now()`); err != nil {
+		return fmt.Errorf("save oauth state: cleanup: %w", err)
+	}
+	_, err := r.DB.ExecContext(ctx, `
+		INSERT INTO oauth_states (state_hash, provider, code_verifier, user_id, expires_at)
+		VALUES ($1, $2, $3, NULLIF($4, 0), $5)
+	`, stateHash, state.Provider, state.CodeVerifier, state.UserID, state.ExpiresAt)
+	if err != nil {
+		return fmt.Errorf("save oauth state: %w", err)
+	}
+	return nil
+}
+
+// ConsumeState забирает state: повторно использовать его нельзя. Неизвестный
+// или просроченный state — ErrOAuthStateInvalid.
+func (r IdentityRepo) ConsumeState(ctx context.Context, stateHash string) (models.OAuthState, error) {
+	var (
+		s      models.OAuthState
+		userID sql.NullInt64
+	)
+	err := r.DB.QueryRowContext(ctx, `
+		DELETE FROM oauth_states
+		WHERE state_hash = $1
+		RETURNING provider, code_verifier, user_id, expires_at
+	`, stateHash).Scan(&s.Provider, &s.CodeVerifier, &userID, &s.ExpiresAt)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return models.OAuthState{}, erors.ErrOAuthStateInvalid
+		}
+		return models.OAuthState{}, fmt.Errorf("consume oauth state: %w", err)
+	}
+	s.UserID = userID.Int64
+	return s, nil
+}
+
+// FindUserID владелец внешней учетной записи; при успехе отмечает время входа
+func (r IdentityRepo) FindUserID(ctx context.Context, provider, subject string) (int64, error) {
+	var userID int64
+	err := r.DB.QueryRowContext(ctx, `
+		UPDATE user_identities SET last_login_at = now()
+		WHERE provider = $1 AND subject = $2
+		RETURNING user_id
+	`, provider, subject).Scan(&userID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return 0, erors.ErrUserNotFound
+		}
+		return 0, fmt.Errorf("find identity: %w", err)
+	}
+	return userID, nil
+}
+
+// Link привязывает внешнюю учетную запись; уже привязанная к кому-либо — ErrConflict.
+// Если провайдер подтвердил тот же email, что у пользователя, он становится подтвержденным.
+func (r IdentityRepo) Link(ctx context.Context, userID int64, provider string, profile models.OAuthProfile) error {
+	if err := insertIdentity(ctx, r.DB, userID, provider, profile); err != nil {
+		return err
+	}
+	if !profile.EmailVerified || profile.Email == "" {
+		return nil
+	}
+	_, err := r.DB.ExecContext(ctx, `
+		UPDATE users SET email_verified_at = now()
+		WHERE id = $1 AND email_verified_at IS NULL AND lower(email) = lower($2)
+	`, userID, profile.Email)
+	if err != nil {
+		return fmt.Errorf("link identity: verify email: %w", err)
+	}
+	return nil
+}
+
+// CreateUserWithIdentity создает пользователя вместе с внешней учетной записью;
+// email, подтвержденный провайдером, сразу считается подтвержденным
+func (r IdentityRepo) CreateUserWithIdentity(ctx context.Context, user models.CreateUserDTO, provider string, profile models.OAuthProfile) (int64, error) {
+	tx, err := r.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, fmt.Errorf("create oauth user: begin: %w", err)
+	}
+	defer tx.Rollback()
+
+	var userID int64
+	err = tx.QueryRowContext(ctx, `
+		INSERT INTO users (name, email, password, role, email_verified_at)
+		VALUES ($1, $2, $3, $4, CASE WHEN $5::boolean THEN now() END)
+		RETURNING id
+	`, user.Name, user.Email, user.Password, user.Role, profile.EmailVerified).Scan(&userID)
+	if err != nil {
+		var pqErr *pq.Error
+		if errors.As(err, &pqErr) && pqErr.Code == "23505" {
+			return 0, erors.ErrUserAlreadyExists
+		}
+		return 0, fmt.Errorf("create oauth user: insert user: %w", err)
+	}
+
+	if err := insertIdentity(ctx, tx, userID, provider, profile); err != nil {
+		return 0, err
+	}
+	if err := tx.Commit(); err != nil {
+		return 0, fmt.Errorf("create oauth user: commit: %w", err)
+	}
+	return userID, nil
+}
+
+// execer общий интерфейс *sql.DB и *sql.Tx
+type execer interface {
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+}
+
+func insertIdentity(ctx context.Context, db execer, userID int64, provider string, profile models.OAuthProfile) error {
+	_, err := db.ExecContext(ctx, `
+		INSERT INTO user_identities (user_id, provider, subject, email, email_verified, last_login_at)
+		VALUES ($1, $2, $3, NULLIF($4, ''), $5, now())
+	`, userID, provider, profile.Subject, profile.Email, profile.EmailVerified)
+	if err != nil {
+		var pqErr *pq.Error
+		if errors.As(err, &pqErr) && pqErr.Code == "23505" {
+			return erors.ErrConflict
+		}
+		return fmt.Errorf("link identity: %w", err)
+	}
+	return nil
+}
+
+func (r IdentityRepo) ListByUserID(ctx context.Context, userID int64) ([]models.UserIdentity, error) {
+	rows, err := r.DB.QueryContext(ctx, `
+		SELECT id, provider, COALESCE(email, ''), email_verified, created_at, last_login_at
+		FROM user_identities
+		WHERE user_id = $1
+		ORDER BY created_at, id
+	`, userID)
+	if err != nil {
+		return nil, fmt.Errorf("list identities: query: %w", err)
+	}
+	defer rows.Close()
+
+	res := []models.UserIdentity{}
+	for rows.Next() {
+		var i models.UserIdentity
+		if err := rows.Scan(&i.ID, &i.Provider, &i.Email, &i.EmailVerified, &i.CreatedAt, &i.LastLoginAt); err != nil {
+			return nil, fmt.Errorf("list identities: scan: %w", err)
+		}
+		res = append(res, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("list identities: rows: %w", err)
+	}
+	return res, nil
+}
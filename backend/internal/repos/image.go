@@ -0,0 +1,265 @@
+package repos
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"backend/internal/erors"
+	"backend/internal/models"
+
+	"github.com/lib/pq"
+)
+
+// Подзапросы галереи для SELECT отелей (алиас h) и комнат (алиас r)
+const (
+	imagesJSONObject = `json_build_object(
+		'id', i.id, 'url', i.url, 'thumbnailUrl', i.thumbnail_url, 'alt', i.alt,
+		'isPrimary', i.is_primary, 'position', i.position, 'width', i.width, 'height', i.height
+	)`
+	hotelImagesColumn = `COALESCE((
+		SELECT json_agg(` + imagesJSONObject + ` ORDER BY i.position, i.id)
+		FROM images i WHERE i.hotel_id = h.id
+	), '[]')`
+	roomImagesColumn = `COALESCE((
+		SELECT json_agg(` + imagesJSONObject + ` ORDER BY i.position, i.id)
+		FROM images i WHERE i.room_id = r.id
+	), '[]')`
+)
+
+const imageColumns = `id, url, thumbnail_url, alt, is_primary, position, width, height,
+	storage_key, thumbnail_key, content_type, size_bytes`
+
+type ImageRepoInterface interface {
+	Create(ctx context.Context, owner models.ImageOwner, image *models.Image) error
+	ListByOwner(ctx context.Context, owner models.ImageOwner) ([]models.Image, error)
+	Reorder(ctx context.Context, owner models.ImageOwner, ids []int64) error
+	SetPrimary(ctx context.Context, owner models.ImageOwner, imageID int64) error
+	Delete(ctx context.Context, owner models.ImageOwner, imageID int64) (models.Image, error)
+}
+
+type ImageRepo struct {
+	DB *sql.DB
+}
+
+func NewImageRepo(db *sql.DB) ImageRepoInterface {
+	return ImageRepo{DB: db}
+}
+
+// imageOwnerColumn имя колонки владельца; в SQL подставляются только эти константы
+func imageOwnerColumn(owner models.ImageOwner) (string, error) {
+	switch owner.Kind {
+	case models.ImageOwnerHotel:
+		return "hotel_id", nil
+	case models.ImageOwnerRoom:
+		return "room_id", nil
+	}
+	return "", fmt.Errorf("image owner: unknown kind %q", owner.Kind)
+}
+
+func scanImage(row interface{ Scan(...any) error }, img *models.Image) error {
+	return row.Scan(&img.ID, &img.URL, &img.ThumbnailURL, &img.Alt, &img.IsPrimary, &img.Position, &img.Width, &img.Height,
+		&img.StorageKey, &img.ThumbnailKey, &img.ContentType, &img.SizeBytes)
+}
+
+// Create добавляет фотографию в конец галереи. Первая фотография владельца
+// автоматически становится главной; IsPrimary=true переносит обложку на новую.
+func (r ImageRepo) Create(ctx context.Context, owner models.ImageOwner, image *models.Image) error {
+	col, err := imageOwnerColumn(owner)
+	if err != nil {
+		return err
+	}
+	tx, err := r.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("create image: begin: %w", err)
+	}
+	defer tx.Rollback()
+
+	var count, nextPosition int
+	err = tx.QueryRowContext(ctx,
+		`SELECT COUNT(*), COALESCE(MAX(position) + 1, 0) FROM images WHERE `+col+` = $1`, owner.ID,
+	).Scan(&count, &nextPosition)
+	if err != nil {
+		return fmt.Errorf("create image: position: %w", err)
+	}
+	if count == 0 {
+		image.IsPrimary = true
+	}
+	if image.IsPrimary && count > 0 {
+		if _, err := tx.ExecContext(ctx, `UPDATE images SET is_primary = FALSE WHERE `+col+` = $1 AND is_primary`, owner.ID); err != nil {
+			return fmt.Errorf("create image: reset primary: %w", err)
+		}
+	}
+	image.Position = nextPosition
+
+	err = tx.QueryRowContext(ctx, `
+		INSERT INTO images (`+col+`, storage_key, thumbnail_key, url, thumbnail_url, alt,
+		                    content_type, size_bytes, width, height, position, is_primary)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
+		RETURNING id
+	`, owner.ID, image.StorageKey, image.ThumbnailKey, image.URL, image.ThumbnailURL, image.Alt,
+		image.ContentType, image.SizeBytes, image.Width, image.Height, image.Position, image.IsPrimary,
+	).Scan(&image.ID)
+	if err != nil {
+		var pqErr *pq.Error
+		if errors.As(err, &pqErr) {
+			switch pqErr.Code {
+			case "23503": // владелец удален физически
+				return erors.ErrNotFound
+			case "23505": // параллельная загрузка тоже назначила обложку
+				return erors.ErrConflict
+			}
+		}
+		return fmt.Errorf("create image: insert: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("create image: commit: %w", err)
+	}
+	return nil
+}
+
+func (r ImageRepo) ListByOwner(ctx context.Context, owner models.ImageOwner) ([]models.Image, error) {
+	col, err := imageOwnerColumn(owner)
+	if err != nil {
+		return nil, err
+	}
+	rows, err := r.DB.QueryContext(ctx,
+		`SELECT `+imageColumns+` FROM images WHERE `+col+` = $1 ORDER BY position ASC, id ASC`, owner.ID,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("list images: query: %w", err)
+	}
+	defer rows.Close()
+
+	res := []models.Image{}
+	for rows.Next() {
+		var img models.Image
+		if err := scanImage(rows, &img); err != nil {
+			return nil, fmt.Errorf("list images: scan: %w", err)
+		}
+		res = append(res, img)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("list images: rows: %w", err)
+	}
+	return res, nil
+}
+
+// Reorder задает порядок галереи; ids должен содержать ровно все фотографии владельца
+func (r ImageRepo) Reorder(ctx context.Context, owner models.ImageOwner, ids []int64) error {
+	col, err := imageOwnerColumn(owner)
+	if err != nil {
+		return err
+	}
+	tx, err := r.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("reorder images: begin: %w", err)
+	}
+	defer tx.Rollback()
+
+	var total, matched int
+	err = tx.QueryRowContext(ctx, `
+		SELECT COUNT(*), COUNT(*) FILTER (WHERE id = ANY($2))
+		FROM (SELECT id FROM images WHERE `+col+` = $1 FOR UPDATE) own
+	`, owner.ID, pq.Array(ids)).Scan(&total, &matched)
+	if err != nil {
+		return fmt.Errorf("reorder images: check: %w", err)
+	}
+	if total != len(ids) || matched != len(ids) {
+		return erors.ErrInvalidInput
+	}
+
+	_, err = tx.ExecContext(ctx, `
+		UPDATE images i
+		SET position = x.ord - 1
+		FROM unnest($2::int[]) WITH ORDINALITY AS x(id, ord)
+		WHERE i.id = x.id AND i.`+col+` = $1
+	`, owner.ID, pq.Array(ids))
+	if err != nil {
+		return fmt.Errorf("reorder images: update: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("reorder images: commit: %w", err)
+	}
+	return nil
+}
+
+// SetPrimary делает фотографию обложкой, снимая признак с прежней
+func (r ImageRepo) SetPrimary(ctx context.Context, owner models.ImageOwner, imageID int64) error {
+	col, err := imageOwnerColumn(owner)
+	if err != nil {
+		return err
+	}
+	tx, err := r.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("set primary image: begin: %w", err)
+	}
+	defer tx.Rollback()
+
+	var exists bool
+	err = tx.QueryRowContext(ctx,
+		`SELECT EXISTS (SELECT 1 FROM images WHERE id = $1 AND `+col+` = $2)`, imageID, owner.ID,
+	).Scan(&exists)
+	if err != nil {
+		return fmt.Errorf("set primary image: check: %w", err)
+	}
+	if !exists {
+		return erors.ErrNotFound
+	}
+
+	// Уникальный индекс проверяется построчно, поэтому сначала снимаем старую обложку
+	if _, err := tx.ExecContext(ctx, `UPDATE images SET is_primary = FALSE WHERE `+col+` = $1 AND is_primary`, owner.ID); err != nil {
+		return fmt.Errorf("set primary image: reset: %w", err)
+	}
+	if _, err := tx.ExecContext(ctx, `UPDATE images SET is_primary = TRUE WHERE id = $1`, imageID); err != nil {
+		return fmt.Errorf("set primary image: set: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("set primary image: commit: %w", err)
+	}
+	return nil
+}
+
+// Delete удаляет запись о фотографии и возвращает ее (ключи нужны для удаления файлов).
+// Если удалена обложка, главной становится первая по порядку из оставшихся.
+func (r ImageRepo) Delete(ctx context.Context, owner models.ImageOwner, imageID int64) (models.Image, error) {
+	col, err := imageOwnerColumn(owner)
+	if err != nil {
+		return models.Image{}, err
+	}
+	tx, err := r.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return models.Image{}, fmt.Errorf("delete image: begin: %w", err)
+	}
+	defer tx.Rollback()
+
+	var img models.Image
+	row := tx.QueryRowContext(ctx,
+		`DELETE FROM images WHERE id = $1 AND `+col+` = $2 RETURNING `+imageColumns, imageID, owner.ID,
+	)
+	if err := scanImage(row, &img); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return models.Image{}, erors.ErrNotFound
+		}
+		return models.Image{}, fmt.Errorf("delete image: %w", err)
+	}
+
+	if img.IsPrimary {
+		_, err := tx.ExecContext(ctx, `
+			UPDATE images SET is_primary = TRUE
+			WHERE id = (SELECT id FROM images WHERE `+col+` = $1 ORDER BY position, id LIMIT 1)
+		`, owner.ID)
+		if err != nil {
+			return models.Image{}, fmt.Errorf("delete image: promote primary: %w", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return models.Image{}, fmt.Errorf("delete image: commit: %w", err)
+	}
+	return img, nil
+}
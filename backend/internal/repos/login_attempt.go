@@ -0,0 +1,95 @@
+package repos
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"backend/internal/models"
+)
+
+// LoginAttemptRepoInterface хранилище счетчиков неудачных входов. Ключ — "account:<email>"
+// или "ip:<адрес>". Реализации: в памяти (одна реплика) и в Postgres (общие для всех реплик).
+type LoginAttemptRepoInterface interface {
+	Get(ctx context.Context, key string) (models.LoginAttempt, error)
+	// RegisterFailure увеличивает счетчик и возвращает новое число неудач подряд;
+	// если последняя неудача была раньше now-resetAfter, счет начинается заново
+	RegisterFailure(ctx context.Context, key string, now time.Time, resetAfter time.Duration) (int, error)
+	Lock(ctx context.Context, key string, until time.Time) error
+	Reset(ctx context.Context, key string) error
+}
+
+type LoginAttemptRepo struct {
+	DB *sql.DB
+}
+
+func NewLoginAttemptRepo(db *sql.DB) LoginAttemptRepoInterface {
+	return LoginAttemptRepo{DB: db}
+}
+
+func (r LoginAttemptRepo) Get(ctx context.Context, key string) (models.LoginAttempt, error) {
+	var (
+		a           models.LoginAttempt
+		lockedUntil sql.NullTime
+	)
+	err := r.DB.QueryRowContext(ctx, `
+		SELECT failures, last_failure_at, locked_until FROM login_attempts WHERE key = $1
+	`, key).Scan(&a.Failures, &a.LastFailureAt, &lockedUntil)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return models.LoginAttempt{}, nil
+		}
+		return models.LoginAttempt{}, fmt.Errorf("get login attempts: %w", err)
+	}
+	a.LockedUntil = lockedUntil.Time
+	return a, nil
+}
+
+// RegisterFailure атомарен: параллельные неудачи с разных реплик не теряются.
+// Заодно удаляет давно устаревшие счетчики.
+func (r LoginAttemptRepo) RegisterFailure(ctx context.Context, key string, now time.Time, resetAfter time.Duration) (int, error) {
+	since := now.Add(-resetAfter)
+	_, err := r.DB.ExecContext(ctx, `
+		DELETE FROM login_attempts
+		WHERE last_failure_at < $1 AND (locked_until IS NULL OR locked_until < $2)
+	`, since, now)
+	if err != nil {
+		return 0, fmt.Errorf("register login failure: cleanup: %w", err)
+	}
+
+	var failures int
+	err = r.DB.QueryRowContext(ctx, `
+		INSERT INTO login_attempts (key, failures, last_failure_at)
+		VALUES ($1, 1, $2)
+		ON CONFLICT (key) DO UPDATE
+		SET failures = CASE WHEN login_attempts.last_failure_at < $3 THEN 1 ELSE login_attempts.failures + 1 END,
+		    last_failure_at = $2
+		RETURNING failures
+	`, key, now, since).Scan(&failures)
+	if err != nil {
+		return 0, fmt.Errorf("register login failure: %w", err)
+	}
+	return failures, nil
+}
+
+// Lock не сокращает уже действующую блокировку
+func (r LoginAttemptRepo) Lock(ctx context.Context, key string, until time.Time) error {
+	_, err := r.DB.ExecContext(ctx, `
+		UPDATE login_attempts
+		SET locked_until = GREATEST(COALESCE(locked_until, $2), $2)
+		WHERE key = $1
+	`, key, until)
+	if err != nil {
+		return fmt.Errorf("lock login: %w", err)
+	}
+	return nil
+}
+
+func (r LoginAttemptRepo) Reset(ctx context.Context, key string) error {
+	if _, err := r.DB.ExecContext(ctx, `DELETE FROM login_attempts WHERE key = $1`, key); err != nil {
+		return fmt.Errorf("reset login attempts: %w", err)
+	}
+	return nil
+}
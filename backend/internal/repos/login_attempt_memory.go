@@ -0,0 +1,114 @@
+package repos
+
+import (
+	"container/list"
+	"context"
+	"sync"
+	"time"
+
+	"backend/internal/models"
+)
+
+type memoryLoginAttempt struct {
+	key     string
+	attempt models.LoginAttempt
+	// expiresAt после этого момента счетчик уже не влияет на вход и его можно удалить
+	expiresAt time.Time
+}
+
+// MemoryLoginAttemptRepo счетчики неудачных входов в памяти процесса. Подходит только
+// для одной реплики: на других репликах счетчики свои, после рестарта они обнуляются.
+//
+// Записи лежат в списке в порядке последнего изменения, поэтому устаревшие всегда
+// в его начале: каждая неудача вычищает их с головы, не перебирая всю карту.
+type MemoryLoginAttemptRepo struct {
+	mu       sync.Mutex
+	attempts map[string]*list.Element
+	order    *list.List
+}
+
+func NewMemoryLoginAttemptRepo() LoginAttemptRepoInterface {
+	return &MemoryLoginAttemptRepo{
+		attempts: make(map[string]*list.Element),
+		order:    list.New(),
+	}
+}
+
+func (r *MemoryLoginAttemptRepo) Get(ctx context.Context, key string) (models.LoginAttempt, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if e, ok := r.attempts[key]; ok {
+		return e.Value.(*memoryLoginAttempt).attempt, nil
+	}
+	return models.LoginAttempt{}, nil
+}
+
+func (r *MemoryLoginAttemptRepo) RegisterFailure(ctx context.Context, key string, now time.Time, resetAfter time.Duration) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.evictExpired(now)
+
+	e, ok := r.attempts[key]
+	if !ok {
+		e = r.order.PushBack(&memoryLoginAttempt{key: key})
+		r.attempts[key] = e
+	} else {
+		r.order.MoveToBack(e)
+	}
+	m := e.Value.(*memoryLoginAttempt)
+
+	if m.attempt.LastFailureAt.Before(now.Add(-resetAfter)) {
+		m.attempt.Failures = 0
+	}
+	m.attempt.Failures++
+	m.attempt.LastFailureAt = now
+	m.expiresAt = later(now.Add(resetAfter), m.attempt.LockedUntil)
+	return m.attempt.Failures, nil
+}
+
+func (r *MemoryLoginAttemptRepo) Lock(ctx context.Context, key string, until time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	e, ok := r.attempts[key]
+	if !ok {
+		return nil
+	}
+	m := e.Value.(*memoryLoginAttempt)
+	if until.After(m.attempt.LockedUntil) {
+		m.attempt.LockedUntil = until
+		m.expiresAt = later(m.expiresAt, until)
+		r.order.MoveToBack(e)
+	}
+	return nil
+}
+
+func (r *MemoryLoginAttemptRepo) Reset(ctx context.Context, key string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if e, ok := r.attempts[key]; ok {
+		r.order.Remove(e)
+		delete(r.attempts, key)
+	}
+	return nil
+}
+
+// evictExpired удаляет истекшие записи с головы списка; вызывается под r.mu
+func (r *MemoryLoginAttemptRepo) evictExpired(now time.Time) {
+	for e := r.order.Front(); e != nil; e = r.order.Front() {
+		m := e.Value.(*memoryLoginAttempt)
+		if m.expiresAt.After(now) {
+			return
+		}
+		r.order.Remove(e)
+		delete(r.attempts, m.key)
+	}
+}
+
+func later(a, b time.Time) time.Time {
+	if a.After(b) {
+		return a
+	}
+	return b
+}
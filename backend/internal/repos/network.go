@@ -0,0 +1,125 @@
+package repos
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"backend/internal/erors"
+	"backend/internal/models"
+
+	"github.com/lib/pq"
+)
+
+type NetworkRepoInterface interface {
+	FindUserIDByTelegramID(ctx context.Context, telegramID int64) (int64, error)
+	LinkTelegram(ctx context.Context, userID int64, tg models.TelegramUser) error
+	CreateTelegramUser(ctx context.Context, user models.CreateUserDTO, tg models.TelegramUser) (int64, error)
+}
+
+type NetworkRepo struct {
+	DB *sql.DB
+}
+
+func NewNetworkRepo(db *sql.DB) NetworkRepoInterface {
+	return NetworkRepo{DB: db}
+}
+
+func (r NetworkRepo) FindUserIDByTelegramID(ctx context.Context, telegramID int64) (int64, error) {
+	var userID int64
+	err := r.DB.QueryRowContext(ctx,
+		`SELECT id_user FROM networks WHERE telegram_id = $1`, telegramID,
+	).Scan(&userID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return 0, erors.ErrUserNotFound
+		}
+		return 0, fmt.Errorf("find user by telegram id: %w", err)
+	}
+	return userID, nil
+}
+
+// LinkTelegram привязывает Telegram к пользователю. Telegram, уже привязанный
+// к другому пользователю, — ErrConflict.
+func (r NetworkRepo) LinkTelegram(ctx context.Context, userID int64, tg models.TelegramUser) error {
+	tx, err := r.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("link telegram: begin: %w", err)
+	}
+	defer tx.Rollback()
+
+	if err := linkTelegramTx(ctx, tx, userID, tg); err != nil {
+		return err
+	}
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("link telegram: commit: %w", err)
+	}
+	return nil
+}
+
+// CreateTelegramUser создает пользователя и сразу привязывает к нему Telegram
+func (r NetworkRepo) CreateTelegramUser(ctx context.Context, user models.CreateUserDTO, tg models.TelegramUser) (int64, error) {
+	tx, err := r.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, fmt.Errorf("create telegram user: begin: %w", err)
+	}
+	defer tx.Rollback()
+
+	var userID int64
+	err = tx.QueryRowContext(ctx, `
+		INSERT INTO users (name, email, password, city, role)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING id
+	`, user.Name, user.Email, user.Password, user.City, user.Role).Scan(&userID)
+	if err != nil {
+		var pqErr *pq.Error
+		if errors.As(err, &pqErr) && pqErr.Code == "23505" {
+			return 0, erors.ErrUserAlreadyExists
+		}
+		return 0, fmt.Errorf("create telegram user: insert user: %w", err)
+	}
+
+	if err := linkTelegramTx(ctx, tx, userID, tg); err != nil {
+		return 0, err
+	}
+	if err := tx.Commit(); err != nil {
+		return 0, fmt.Errorf("create telegram user: commit: %w", err)
+	}
+	return userID, nil
+}
+
+// linkTelegramTx обновляет запись соцсетей пользователя или создает ее
+func linkTelegramTx(ctx context.Context, tx *sql.Tx, userID int64, tg models.TelegramUser) error {
+	res, err := tx.ExecContext(ctx, `
+		UPDATE networks SET telegram_id = $2, telegram = NULLIF($3, '')
+		WHERE id = (SELECT id FROM networks WHERE id_user = $1 ORDER BY id LIMIT 1)
+	`, userID, tg.ID, tg.Username)
+	if err != nil {
+		return telegramLinkError(err)
+	}
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("link telegram: affected: %w", err)
+	}
+	if affected > 0 {
+		return nil
+	}
+
+	_, err = tx.ExecContext(ctx,
+		`INSERT INTO networks (id_user, telegram_id, telegram) VALUES ($1, $2, NULLIF($3, ''))`,
+		userID, tg.ID, tg.Username,
+	)
+	if err != nil {
+		return telegramLinkError(err)
+	}
+	return nil
+}
+
+func telegramLinkError(err error) error {
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) && pqErr.Code == "23505" {
+		return erors.ErrConflict
+	}
+	return fmt.Errorf("link telegram: %w", err)
+}
@@ -0,0 +1,107 @@
+package repos
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"backend/internal/models"
+)
+
+type RecommendationRepoInterface interface {
+	ListFriendSignals(ctx context.Context, userID int64, maxAgeDays int) ([]models.FriendSignal, error)
+}
+
+type RecommendationRepo struct {
+	DB *sql.DB
+}
+
+func NewRecommendationRepo(db *sql.DB) RecommendationRepoInterface {
+	return RecommendationRepo{DB: db}
+}
+
+// ListFriendSignals действия друзей пользователя с комнатами за последние maxAgeDays дней:
+// избранное, проживание и опубликованные отзывы с оценкой комнаты 4+. Комнаты, которые
+// пользователь уже видел (избранное, проживание, отзыв или бронь), удаленные объекты и действия,
+// скрытые друзьями в настройках приватности, исключаются.
+func (r RecommendationRepo) ListFriendSignals(ctx context.Context, userID int64, maxAgeDays int) ([]models.FriendSignal, error) {
+	const q = `
+		WITH friends AS (
+			SELECT CASE WHEN user_id = $1 THEN friend_id ELSE user_id END AS id
+			FROM user_friends
+			WHERE (user_id = $1 OR friend_id = $1) AND status = 'accepted'
+		),
+		edges AS (
+			SELECT user_id AS a, friend_id AS b FROM user_friends WHERE status = 'accepted'
+			UNION ALL
+			SELECT friend_id, user_id FROM user_friends WHERE status = 'accepted'
+		),
+		closeness AS (
+			SELECT f.id, COUNT(e.b) AS mutual
+			FROM friends f
+			LEFT JOIN edges e ON e.a = f.id AND e.b IN (SELECT id FROM friends)
+			GROUP BY f.id
+		),
+		known_rooms AS (
+			SELECT room_id FROM user_favorite_rooms WHERE user_id = $1
+			UNION SELECT room_id FROM user_visited_rooms WHERE user_id = $1
+			UNION SELECT room_id FROM reviews WHERE user_id = $1
+			UNION SELECT room_id FROM bookings WHERE user_id = $1
+		),
+		known_hotels AS (
+			SELECT DISTINCT kr.hotel_id FROM rooms kr JOIN known_rooms k ON k.room_id = kr.id
+			WHERE kr.hotel_id IS NOT NULL
+		),
+		signals AS (
+			SELECT uf.user_id, uf.room_id, 'favorite' AS kind, uf.created_at
+			FROM user_favorite_rooms uf
+			LEFT JOIN user_privacy_settings p ON p.user_id = uf.user_id
+			WHERE COALESCE(p.share_favorites, TRUE)
+			UNION ALL
+			SELECT uv.user_id, uv.room_id, 'visit', uv.created_at
+			FROM user_visited_rooms uv
+			LEFT JOIN user_privacy_settings p ON p.user_id = uv.user_id
+			WHERE COALESCE(p.share_stays, TRUE)
+			UNION ALL
+			SELECT rv.user_id, rv.room_id, 'review', rv.created_at
+			FROM reviews rv
+			LEFT JOIN user_privacy_settings p ON p.user_id = rv.user_id
+			WHERE rv.approved AND rv.room_rating >= 4 AND COALESCE(p.share_reviews, TRUE)
+		)
+		SELECT s.user_id, u.name, c.mutual, s.kind,
+		       EXTRACT(EPOCH FROM now() - s.created_at) / 86400,
+		       r.id, COALESCE(r.price, 0), r.average_rating,
+		       h.id, h.name, COALESCE(h.city, ''), h.average_rating,
+		       EXISTS (SELECT 1 FROM known_hotels kh WHERE kh.hotel_id = h.id)
+		FROM signals s
+		JOIN closeness c ON c.id = s.user_id
+		JOIN users u ON u.id = s.user_id
+		JOIN rooms r ON r.id = s.room_id AND r.deleted_at IS NULL
+		JOIN hotels h ON h.id = r.hotel_id AND h.deleted_at IS NULL
+		WHERE s.created_at > now() - $2::int * INTERVAL '1 day'
+		  AND r.id NOT IN (SELECT room_id FROM known_rooms WHERE room_id IS NOT NULL)
+	`
+	rows, err := r.DB.QueryContext(ctx, q, userID, maxAgeDays)
+	if err != nil {
+		return nil, fmt.Errorf("list friend signals: query: %w", err)
+	}
+	defer rows.Close()
+
+	var res []models.FriendSignal
+	for rows.Next() {
+		var s models.FriendSignal
+		if err := rows.Scan(
+			&s.FriendID, &s.FriendName, &s.MutualFriends, &s.Kind, &s.AgeDays,
+			&s.RoomID, &s.RoomPrice, &s.RoomAverageRating,
+			&s.HotelID, &s.HotelName, &s.City, &s.HotelAverageRating,
+			&s.HotelKnown,
+		); err != nil {
+			return nil, fmt.Errorf("list friend signals: scan: %w", err)
+		}
+		res = append(res, s)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("list friend signals: rows: %w", err)
+	}
+	return res, nil
+}
@@ -0,0 +1,88 @@
+package repos
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"backend/internal/erors"
+	"backend/internal/models"
+)
+
+type RefreshTokenRepoInterface interface {
+	Create(ctx context.Context, token models.RefreshToken) error
+	GetByHash(ctx context.Context, tokenHash string) (models.RefreshToken, error)
+	MarkUsed(ctx context.Context, tokenID int64) (bool, error)
+	RevokeFamily(ctx context.Context, familyID string) error
+}
+
+type refreshTokenRepo struct {
+	DB *sql.DB
+}
+
+func NewRefreshTokenRepo(db *sql.DB) RefreshTokenRepoInterface {
+	return &refreshTokenRepo{DB: db}
+}
+
+func (r *refreshTokenRepo) Create(ctx context.Context, token models.RefreshToken) error {
+	const q = `
+		INSERT INTO refresh_tokens (user_id, family_id, token_hash, expires_at)
+		VALUES ($1, $2, $3, $4)
+	`
+	if _, err := r.DB.ExecContext(ctx, q, token.UserID, token.FamilyID, token.TokenHash, token.ExpiresAt); err != nil {
+		return fmt.Errorf("create refresh token: %w", err)
+	}
+	return nil
+}
+
+func (r *refreshTokenRepo) GetByHash(ctx context.Context, tokenHash string) (models.RefreshToken, error) {
+	const q = `
+		SELECT id, user_id, family_id, token_hash, expires_at, used_at, revoked_at, created_at
+		FROM refresh_tokens
+		WHERE token_hash = $1
+	`
+	var t models.RefreshToken
+	err := r.DB.QueryRowContext(ctx, q, tokenHash).Scan(
+		&t.ID, &t.UserID, &t.FamilyID, &t.TokenHash, &t.ExpiresAt, &t.UsedAt, &t.RevokedAt, &t.CreatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return models.RefreshToken{}, erors.ErrNotFound
+		}
+		return models.RefreshToken{}, fmt.Errorf("refresh token by hash: %w", err)
+	}
+	return t, nil
+}
+
+// MarkUsed атомарно помечает токен использованным. Возвращает false, если токен
+// уже был использован или отозван — например, параллельным запросом с тем же токеном.
+func (r *refreshTokenRepo) MarkUsed(ctx context.Context, tokenID int64) (bool, error) {
+	const q = `
+		UPDATE refresh_tokens
+		SET used_at = now()
+		WHERE id = $1 AND used_at IS NULL AND revoked_at IS NULL
+	`
+	res, err := r.DB.ExecContext(ctx, q, tokenID)
+	if err != nil {
+		return false, fmt.Errorf("mark refresh token used: exec: %w", err)
+	}
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("mark refresh token used: affected: %w", err)
+	}
+	return affected == 1, nil
+}
+
+// RevokeFamily отзывает все refresh-токены семейства
+func (r *refreshTokenRepo) RevokeFamily(ctx context.Context, familyID string) error {
+	const q = `
+		UPDATE refresh_tokens
+		SET revoked_at = now()
+		WHERE family_id = $1 AND revoked_at IS NULL
+	`
+	if _, err := r.DB.ExecContext(ctx, q, familyID); err != nil {
+		return fmt.Errorf("revoke refresh token family: %w", err)
+	}
+	return nil
+}
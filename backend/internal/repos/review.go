@@ -1,68 +1,299 @@
 package repos
 
 import (
-	"backend/internal/erors"
-	"backend/internal/models"
 	"context"
 	"database/sql"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"time"
+
+	"backend/internal/erors"
+	"backend/internal/models"
+
+	"github.com/lib/pq"
 )
 
+// reviewColumns общий набор колонок отзыва; статус модерации вычисляется из approved и rejected_at,
+// ответ администрации отеля приходит JSON-объектом (NULL, если ответа нет)
+const reviewColumns = `
+	id, room_id, created_at, user_id, COALESCE(description, ''), room_rating, hotel_rating, approved,
+	CASE WHEN approved THEN 'approved' WHEN rejected_at IS NOT NULL THEN 'rejected' ELSE 'pending' END,
+	COALESCE(rejection_reason, ''), updated_at, helpful_count, report_count,
+	(
+		SELECT json_build_object(
+			'id', rr.id, 'review_id', rr.review_id, 'author_id', rr.author_id, 'body', rr.body,
+			'created_at', rr.created_at, 'updated_at', rr.updated_at
+		)
+		FROM review_replies rr WHERE rr.review_id = reviews.id
+	)
+`
+
+const reviewReplyColumns = `id, review_id, author_id, body, created_at, updated_at`
+
+type ReviewRepoInterface interface {
+	Create(ctx context.Context, review *models.Review) error
+	GetByID(ctx context.Context, reviewID int64) (models.Review, error)
+	HasCompletedStay(ctx context.Context, userID, roomID int64) (bool, error)
+	Update(ctx context.Context, reviewID, userID int64, dto models.UpdateReviewDTO, editWindow time.Duration) (models.Review, error)
+	ListByUserID(ctx context.Context, userID int64, onlyApproved bool) ([]models.Review, error)
+	ListByRoomID(ctx context.Context, roomID, viewerID int64, sort string) ([]models.Review, error)
+	ListByStatus(ctx context.Context, status string, limit, offset int) ([]models.Review, int, error)
+	Approve(ctx context.Context, reviewID, moderatorID int64) (models.Review, error)
+	Reject(ctx context.Context, reviewID, moderatorID int64, reason string) (models.Review, error)
+	DeleteByID(ctx context.Context, reviewID int64) error
+	AddHelpfulVote(ctx context.Context, reviewID, userID int64) (models.Review, error)
+	AddReport(ctx context.Context, reviewID, userID int64, reason string, threshold int) (models.Review, error)
+
+	CreateReply(ctx context.Context, reply *models.ReviewReply) error
+	UpdateReply(ctx context.Context, reviewID, authorID int64, body string) (models.ReviewReply, error)
+	DeleteReply(ctx context.Context, reviewID int64) error
+}
+
 type ReviewRepo struct {
-    DB *sql.DB
+	DB *sql.DB
 }
 
-func NewReviewRepo(db *sql.DB) *ReviewRepo {
-    return &ReviewRepo{DB: db}
+func NewReviewRepo(db *sql.DB) ReviewRepoInterface {
+	return ReviewRepo{DB: db}
 }
 
-func (r *ReviewRepo) Create(review *models.Review) error {
-    return r.DB.QueryRow(
-        `INSERT INTO reviews (room_id, created_at, user_id, description, room_rating, hotel_rating, approved) 
-         VALUES ($1, NOW(), $2, $3, $4, $5, $6) RETURNING id`,
-        review.RoomID, review.UserID, review.Description, review.RoomRating, review.HotelRating, review.Approved,
-    ).Scan(&review.ID)
+// Create сохраняет новый отзыв; он всегда попадает в очередь модерации
+func (r ReviewRepo) Create(ctx context.Context, review *models.Review) error {
+	const q = `
+		INSERT INTO reviews (room_id, created_at, user_id, description, room_rating, hotel_rating, approved)
+		VALUES ($1, NOW(), $2, $3, $4, $5, FALSE)
+		RETURNING id, created_at
+	`
+	err := r.DB.QueryRowContext(ctx, q,
+		review.RoomID, review.UserID, review.Description, review.RoomRating, review.HotelRating,
+	).Scan(&review.ID, &review.CreatedAt)
+	if err != nil {
+		var pqErr *pq.Error
+		if errors.As(err, &pqErr) {
+			switch pqErr.Code {
+			case "23503":
+				return erors.ErrNotFound
+			case "23505":
+				return erors.ErrConflict
+			}
+		}
+		return fmt.Errorf("create review: %w", err)
+	}
+	review.Approved = false
+	review.Status = models.ReviewStatusPending
+	review.RejectionReason = ""
+	return nil
 }
 
+func (r ReviewRepo) GetByID(ctx context.Context, reviewID int64) (models.Review, error) {
+	q := `SELECT ` + reviewColumns + ` FROM reviews WHERE id = $1`
+	rv, err := scanReview(r.DB.QueryRowContext(ctx, q, reviewID))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return models.Review{}, erors.ErrNotFound
+		}
+		return models.Review{}, fmt.Errorf("review by id: %w", err)
+	}
+	return rv, nil
+}
 
-func (r *ReviewRepo) ListByUserID(ctx context.Context, userID int64) ([]models.Review, error) {
+// HasCompletedStay проверяет, что пользователь действительно жил в комнате: есть отметка
+// в user_visited_rooms, завершенная бронь или подтвержденная бронь с уже прошедшим выездом
+func (r ReviewRepo) HasCompletedStay(ctx context.Context, userID, roomID int64) (bool, error) {
 	const q = `
-		SELECT id, room_id, created_at, user_id, description, room_rating, hotel_rating, approved
+		SELECT EXISTS (
+			SELECT 1 FROM user_visited_rooms WHERE user_id = $1 AND room_id = $2
+		) OR EXISTS (
+			SELECT 1 FROM bookings
+			WHERE user_id = $1 AND room_id = $2
+			  AND (status = 'completed' OR (status = 'confirmed' AND checkout <= CURRENT_DATE))
+		)
+	`
+	var ok bool
+	if err := r.DB.QueryRowContext(ctx, q, userID, roomID).Scan(&ok); err != nil {
+		return false, fmt.Errorf("has completed stay: %w", err)
+	}
+	return ok, nil
+}
+
+// Update правка отзыва автором, пока не истекло окно редактирования. Измененный отзыв
+// снимается с публикации и снова попадает в очередь модерации. Если строка не обновилась,
+// причина уточняется отдельным запросом: нет отзыва, чужой отзыв или окно истекло.
+func (r ReviewRepo) Update(ctx context.Context, reviewID, userID int64, dto models.UpdateReviewDTO, editWindow time.Duration) (models.Review, error) {
+	q := `
+		UPDATE reviews
+		SET description = COALESCE($3, description),
+		    room_rating = COALESCE($4, room_rating),
+		    hotel_rating = COALESCE($5, hotel_rating),
+		    approved = FALSE,
+		    rejected_at = NULL,
+		    rejection_reason = NULL,
+		    moderated_by = NULL,
+		    moderated_at = NULL,
+		    updated_at = NOW()
+		WHERE id = $1 AND user_id = $2
+		  AND created_at > NOW() - $6::float8 * INTERVAL '1 second'
+		RETURNING ` + reviewColumns
+	rv, err := scanReview(r.DB.QueryRowContext(ctx, q,
+		reviewID, userID, dto.Description, dto.RoomRating, dto.HotelRating, int64(editWindow/time.Second),
+	))
+	if err == nil {
+		return rv, nil
+	}
+	if !errors.Is(err, sql.ErrNoRows) {
+		return models.Review{}, fmt.Errorf("update review: %w", err)
+	}
+
+	existing, err := r.GetByID(ctx, reviewID)
+	if err != nil {
+		return models.Review{}, err
+	}
+	if existing.UserID != userID {
+		return models.Review{}, erors.ErrForbidden
+	}
+	return models.Review{}, erors.ErrReviewEditExpired
+}
+
+// ListByUserID отзывы пользователя; onlyApproved скрывает неопубликованные (для чужих глаз)
+func (r ReviewRepo) ListByUserID(ctx context.Context, userID int64, onlyApproved bool) ([]models.Review, error) {
+	q := `
+		SELECT ` + reviewColumns + `
 		FROM reviews
-		WHERE user_id = $1
+		WHERE user_id = $1 AND (approved OR NOT $2)
 		ORDER BY id ASC
 	`
-	rows, err := r.DB.QueryContext(ctx, q, userID)
+	rows, err := r.DB.QueryContext(ctx, q, userID, onlyApproved)
 	if err != nil {
 		return nil, fmt.Errorf("list reviews by user: query: %w", err)
 	}
 	defer rows.Close()
 
-	var res []models.Review
-	for rows.Next() {
-		var rv models.Review
-		if err := rows.Scan(
-			&rv.ID,
-			&rv.RoomID,
-			&rv.CreatedAt,
-			&rv.UserID,
-			&rv.Description,
-			&rv.RoomRating,
-			&rv.HotelRating,
-			&rv.Approved,
-		); err != nil {
-			return nil, fmt.Errorf("list reviews by user: scan: %w", err)
-		}
-		res = append(res, rv)
+	res, err := scanReviews(rows)
+	if err != nil {
+		return nil, fmt.Errorf("list reviews by user: %w", err)
 	}
-	if err := rows.Err(); err != nil {
-		return nil, fmt.Errorf("list reviews by user: rows: %w", err)
+	return res, nil
+}
+
+// reviewSortOrders белый список сортировок отзывов о комнате; пустое значение — порядок создания
+var reviewSortOrders = map[string]string{
+	"":                       "id ASC",
+	models.ReviewSortNewest:  "created_at DESC, id DESC",
+	models.ReviewSortHelpful: "helpful_count DESC, created_at DESC, id DESC",
+	models.ReviewSortRating:  "room_rating DESC, created_at DESC, id DESC",
+}
+
+// ListByRoomID опубликованные отзывы о комнате. Автору (viewerID) дополнительно видны
+// его собственные отзывы, ожидающие модерации; viewerID = 0 — анонимный просмотр.
+func (r ReviewRepo) ListByRoomID(ctx context.Context, roomID, viewerID int64, sort string) ([]models.Review, error) {
+	order, ok := reviewSortOrders[sort]
+	if !ok {
+		return nil, erors.ErrInvalidInput
+	}
+	q := `
+		SELECT ` + reviewColumns + `
+		FROM reviews
+		WHERE room_id = $1
+		  AND (approved OR (user_id = $2 AND rejected_at IS NULL))
+		ORDER BY ` + order
+	rows, err := r.DB.QueryContext(ctx, q, roomID, viewerID)
+	if err != nil {
+		return nil, fmt.Errorf("list reviews by room: query: %w", err)
+	}
+	defer rows.Close()
+
+	res, err := scanReviews(rows)
+	if err != nil {
+		return nil, fmt.Errorf("list reviews by room: %w", err)
 	}
 	return res, nil
 }
 
+// reviewStatusConditions условия выборки по статусу модерации
+var reviewStatusConditions = map[string]string{
+	models.ReviewStatusPending:  `approved = FALSE AND rejected_at IS NULL`,
+	models.ReviewStatusApproved: `approved = TRUE`,
+	models.ReviewStatusRejected: `approved = FALSE AND rejected_at IS NOT NULL`,
+}
+
+// ListByStatus страница очереди модерации; старые отзывы первыми
+func (r ReviewRepo) ListByStatus(ctx context.Context, status string, limit, offset int) ([]models.Review, int, error) {
+	cond, ok := reviewStatusConditions[status]
+	if !ok {
+		return nil, 0, erors.ErrInvalidInput
+	}
+
+	var total int
+	if err := r.DB.QueryRowContext(ctx, `SELECT COUNT(*) FROM reviews WHERE `+cond).Scan(&total); err != nil {
+		return nil, 0, fmt.Errorf("list reviews by status: count: %w", err)
+	}
+
+	q := `
+		SELECT ` + reviewColumns + `
+		FROM reviews
+		WHERE ` + cond + `
+		ORDER BY created_at ASC, id ASC
+		LIMIT $1 OFFSET $2
+	`
+	rows, err := r.DB.QueryContext(ctx, q, limit, offset)
+	if err != nil {
+		return nil, 0, fmt.Errorf("list reviews by status: query: %w", err)
+	}
+	defer rows.Close()
+
+	res, err := scanReviews(rows)
+	if err != nil {
+		return nil, 0, fmt.Errorf("list reviews by status: %w", err)
+	}
+	return res, total, nil
+}
+
+// Approve публикует отзыв; ранее отклоненный отзыв тоже можно одобрить.
+// Счетчик жалоб обнуляется: модератор уже рассмотрел их.
+func (r ReviewRepo) Approve(ctx context.Context, reviewID, moderatorID int64) (models.Review, error) {
+	q := `
+		UPDATE reviews
+		SET approved = TRUE,
+		    rejected_at = NULL,
+		    rejection_reason = NULL,
+		    report_count = 0,
+		    moderated_by = $2,
+		    moderated_at = NOW()
+		WHERE id = $1
+		RETURNING ` + reviewColumns
+	rv, err := scanReview(r.DB.QueryRowContext(ctx, q, reviewID, moderatorID))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return models.Review{}, erors.ErrNotFound
+		}
+		return models.Review{}, fmt.Errorf("approve review: %w", err)
+	}
+	return rv, nil
+}
+
+// Reject снимает отзыв с публикации и сохраняет причину для автора
+func (r ReviewRepo) Reject(ctx context.Context, reviewID, moderatorID int64, reason string) (models.Review, error) {
+	q := `
+		UPDATE reviews
+		SET approved = FALSE,
+		    rejected_at = NOW(),
+		    rejection_reason = $3,
+		    moderated_by = $2,
+		    moderated_at = NOW()
+		WHERE id = $1
+		RETURNING ` + reviewColumns
+	rv, err := scanReview(r.DB.QueryRowContext(ctx, q, reviewID, moderatorID, reason))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return models.Review{}, erors.ErrNotFound
+		}
+		return models.Review{}, fmt.Errorf("reject review: %w", err)
+	}
+	return rv, nil
+}
 
-func (r *ReviewRepo) DeleteByID(ctx context.Context, reviewID int64) error {
+func (r ReviewRepo) DeleteByID(ctx context.Context, reviewID int64) error {
 	const q = `DELETE FROM reviews WHERE id = $1`
 	res, err := r.DB.ExecContext(ctx, q, reviewID)
 	if err != nil {
@@ -78,41 +309,219 @@ func (r *ReviewRepo) DeleteByID(ctx context.Context, reviewID int64) error {
 	return nil
 }
 
+// lockVotableReview блокирует отзыв до конца транзакции и проверяет, что за него можно
+// голосовать: отзыв опубликован и написан не самим голосующим
+func lockVotableReview(ctx context.Context, tx *sql.Tx, reviewID, userID int64) error {
+	var authorID int64
+	var approved bool
+	err := tx.QueryRowContext(ctx,
+		`SELECT user_id, approved FROM reviews WHERE id = $1 FOR UPDATE`, reviewID,
+	).Scan(&authorID, &approved)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return erors.ErrNotFound
+		}
+		return err
+	}
+	if !approved {
+		return erors.ErrNotFound
+	}
+	if authorID == userID {
+		return erors.ErrForbidden
+	}
+	return nil
+}
 
-func (r *ReviewRepo) ListByRoomID(ctx context.Context, roomID int64) ([]models.Review, error) {
-	const q = `
-		SELECT id, room_id, created_at, user_id, description, room_rating, hotel_rating, approved
-		FROM reviews
-		WHERE room_id = $1
-		ORDER BY id ASC
-	`
-	rows, err := r.DB.QueryContext(ctx, q, roomID)
+// AddHelpfulVote отмечает отзыв полезным; повторная отметка того же пользователя — ErrConflict
+func (r ReviewRepo) AddHelpfulVote(ctx context.Context, reviewID, userID int64) (models.Review, error) {
+	tx, err := r.DB.BeginTx(ctx, nil)
 	if err != nil {
-		return nil, fmt.Errorf("list reviews by room: query: %w", err)
+		return models.Review{}, fmt.Errorf("helpful vote: begin: %w", err)
 	}
-	defer rows.Close()
+	defer tx.Rollback()
+
+	if err := lockVotableReview(ctx, tx, reviewID, userID); err != nil {
+		if errors.Is(err, erors.ErrNotFound) || errors.Is(err, erors.ErrForbidden) {
+			return models.Review{}, err
+		}
+		return models.Review{}, fmt.Errorf("helpful vote: lock: %w", err)
+	}
+
+	res, err := tx.ExecContext(ctx, `
+		INSERT INTO review_helpful_votes (review_id, user_id) VALUES ($1, $2)
+		ON CONFLICT DO NOTHING
+	`, reviewID, userID)
+	if err != nil {
+		return models.Review{}, fmt.Errorf("helpful vote: insert: %w", err)
+	}
+	if n, err := res.RowsAffected(); err != nil {
+		return models.Review{}, fmt.Errorf("helpful vote: affected: %w", err)
+	} else if n == 0 {
+		return models.Review{}, erors.ErrConflict
+	}
+
+	q := `UPDATE reviews SET helpful_count = helpful_count + 1 WHERE id = $1 RETURNING ` + reviewColumns
+	rv, err := scanReview(tx.QueryRowContext(ctx, q, reviewID))
+	if err != nil {
+		return models.Review{}, fmt.Errorf("helpful vote: count: %w", err)
+	}
+	if err := tx.Commit(); err != nil {
+		return models.Review{}, fmt.Errorf("helpful vote: commit: %w", err)
+	}
+	return rv, nil
+}
+
+// AddReport сохраняет жалобу на отзыв. Набрав threshold жалоб с последнего одобрения,
+// отзыв снимается с публикации и возвращается в очередь модерации.
+func (r ReviewRepo) AddReport(ctx context.Context, reviewID, userID int64, reason string, threshold int) (models.Review, error) {
+	tx, err := r.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return models.Review{}, fmt.Errorf("report review: begin: %w", err)
+	}
+	defer tx.Rollback()
+
+	if err := lockVotableReview(ctx, tx, reviewID, userID); err != nil {
+		if errors.Is(err, erors.ErrNotFound) || errors.Is(err, erors.ErrForbidden) {
+			return models.Review{}, err
+		}
+		return models.Review{}, fmt.Errorf("report review: lock: %w", err)
+	}
+
+	res, err := tx.ExecContext(ctx, `
+		INSERT INTO review_reports (review_id, user_id, reason) VALUES ($1, $2, NULLIF($3, ''))
+		ON CONFLICT DO NOTHING
+	`, reviewID, userID, reason)
+	if err != nil {
+		return models.Review{}, fmt.Errorf("report review: insert: %w", err)
+	}
+	if n, err := res.RowsAffected(); err != nil {
+		return models.Review{}, fmt.Errorf("report review: affected: %w", err)
+	} else if n == 0 {
+		return models.Review{}, erors.ErrConflict
+	}
+
+	q := `
+		UPDATE reviews
+		SET report_count = report_count + 1,
+		    approved = (report_count + 1 < $2)
+		WHERE id = $1
+		RETURNING ` + reviewColumns
+	rv, err := scanReview(tx.QueryRowContext(ctx, q, reviewID, threshold))
+	if err != nil {
+		return models.Review{}, fmt.Errorf("report review: count: %w", err)
+	}
+	if err := tx.Commit(); err != nil {
+		return models.Review{}, fmt.Errorf("report review: commit: %w", err)
+	}
+	return rv, nil
+}
+
+// CreateReply сохраняет ответ на отзыв; второй ответ на тот же отзыв дает ErrConflict
+func (r ReviewRepo) CreateReply(ctx context.Context, reply *models.ReviewReply) error {
+	q := `
+		INSERT INTO review_replies (review_id, author_id, body)
+		VALUES ($1, $2, $3)
+		RETURNING ` + reviewReplyColumns
+	rp, err := scanReviewReply(r.DB.QueryRowContext(ctx, q, reply.ReviewID, reply.AuthorID, reply.Body))
+	if err != nil {
+		var pqErr *pq.Error
+		if errors.As(err, &pqErr) {
+			switch pqErr.Code {
+			case "23503":
+				return erors.ErrNotFound
+			case "23505":
+				return erors.ErrConflict
+			}
+		}
+		return fmt.Errorf("create review reply: %w", err)
+	}
+	*reply = rp
+	return nil
+}
+
+func (r ReviewRepo) UpdateReply(ctx context.Context, reviewID, authorID int64, body string) (models.ReviewReply, error) {
+	q := `
+		UPDATE review_replies
+		SET body = $3, author_id = $2, updated_at = NOW()
+		WHERE review_id = $1
+		RETURNING ` + reviewReplyColumns
+	rp, err := scanReviewReply(r.DB.QueryRowContext(ctx, q, reviewID, authorID, body))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return models.ReviewReply{}, erors.ErrNotFound
+		}
+		return models.ReviewReply{}, fmt.Errorf("update review reply: %w", err)
+	}
+	return rp, nil
+}
+
+func (r ReviewRepo) DeleteReply(ctx context.Context, reviewID int64) error {
+	res, err := r.DB.ExecContext(ctx, `DELETE FROM review_replies WHERE review_id = $1`, reviewID)
+	if err != nil {
+		return fmt.Errorf("delete review reply: exec: %w", err)
+	}
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("delete review reply: affected: %w", err)
+	}
+	if affected == 0 {
+		return erors.ErrNotFound
+	}
+	return nil
+}
 
-	var res []models.Review
+func scanReviewReply(row rowScanner) (models.ReviewReply, error) {
+	var rp models.ReviewReply
+	err := row.Scan(&rp.ID, &rp.ReviewID, &rp.AuthorID, &rp.Body, &rp.CreatedAt, &rp.UpdatedAt)
+	return rp, err
+}
+
+type rowScanner interface {
+	Scan(dest ...any) error
+}
+
+func scanReview(row rowScanner) (models.Review, error) {
+	var rv models.Review
+	var reply []byte
+	err := row.Scan(
+		&rv.ID,
+		&rv.RoomID,
+		&rv.CreatedAt,
+		&rv.UserID,
+		&rv.Description,
+		&rv.RoomRating,
+		&rv.HotelRating,
+		&rv.Approved,
+		&rv.Status,
+		&rv.RejectionReason,
+		&rv.UpdatedAt,
+		&rv.HelpfulCount,
+		&rv.ReportCount,
+		&reply,
+	)
+	if err != nil {
+		return rv, err
+	}
+	if reply != nil {
+		rv.Reply = &models.ReviewReply{}
+		if err := json.Unmarshal(reply, rv.Reply); err != nil {
+			return rv, fmt.Errorf("review reply: %w", err)
+		}
+	}
+	return rv, nil
+}
+
+func scanReviews(rows *sql.Rows) ([]models.Review, error) {
+	res := []models.Review{}
 	for rows.Next() {
-		var rv models.Review
-		if err := rows.Scan(
-			&rv.ID,
-			&rv.RoomID,
-			&rv.CreatedAt,
-			&rv.UserID,
-			&rv.Description,
-			&rv.RoomRating,
-			&rv.HotelRating,
-			&rv.Approved,
-		); err != nil {
-			return nil, fmt.Errorf("list reviews by room: scan: %w", err)
+		rv, err := scanReview(rows)
+		if err != nil {
+			return nil, fmt.Errorf("scan: %w", err)
 		}
 		res = append(res, rv)
 	}
 	if err := rows.Err(); err != nil {
-		return nil, fmt.Errorf("list reviews by room: rows: %w", err)
+		return nil, fmt.Errorf("rows: %w", err)
 	}
 	return res, nil
 }
-
-
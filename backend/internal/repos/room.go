@@ -3,10 +3,14 @@ package repos
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"fmt"
 	"strings"
 
+	"backend/internal/erors"
 	"backend/internal/models"
+
+	"github.com/lib/pq"
 )
 
 type RoomRepo struct {
@@ -17,7 +21,10 @@ type RoomRepoInterface interface {
 	Create(ctx context.Context, room *models.Room) error
 	GetRoomsByHotelID(ctx context.Context, hotelID int64) ([]models.Room, error)
 	GetRoomByID(ctx context.Context, roomID int64) (models.Room, error)
-	SearchRooms(ctx context.Context, city string, guests int, checkin, checkout string) ([]models.Room, error)
+	SearchRooms(ctx context.Context, city string, guests int, checkin, checkout string, amenities []string) ([]models.Room, error)
+	Update(ctx context.Context, roomID int64, dto models.UpdateRoomDTO) (models.Room, error)
+	Delete(ctx context.Context, roomID int64) error
+	HardDelete(ctx context.Context, roomID int64) error
 }
 
 func NewRoomRepo(db *sql.DB) RoomRepoInterface {
@@ -25,22 +32,27 @@ func NewRoomRepo(db *sql.DB) RoomRepoInterface {
 }
 
 func (r RoomRepo) Create(ctx context.Context, room *models.Room) error {
+	// Удобства и фотографии задаются отдельными запросами, у новой комнаты их нет
+	room.Amenities = []string{}
+	room.Images = models.ImageList{}
 	const q = `
         INSERT INTO rooms (beds, price, rating, description, hotel_id)
-        VALUES ($1, $2, $3, $4, $5)
+        VALUES ($1, $2, 0, $3, $4)
         RETURNING id
     `
+	// Рейтинг считается из отзывов: у новой комнаты их нет, значение от клиента игнорируется
+	room.Rating, room.AverageRating, room.ReviewCount = 0, 0, 0
 	return r.DB.QueryRowContext(ctx, q,
-		room.Beds, room.Price, room.Rating, room.Description, room.HotelID,
+		room.Beds, room.Price, room.Description, room.HotelID,
 	).Scan(&room.ID)
 }
 
 func (r RoomRepo) GetRoomsByHotelID(ctx context.Context, hotelID int64) ([]models.Room, error) {
-	const q = `
-        SELECT id, hotel_id, beds, price, rating, description
-        FROM rooms
-        WHERE hotel_id = $1
-        ORDER BY id ASC
+	q := `
+        SELECT r.id, r.hotel_id, r.beds, r.price, r.rating, r.average_rating, r.review_count, r.description, ` + roomAmenitiesColumn + `, ` + roomImagesColumn + `
+        FROM rooms r
+        WHERE r.hotel_id = $1 AND r.deleted_at IS NULL
+        ORDER BY r.id ASC
     `
 	rows, err := r.DB.QueryContext(ctx, q, hotelID)
 	if err != nil {
@@ -51,7 +63,7 @@ func (r RoomRepo) GetRoomsByHotelID(ctx context.Context, hotelID int64) ([]model
 	var rooms []models.Room
 	for rows.Next() {
 		var rm models.Room
-		if err := rows.Scan(&rm.ID, &rm.HotelID, &rm.Beds, &rm.Price, &rm.Rating, &rm.Description); err != nil {
+		if err := rows.Scan(&rm.ID, &rm.HotelID, &rm.Beds, &rm.Price, &rm.Rating, &rm.AverageRating, &rm.ReviewCount, &rm.Description, pq.Array(&rm.Amenities), &rm.Images); err != nil {
 			return nil, fmt.Errorf("rooms by hotel: scan: %w", err)
 		}
 		rooms = append(rooms, rm)
@@ -63,29 +75,66 @@ func (r RoomRepo) GetRoomsByHotelID(ctx context.Context, hotelID int64) ([]model
 }
 
 func (r RoomRepo) GetRoomByID(ctx context.Context, roomID int64) (models.Room, error) {
-	const q = `
-        SELECT id, hotel_id, beds, price, rating, description
-        FROM rooms
-        WHERE id = $1
+	q := `
+        SELECT r.id, r.hotel_id, r.beds, r.price, r.rating, r.average_rating, r.review_count, r.description, ` + roomAmenitiesColumn + `, ` + roomImagesColumn + `
+        FROM rooms r
+        WHERE r.id = $1 AND r.deleted_at IS NULL
     `
 	var rm models.Room
 	if err := r.DB.QueryRowContext(ctx, q, roomID).
-		Scan(&rm.ID, &rm.HotelID, &rm.Beds, &rm.Price, &rm.Rating, &rm.Description); err != nil {
+		Scan(&rm.ID, &rm.HotelID, &rm.Beds, &rm.Price, &rm.Rating, &rm.AverageRating, &rm.ReviewCount, &rm.Description, pq.Array(&rm.Amenities), &rm.Images); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return models.Room{}, erors.ErrNotFound
+		}
 		return models.Room{}, fmt.Errorf("room by id: %w", err)
 	}
 	return rm, nil
 }
 
-func (r RoomRepo) SearchRooms(ctx context.Context, city string, guests int, checkin, checkout string) ([]models.Room, error) {
-	const q = `
-        SELECT r.id, r.hotel_id, r.beds, r.price, r.rating, r.description
+// SearchRooms ищет комнаты по городу и вместимости. Если переданы даты
+// (уже провалидированные сервисом), исключаются комнаты с активной бронью,
+// пересекающейся с полуинтервалом [checkin, checkout). Удобство считается
+// у комнаты, если оно есть у нее самой или у ее отеля; нужны все переданные.
+func (r RoomRepo) SearchRooms(ctx context.Context, city string, guests int, checkin, checkout string, amenities []string) ([]models.Room, error) {
+	q := `
+        SELECT r.id, r.hotel_id, r.beds, r.price, r.rating, r.average_rating, r.review_count, r.description, ` + roomAmenitiesColumn + `, ` + roomImagesColumn + `
         FROM rooms r
         JOIN hotels h ON h.id = r.hotel_id
         WHERE h.city ILIKE $1
           AND r.beds >= $2
+          AND r.deleted_at IS NULL
+          AND h.deleted_at IS NULL
+    `
+	args := []any{"%" + strings.TrimSpace(city) + "%", guests}
+	if checkin != "" && checkout != "" {
+		q += `
+          AND NOT EXISTS (
+              SELECT 1 FROM bookings b
+              WHERE b.room_id = r.id
+                AND b.status <> 'cancelled'
+                AND daterange(b.checkin, b.checkout, '[)') && daterange($3::date, $4::date, '[)')
+          )
+    `
+		args = append(args, checkin, checkout)
+	}
+	if len(amenities) > 0 {
+		args = append(args, pq.Array(amenities), len(amenities))
+		q += fmt.Sprintf(`
+          AND (
+              SELECT COUNT(*) FROM amenities a
+              WHERE a.code = ANY($%d)
+                AND (
+                    EXISTS (SELECT 1 FROM room_amenities ra WHERE ra.room_id = r.id AND ra.amenity_id = a.id)
+                    OR EXISTS (SELECT 1 FROM hotel_amenities ha WHERE ha.hotel_id = r.hotel_id AND ha.amenity_id = a.id)
+                )
+          ) = $%d
+    `, len(args)-1, len(args))
+	}
+	q += `
         ORDER BY r.price ASC, r.id ASC
     `
-	rows, err := r.DB.QueryContext(ctx, q, "%"+strings.TrimSpace(city)+"%", guests)
+
+	rows, err := r.DB.QueryContext(ctx, q, args...)
 	if err != nil {
 		return nil, fmt.Errorf("search rooms: query: %w", err)
 	}
@@ -94,7 +143,7 @@ func (r RoomRepo) SearchRooms(ctx context.Context, city string, guests int, chec
 	var res []models.Room
 	for rows.Next() {
 		var rm models.Room
-		if err := rows.Scan(&rm.ID, &rm.HotelID, &rm.Beds, &rm.Price, &rm.Rating, &rm.Description); err != nil {
+		if err := rows.Scan(&rm.ID, &rm.HotelID, &rm.Beds, &rm.Price, &rm.Rating, &rm.AverageRating, &rm.ReviewCount, &rm.Description, pq.Array(&rm.Amenities), &rm.Images); err != nil {
 			return nil, fmt.Errorf("search rooms: scan: %w", err)
 		}
 		res = append(res, rm)
@@ -104,3 +153,59 @@ func (r RoomRepo) SearchRooms(ctx context.Context, city string, guests int, chec
 	}
 	return res, nil
 }
+
+// Update меняет только переданные (не nil) поля
+func (r RoomRepo) Update(ctx context.Context, roomID int64, dto models.UpdateRoomDTO) (models.Room, error) {
+	q := `
+        UPDATE rooms r
+        SET beds        = COALESCE($1, beds),
+            price       = COALESCE($2, price),
+            description = COALESCE($3, description)
+        WHERE r.id = $4 AND r.deleted_at IS NULL
+        RETURNING r.id, r.hotel_id, r.beds, r.price, r.rating, r.average_rating, r.review_count, r.description, ` + roomAmenitiesColumn + `, ` + roomImagesColumn + `
+    `
+	var rm models.Room
+	err := r.DB.QueryRowContext(ctx, q, dto.Beds, dto.Price, dto.Description, roomID).
+		Scan(&rm.ID, &rm.HotelID, &rm.Beds, &rm.Price, &rm.Rating, &rm.AverageRating, &rm.ReviewCount, &rm.Description, pq.Array(&rm.Amenities), &rm.Images)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return models.Room{}, erors.ErrNotFound
+		}
+		return models.Room{}, fmt.Errorf("update room: %w", err)
+	}
+	return rm, nil
+}
+
+// Delete мягко удаляет комнату: отзывы, избранное и брони сохраняют историю
+func (r RoomRepo) Delete(ctx context.Context, roomID int64) error {
+	res, err := r.DB.ExecContext(ctx,
+		`UPDATE rooms SET deleted_at = now() WHERE id = $1 AND deleted_at IS NULL`, roomID,
+	)
+	if err != nil {
+		return fmt.Errorf("delete room: exec: %w", err)
+	}
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("delete room: affected: %w", err)
+	}
+	if affected == 0 {
+		return erors.ErrNotFound
+	}
+	return nil
+}
+
+// HardDelete физически удаляет комнату; отзывы, избранное и брони удаляются каскадно
+func (r RoomRepo) HardDelete(ctx context.Context, roomID int64) error {
+	res, err := r.DB.ExecContext(ctx, `DELETE FROM rooms WHERE id = $1`, roomID)
+	if err != nil {
+		return fmt.Errorf("hard delete room: exec: %w", err)
+	}
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("hard delete room: affected: %w", err)
+	}
+	if affected == 0 {
+		return erors.ErrNotFound
+	}
+	return nil
+}
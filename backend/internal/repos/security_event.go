@@ -0,0 +1,87 @@
+package repos
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"backend/internal/models"
+)
+
+type SecurityEventRepoInterface interface {
+	Create(ctx context.Context, event *models.SecurityEvent) error
+	List(ctx context.Context, eventType string, limit, offset int) ([]models.SecurityEvent, int, error)
+}
+
+type SecurityEventRepo struct {
+	DB *sql.DB
+}
+
+func NewSecurityEventRepo(db *sql.DB) SecurityEventRepoInterface {
+	return SecurityEventRepo{DB: db}
+}
+
+func (r SecurityEventRepo) Create(ctx context.Context, event *models.SecurityEvent) error {
+	err := r.DB.QueryRowContext(ctx, `
+		INSERT INTO security_events (type, user_id, email, ip, failures, locked_until, actor_id)
+		VALUES ($1, $2, NULLIF($3, ''), NULLIF($4, ''), NULLIF($5, 0), $6, $7)
+		RETURNING id, created_at
+	`, event.Type, event.UserID, event.Email, event.IP, event.Failures, event.LockedUntil, event.ActorID,
+	).Scan(&event.ID, &event.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("create security event: %w", err)
+	}
+	return nil
+}
+
+// List события от новых к старым; пустой eventType — все типы
+func (r SecurityEventRepo) List(ctx context.Context, eventType string, limit, offset int) ([]models.SecurityEvent, int, error) {
+	var total int
+	err := r.DB.QueryRowContext(ctx,
+		`SELECT COUNT(*) FROM security_events WHERE $1 = '' OR type = $1`, eventType,
+	).Scan(&total)
+	if err != nil {
+		return nil, 0, fmt.Errorf("list security events: count: %w", err)
+	}
+
+	rows, err := r.DB.QueryContext(ctx, `
+		SELECT id, type, user_id, COALESCE(email, ''), COALESCE(ip, ''), COALESCE(failures, 0),
+		       locked_until, actor_id, created_at
+		FROM security_events
+		WHERE $1 = '' OR type = $1
+		ORDER BY created_at DESC, id DESC
+		LIMIT $2 OFFSET $3
+	`, eventType, limit, offset)
+	if err != nil {
+		return nil, 0, fmt.Errorf("list security events: query: %w", err)
+	}
+	defer rows.Close()
+
+	res := []models.SecurityEvent{}
+	for rows.Next() {
+		var (
+			e           models.SecurityEvent
+			userID      sql.NullInt64
+			actorID     sql.NullInt64
+			lockedUntil sql.NullTime
+		)
+		if err := rows.Scan(&e.ID, &e.Type, &userID, &e.Email, &e.IP, &e.Failures,
+			&lockedUntil, &actorID, &e.CreatedAt); err != nil {
+			return nil, 0, fmt.Errorf("list security events: scan: %w", err)
+		}
+		if userID.Valid {
+			e.UserID = &userID.Int64
+		}
+		if actorID.Valid {
+			e.ActorID = &actorID.Int64
+		}
+		if lockedUntil.Valid {
+			e.LockedUntil = &lockedUntil.Time
+		}
+		res = append(res, e)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, 0, fmt.Errorf("list security events: rows: %w", err)
+	}
+	return res, total, nil
+}
@@ -0,0 +1,111 @@
+package repos
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"backend/internal/erors"
+	"backend/internal/models"
+)
+
+type SessionRepoInterface interface {
+	Create(ctx context.Context, session models.Session) error
+	GetByJTI(ctx context.Context, jti string) (models.Session, error)
+	Extend(ctx context.Context, jti string, expiresAt time.Time) error
+	Revoke(ctx context.Context, jti string) error
+	RevokeAllByUserID(ctx context.Context, userID int64) error
+}
+
+type sessionRepo struct {
+	DB *sql.DB
+}
+
+func NewSessionRepo(db *sql.DB) SessionRepoInterface {
+	return &sessionRepo{DB: db}
+}
+
+func (r *sessionRepo) Create(ctx context.Context, session models.Session) error {
+	const q = `
+		INSERT INTO sessions (jti, user_id, expires_at)
+		VALUES ($1, $2, $3)
+	`
+	if _, err := r.DB.ExecContext(ctx, q, session.JTI, session.UserID, session.ExpiresAt); err != nil {
+		return fmt.Errorf("create session: %w", err)
+	}
+	return nil
+}
+
+func (r *sessionRepo) GetByJTI(ctx context.Context, jti string) (models.Session, error) {
+	const q = `
+		SELECT jti, user_id, created_at, expires_at, revoked_at
+		FROM sessions
+		WHERE jti = $1
+	`
+	var s models.Session
+	err := r.DB.QueryRowContext(ctx, q, jti).Scan(&s.JTI, &s.UserID, &s.CreatedAt, &s.ExpiresAt, &s.RevokedAt)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return models.Session{}, erors.ErrNotFound
+		}
+		return models.Session{}, fmt.Errorf("session by jti: %w", err)
+	}
+	return s, nil
+}
+
+// Extend продлевает неотозванную сессию до expiresAt
+func (r *sessionRepo) Extend(ctx context.Context, jti string, expiresAt time.Time) error {
+	const q = `
+		UPDATE sessions
+		SET expires_at = $2
+		WHERE jti = $1 AND revoked_at IS NULL
+	`
+	if _, err := r.DB.ExecContext(ctx, q, jti, expiresAt); err != nil {
+		return fmt.Errorf("extend session: %w", err)
+	}
+	return nil
+}
+
+// Revoke отзывает сессию вместе со всеми refresh-токенами ее семейства
+func (r *sessionRepo) Revoke(ctx context.Context, jti string) error {
+	tx, err := r.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("revoke session: begin: %w", err)
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx,
+		`UPDATE sessions SET revoked_at = now() WHERE jti = $1 AND revoked_at IS NULL`, jti,
+	); err != nil {
+		return fmt.Errorf("revoke session: sessions: %w", err)
+	}
+	if _, err := tx.ExecContext(ctx,
+		`UPDATE refresh_tokens SET revoked_at = now() WHERE family_id = $1 AND revoked_at IS NULL`, jti,
+	); err != nil {
+		return fmt.Errorf("revoke session: refresh tokens: %w", err)
+	}
+	return tx.Commit()
+}
+
+// RevokeAllByUserID отзывает все сессии и refresh-токены пользователя
+func (r *sessionRepo) RevokeAllByUserID(ctx context.Context, userID int64) error {
+	tx, err := r.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("revoke user sessions: begin: %w", err)
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx,
+		`UPDATE sessions SET revoked_at = now() WHERE user_id = $1 AND revoked_at IS NULL`, userID,
+	); err != nil {
+		return fmt.Errorf("revoke user sessions: sessions: %w", err)
+	}
+	if _, err := tx.ExecContext(ctx,
+		`UPDATE refresh_tokens SET revoked_at = now() WHERE user_id = $1 AND revoked_at IS NULL`, userID,
+	); err != nil {
+		return fmt.Errorf("revoke user sessions: refresh tokens: %w", err)
+	}
+	return tx.Commit()
+}
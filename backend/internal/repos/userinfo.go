@@ -14,6 +14,9 @@ import (
 type UserRepoInterface interface {
 	GetUserInfo(ctx context.Context, userID int64) (models.User, error)
 	UpdateUserInfo(ctx context.Context, user models.User) error
+	UpdateRole(ctx context.Context, userID int64, role string) error
+	GetPrivacy(ctx context.Context, userID int64) (models.PrivacySettings, error)
+	UpdatePrivacy(ctx context.Context, userID int64, dto models.UpdatePrivacyDTO) (models.PrivacySettings, error)
 }
 
 type userInfoRepo struct {
@@ -29,13 +32,16 @@ func (r *userInfoRepo) GetUserInfo(ctx context.Context, userID int64) (models.Us
 
 	row := r.DB.QueryRowContext(
 		ctx,
-		`SELECT name, email, created_at, date_of_birth, city FROM users WHERE id = $1`,
+		`SELECT name, email, email_verified_at IS NOT NULL, created_at,
+		        COALESCE(to_char(date_of_birth, 'YYYY-MM-DD'), ''), COALESCE(city, '')
+		 FROM users WHERE id = $1`,
 		userID,
 	)
 
 	if err := row.Scan(
 		&user.Name,
 		&user.Email,
+		&user.EmailVerified,
 		&user.CreatedAt,
 		&user.DateOfBirth,
 		&user.City,
@@ -49,11 +55,13 @@ func (r *userInfoRepo) GetUserInfo(ctx context.Context, userID int64) (models.Us
 	return user, nil
 }
 
+// UpdateUserInfo пустые имя и email оставляет прежними; при смене email сбрасывает его подтверждение
 func (r *userInfoRepo) UpdateUserInfo(ctx context.Context, user models.User) error {
 	result, err := r.DB.ExecContext(
 		ctx,
 		`UPDATE users 
-         SET name = $1, email = $2, city = $3 
+         SET name = COALESCE(NULLIF($1, ''), name), email = COALESCE(NULLIF($2, ''), email), city = $3,
+             email_verified_at = CASE WHEN email = COALESCE(NULLIF($2, ''), email) THEN email_verified_at END
          WHERE id = $4`,
 		user.Name, user.Email, user.City, user.ID,
 	)
@@ -76,3 +84,61 @@ func (r *userInfoRepo) UpdateUserInfo(ctx context.Context, user models.User) err
 
 	return nil
 }
+
+func (r *userInfoRepo) UpdateRole(ctx context.Context, userID int64, role string) error {
+	result, err := r.DB.ExecContext(ctx, `UPDATE users SET role = $1 WHERE id = $2`, role, userID)
+	if err != nil {
+		return fmt.Errorf("update user role: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("rows affected: %w", err)
+	}
+	if rows == 0 {
+		return erors.ErrUserNotFound
+	}
+
+	return nil
+}
+
+// GetPrivacy настройки приватности; пока пользователь их не менял, все открыто
+func (r *userInfoRepo) GetPrivacy(ctx context.Context, userID int64) (models.PrivacySettings, error) {
+	var p models.PrivacySettings
+	err := r.DB.QueryRowContext(ctx, `
+		SELECT COALESCE(p.share_reviews, TRUE), COALESCE(p.share_favorites, TRUE), COALESCE(p.share_stays, TRUE)
+		FROM users u
+		LEFT JOIN user_privacy_settings p ON p.user_id = u.id
+		WHERE u.id = $1
+	`, userID).Scan(&p.ShareReviews, &p.ShareFavorites, &p.ShareStays)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return models.PrivacySettings{}, erors.ErrUserNotFound
+		}
+		return models.PrivacySettings{}, fmt.Errorf("get privacy: %w", err)
+	}
+	return p, nil
+}
+
+// UpdatePrivacy меняет только переданные (не nil) поля
+func (r *userInfoRepo) UpdatePrivacy(ctx context.Context, userID int64, dto models.UpdatePrivacyDTO) (models.PrivacySettings, error) {
+	var p models.PrivacySettings
+	err := r.DB.QueryRowContext(ctx, `
+		INSERT INTO user_privacy_settings (user_id, share_reviews, share_favorites, share_stays)
+		VALUES ($1, COALESCE($2::boolean, TRUE), COALESCE($3::boolean, TRUE), COALESCE($4::boolean, TRUE))
+		ON CONFLICT (user_id) DO UPDATE
+		SET share_reviews   = COALESCE($2::boolean, user_privacy_settings.share_reviews),
+		    share_favorites = COALESCE($3::boolean, user_privacy_settings.share_favorites),
+		    share_stays     = COALESCE($4::boolean, user_privacy_settings.share_stays),
+		    updated_at      = now()
+		RETURNING share_reviews, share_favorites, share_stays
+	`, userID, dto.ShareReviews, dto.ShareFavorites, dto.ShareStays).Scan(&p.ShareReviews, &p.ShareFavorites, &p.ShareStays)
+	if err != nil {
+		var pqErr *pq.Error
+		if errors.As(err, &pqErr) && pqErr.Code == "23503" {
+			return models.PrivacySettings{}, erors.ErrUserNotFound
+		}
+		return models.PrivacySettings{}, fmt.Errorf("update privacy: %w", err)
+	}
+	return p, nil
+}
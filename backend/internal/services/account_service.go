@@ -0,0 +1,217 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"backend/internal/config"
+	"backend/internal/erors"
+	"backend/internal/logger"
+	"backend/internal/mailer"
+	"backend/internal/models"
+	"backend/internal/repos"
+
+	"go.uber.org/zap"
+)
+
+const (
+	defaultVerifyTokenTTL = 48 * time.Hour
+	defaultResetTokenTTL  = time.Hour
+)
+
+// AccountServiceInterface подтверждение email и восстановление пароля по одноразовым ссылкам из писем
+type AccountServiceInterface interface {
+	SendVerification(ctx context.Context, userID int64) error
+	VerifyEmail(ctx context.Context, token string) error
+	ForgotPassword(ctx context.Context, email string) error
+	ResetPassword(ctx context.Context, token, password string) error
+
+	// EnsureVerified ErrEmailNotVerified, если пользователь еще не подтвердил email
+	EnsureVerified(ctx context.Context, userID int64) error
+}
+
+type accountService struct {
+	repo      repos.AccountRepoInterface
+	authRepo  repos.AuthRepoInterface
+	sessions  SessionServiceInterface
+	mailer    mailer.Mailer
+	appURL    string
+	verifyTTL time.Duration
+	resetTTL  time.Duration
+	logger    logger.Logger
+}
+
+func NewAccountService(cfg config.AccountConfig, appURL string, repo repos.AccountRepoInterface, authRepo repos.AuthRepoInterface, sessions SessionServiceInterface, m mailer.Mailer, logger logger.Logger) AccountServiceInterface {
+	s := &accountService{
+		repo:      repo,
+		authRepo:  authRepo,
+		sessions:  sessions,
+		mailer:    m,
+		appURL:    strings.TrimRight(appURL, "/"),
+		verifyTTL: time.Duration(cfg.VerifyTokenTTL) * time.Second,
+		resetTTL:  time.Duration(cfg.ResetTokenTTL) * time.Second,
+		logger:    logger,
+	}
+	if s.verifyTTL <= 0 {
+		s.verifyTTL = defaultVerifyTokenTTL
+	}
+	if s.resetTTL <= 0 {
+		s.resetTTL = defaultResetTokenTTL
+	}
+	return s
+}
+
+// isPlaceholderEmail служебные адреса пользователей Telegram и OAuth без email: писать на них некуда
+func isPlaceholderEmail(email string) bool {
+	return strings.HasSuffix(strings.ToLower(email), ".invalid")
+}
+
+// SendVerification отправляет ссылку подтверждения на текущий email пользователя;
+// предыдущие ссылки перестают действовать
+func (s *accountService) SendVerification(ctx context.Context, userID int64) error {
+	email, verified, err := s.repo.GetEmailStatus(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if verified {
+		return erors.ErrEmailAlreadyVerified
+	}
+	if isPlaceholderEmail(email) {
+		return erors.ErrInvalidInput
+	}
+
+	token, err := s.issueToken(ctx, userID, models.AccountTokenVerifyEmail, email, s.verifyTTL)
+	if err != nil {
+		return err
+	}
+	msg := mailer.Message{
+		To:      email,
+		Subject: "Подтвердите email в StayGo",
+		Body: fmt.Sprintf("Чтобы подтвердить адрес, перейдите по ссылке:\n\n%s\n\nСсылка действует %s. Если вы не регистрировались в StayGo, просто проигнорируйте письмо.\n",
+			s.link("/verify-email", token), formatTTL(s.verifyTTL)),
+	}
+	if err := s.mailer.Send(ctx, msg); err != nil {
+		s.logger.Error("failed to send verification email", zap.Error(err), zap.Int64("user_id", userID))
+		return err
+	}
+	return nil
+}
+
+func (s *accountService) VerifyEmail(ctx context.Context, token string) error {
+	if token == "" {
+		return erors.ErrInvalidToken
+	}
+	userID, err := s.repo.VerifyEmail(ctx, hashToken(token))
+	if err != nil {
+		return err
+	}
+	s.logger.Info("email verified", zap.Int64("user_id", userID))
+	return nil
+}
+
+// ForgotPassword отправляет ссылку сброса пароля. Для неизвестного email ошибки нет,
+// чтобы по ответу нельзя было проверить, зарегистрирован ли адрес.
+func (s *accountService) ForgotPassword(ctx context.Context, email string) error {
+	email = strings.TrimSpace(email)
+	if email == "" {
+		return erors.ErrInvalidInput
+	}
+	if isPlaceholderEmail(email) {
+		return nil
+	}
+	user, err := s.authRepo.GetUserByEmail(ctx, email)
+	if err != nil {
+		if errors.Is(err, erors.ErrInvalidCredentials) {
+			s.logger.Info("password reset requested for unknown email", zap.String("email", email))
+			return nil
+		}
+		return err
+	}
+
+	token, err := s.issueToken(ctx, user.ID, models.AccountTokenResetPassword, user.Email, s.resetTTL)
+	if err != nil {
+		return err
+	}
+	msg := mailer.Message{
+		To:      user.Email,
+		Subject: "Сброс пароля в StayGo",
+		Body: fmt.Sprintf("Чтобы задать новый пароль, перейдите по ссылке:\n\n%s\n\nСсылка действует %s и сработает один раз. Если вы не запрашивали сброс, просто проигнорируйте письмо — пароль останется прежним.\n",
+			s.link("/reset-password", token), formatTTL(s.resetTTL)),
+	}
+	if err := s.mailer.Send(ctx, msg); err != nil {
+		// Ответ не должен отличаться от ответа для неизвестного адреса
+		s.logger.Error("failed to send password reset email", zap.Error(err), zap.Int64("user_id", user.ID))
+	}
+	return nil
+}
+
+// ResetPassword задает новый пароль и завершает все сессии пользователя
+func (s *accountService) ResetPassword(ctx context.Context, token, password string) error {
+	if token == "" {
+		return erors.ErrInvalidToken
+	}
+	if fe := checkPassword(password, "", ""); fe != nil {
+		return &erors.ValidationError{Fields: []erors.FieldError{*fe}}
+	}
+	hash, err := HashPassword(password)
+	if err != nil {
+		s.logger.Error("failed to hash password", zap.Error(err))
+		return err
+	}
+
+	userID, err := s.repo.ResetPassword(ctx, hashToken(token), hash)
+	if err != nil {
+		return err
+	}
+	if err := s.sessions.RevokeAll(ctx, userID); err != nil {
+		s.logger.Error("failed to revoke sessions after password reset", zap.Error(err), zap.Int64("user_id", userID))
+		return err
+	}
+	s.logger.Info("password reset", zap.Int64("user_id", userID))
+	return nil
+}
+
+func (s *accountService) EnsureVerified(ctx context.Context, userID int64) error {
+	_, verified, err := s.repo.GetEmailStatus(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if !verified {
+		return erors.ErrEmailNotVerified
+	}
+	return nil
+}
+
+// issueToken создает одноразовый токен; в БД попадает только его хеш
+func (s *accountService) issueToken(ctx context.Context, userID int64, purpose, email string, ttl time.Duration) (string, error) {
+	token, err := randomHex(32)
+	if err != nil {
+		return "", err
+	}
+	err = s.repo.CreateToken(ctx, hashToken(token), models.AccountToken{
+		UserID:    userID,
+		Purpose:   purpose,
+		Email:     email,
+		ExpiresAt: time.Now().Add(ttl),
+	})
+	if err != nil {
+		return "", err
+	}
+	return token, nil
+}
+
+func (s *accountService) link(path, token string) string {
+	return s.appURL + path + "?token=" + url.QueryEscape(token)
+}
+
+// formatTTL срок действия ссылки для текста письма
+func formatTTL(d time.Duration) string {
+	if d >= time.Hour && d%time.Hour == 0 {
+		return fmt.Sprintf("%d ч", int(d/time.Hour))
+	}
+	return fmt.Sprintf("%d мин", int(d.Round(time.Minute)/time.Minute))
+}
@@ -0,0 +1,128 @@
+package services
+
+import (
+	"context"
+	"regexp"
+	"sort"
+	"strings"
+
+	"backend/internal/erors"
+	"backend/internal/models"
+	"backend/internal/repos"
+)
+
+// maxAmenityFilterCodes ограничение количества удобств в одном фильтре или наборе
+const maxAmenityFilterCodes = 32
+
+// amenityCodeRe коды совпадают с типом Amenity фронтенда, поэтому регистр значим (roomService)
+var amenityCodeRe = regexp.MustCompile(`^[a-zA-Z0-9_]{1,32}$`)
+
+type AmenityServiceInterface interface {
+	List(ctx context.Context, scope string) ([]models.Amenity, error)
+	Create(ctx context.Context, amenity *models.Amenity) error
+	Update(ctx context.Context, amenityID int64, dto models.UpdateAmenityDTO) (models.Amenity, error)
+	Delete(ctx context.Context, amenityID int64) error
+	SetHotelAmenities(ctx context.Context, hotelID int64, codes []string) ([]string, error)
+	SetRoomAmenities(ctx context.Context, roomID int64, codes []string) ([]string, error)
+}
+
+type amenityService struct {
+	amenityRepo repos.AmenityRepoInterface
+}
+
+func NewAmenityService(amenityRepo repos.AmenityRepoInterface) AmenityServiceInterface {
+	return amenityService{amenityRepo: amenityRepo}
+}
+
+// normalizeAmenityCodes убирает пробелы, пустые коды и повторы, сортирует
+func normalizeAmenityCodes(codes []string) ([]string, error) {
+	seen := make(map[string]struct{}, len(codes))
+	res := make([]string, 0, len(codes))
+	for _, code := range codes {
+		code = strings.TrimSpace(code)
+		if code == "" {
+			continue
+		}
+		if !amenityCodeRe.MatchString(code) {
+			return nil, erors.ErrInvalidInput
+		}
+		if _, ok := seen[code]; ok {
+			continue
+		}
+		seen[code] = struct{}{}
+		res = append(res, code)
+	}
+	if len(res) > maxAmenityFilterCodes {
+		return nil, erors.ErrInvalidInput
+	}
+	sort.Strings(res)
+	return res, nil
+}
+
+func (s amenityService) List(ctx context.Context, scope string) ([]models.Amenity, error) {
+	scope = strings.TrimSpace(scope)
+	if scope != "" && !models.IsValidAmenityScope(scope) {
+		return nil, erors.ErrInvalidInput
+	}
+	return s.amenityRepo.List(ctx, scope)
+}
+
+func (s amenityService) Create(ctx context.Context, amenity *models.Amenity) error {
+	amenity.Code = strings.TrimSpace(amenity.Code)
+	amenity.Name = strings.TrimSpace(amenity.Name)
+	if amenity.Scope == "" {
+		amenity.Scope = models.AmenityScopeBoth
+	}
+	if !amenityCodeRe.MatchString(amenity.Code) || amenity.Name == "" || !models.IsValidAmenityScope(amenity.Scope) {
+		return erors.ErrInvalidInput
+	}
+	return s.amenityRepo.Create(ctx, amenity)
+}
+
+func (s amenityService) Update(ctx context.Context, amenityID int64, dto models.UpdateAmenityDTO) (models.Amenity, error) {
+	if amenityID <= 0 || (dto.Name == nil && dto.Scope == nil) {
+		return models.Amenity{}, erors.ErrInvalidInput
+	}
+	if dto.Name != nil {
+		name := strings.TrimSpace(*dto.Name)
+		if name == "" {
+			return models.Amenity{}, erors.ErrInvalidInput
+		}
+		dto.Name = &name
+	}
+	if dto.Scope != nil && !models.IsValidAmenityScope(*dto.Scope) {
+		return models.Amenity{}, erors.ErrInvalidInput
+	}
+	return s.amenityRepo.Update(ctx, amenityID, dto)
+}
+
+func (s amenityService) Delete(ctx context.Context, amenityID int64) error {
+	if amenityID <= 0 {
+		return erors.ErrInvalidInput
+	}
+	return s.amenityRepo.Delete(ctx, amenityID)
+}
+
+// SetHotelAmenities заменяет набор удобств отеля и возвращает его в нормализованном виде
+func (s amenityService) SetHotelAmenities(ctx context.Context, hotelID int64, codes []string) ([]string, error) {
+	codes, err := normalizeAmenityCodes(codes)
+	if err != nil || hotelID <= 0 {
+		return nil, erors.ErrInvalidInput
+	}
+	if err := s.amenityRepo.SetHotelAmenities(ctx, hotelID, codes); err != nil {
+		return nil, err
+	}
+	return codes, nil
+}
+
+// SetRoomAmenities заменяет набор удобств комнаты и возвращает его в нормализованном виде
+func (s amenityService) SetRoomAmenities(ctx context.Context, roomID int64, codes []string) ([]string, error) {
+	codes, err := normalizeAmenityCodes(codes)
+	if err != nil || roomID <= 0 {
+		return nil, erors.ErrInvalidInput
+	}
+	if err := s.amenityRepo.SetRoomAmenities(ctx, roomID, codes); err != nil {
+		return nil, err
+	}
+	return codes, nil
+}
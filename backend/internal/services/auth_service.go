@@ -2,11 +2,13 @@ package services
 
 import (
     "backend/internal/config"
+    "backend/internal/erors"
     "backend/internal/models"
     "backend/internal/repos"
     "backend/internal/logger"
     "context"
     "errors"
+    "time"
 
     "go.uber.org/zap"
     "golang.org/x/crypto/bcrypt"
@@ -16,24 +18,32 @@ import (
 type authService struct {
     config   *config.Config
     authRepo repos.AuthRepoInterface
+    guard    LoginGuardServiceInterface
     logger   logger.Logger
 }
 
 type AuthServiceInterface interface {
     RegisterUser(ctx context.Context, user models.CreateUserDTO) (int64, error)
-    LoginUser(ctx context.Context, email, password string) (models.User, error)
+    LoginUser(ctx context.Context, email, password, ip string) (models.User, error)
 }
 
-func NewAuthService(cfg *config.Config, authRepo repos.AuthRepoInterface, logger logger.Logger) AuthServiceInterface {
+func NewAuthService(cfg *config.Config, authRepo repos.AuthRepoInterface, guard LoginGuardServiceInterface, logger logger.Logger) AuthServiceInterface {
     return &authService{
         config:   cfg,
         authRepo: authRepo,
+        guard:    guard,
         logger:   logger,
     }
 }
 
-// RegisterUser регистрирует нового пользователя с хешированием пароля
+// RegisterUser регистрирует нового пользователя с хешированием пароля.
+// Некорректные данные — *erors.ValidationError с ошибками по полям.
 func (a *authService) RegisterUser(ctx context.Context, dto models.CreateUserDTO) (int64, error) {
+    if err := validateRegistration(&dto, a.config.Account.MinAge, time.Now()); err != nil {
+        a.logger.Info("registration rejected", zap.String("email", dto.Email), zap.Error(err))
+        return 0, err
+    }
+
     hashedPassword, err := HashPassword(dto.Password)
     if err != nil {
         a.logger.Error("failed to hash password", zap.Error(err))
@@ -46,7 +56,7 @@ func (a *authService) RegisterUser(ctx context.Context, dto models.CreateUserDTO
         Password:    hashedPassword,
         City:        dto.City,
         DateOfBirth: dto.DateOfBirth,
-        Role:        dto.Role,  // Передаем роль здесь
+        Role:        models.RoleUser, // Самостоятельно можно зарегистрироваться только обычным пользователем
     }
 
     id, err := a.authRepo.CreateUser(ctx, userToCreate)
@@ -60,29 +70,53 @@ func (a *authService) RegisterUser(ctx context.Context, dto models.CreateUserDTO
 }
 
 
-// LoginUser ищет пользователя по email и проверяет пароль
-func (a *authService) LoginUser(ctx context.Context, email, password string) (models.User, error) {
+// LoginUser ищет пользователя по email и проверяет пароль.
+// После серии неудач по email или с IP вход временно запрещен: *erors.LoginLockedError.
+func (a *authService) LoginUser(ctx context.Context, email, password, ip string) (models.User, error) {
+    if err := a.guard.Check(ctx, email, ip); err != nil {
+        a.logger.Warn("login rejected: too many attempts", zap.String("email", email), zap.String("ip", ip), zap.Error(err))
+        return models.User{}, err
+    }
+
     user, err := a.authRepo.GetUserByEmail(ctx, email)
     if err != nil {
-        a.logger.Warn("login failed: user not found", zap.String("email", email), zap.Error(err))
-        return models.User{}, errors.New("invalid credentials")
+        if !errors.Is(err, erors.ErrInvalidCredentials) {
+            a.logger.Error("login failed", zap.String("email", email), zap.Error(err))
+            return models.User{}, err
+        }
+        // Сравнение с фиктивным хешем выравнивает время ответа: по нему не понять, есть ли такой email
+        CheckPasswordHash(password, dummyPasswordHash)
+        a.logger.Warn("login failed: user not found", zap.String("email", email), zap.String("ip", ip))
+        a.registerFailure(ctx, email, ip, 0)
+        return models.User{}, erors.ErrInvalidCredentials
     }
 
-    a.logger.Info("attempt login",
-        zap.String("email", email),
-        zap.String("dbPasswordHash", user.Password),
-        zap.String("inputPassword", password), // временно для отладки, потом убрать
-    )
-
     if !CheckPasswordHash(password, user.Password) {
-        a.logger.Warn("login failed: password mismatch", zap.String("email", email))
-        return models.User{}, errors.New("invalid credentials")
+        a.logger.Warn("login failed: password mismatch", zap.String("email", email), zap.String("ip", ip))
+        a.registerFailure(ctx, email, ip, user.ID)
+        return models.User{}, erors.ErrInvalidCredentials
     }
 
+    if err := a.guard.Success(ctx, email); err != nil {
+        a.logger.Error("failed to reset login attempts", zap.String("email", email), zap.Error(err))
+    }
     a.logger.Info("user logged in successfully", zap.String("email", email))
     return user, nil
 }
 
+// registerFailure ошибка хранилища счетчиков не должна менять ответ на неверный пароль
+func (a *authService) registerFailure(ctx context.Context, email, ip string, userID int64) {
+    if err := a.guard.Failure(ctx, email, ip, userID); err != nil {
+        a.logger.Error("failed to register login failure", zap.String("email", email), zap.String("ip", ip), zap.Error(err))
+    }
+}
+
+// dummyPasswordHash bcrypt-хеш произвольной строки для входа с незарегистрированным email
+var dummyPasswordHash = func() string {
+    hash, _ := HashPassword("staygo-dummy-password")
+    return hash
+}()
+
 // HashPassword создает хэш пароля с помощью bcrypt
 func HashPassword(password string) (string, error) {
     bytes, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
@@ -0,0 +1,192 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"backend/internal/erors"
+	"backend/internal/models"
+	"backend/internal/repos"
+)
+
+const dateLayout = "2006-01-02"
+
+type BookingServiceInterface interface {
+	CreateBooking(ctx context.Context, userID int64, dto models.CreateBookingDTO) (models.Booking, error)
+	GetByID(ctx context.Context, userID, bookingID int64) (models.Booking, error)
+	ListByUserID(ctx context.Context, userID int64) ([]models.Booking, error)
+	CancelBooking(ctx context.Context, userID, bookingID int64) (models.Booking, error)
+
+	// ConfirmBooking и CompleteBooking доступны владельцу отеля и администратору
+	ConfirmBooking(ctx context.Context, userID int64, role string, bookingID int64) (models.Booking, error)
+	CompleteBooking(ctx context.Context, userID int64, role string, bookingID int64) (models.Booking, error)
+}
+
+type bookingService struct {
+	bookingRepo repos.BookingRepoInterface
+	roomRepo    repos.RoomRepoInterface
+	hotelRepo   repos.HotelRepoInterface
+}
+
+func NewBookingService(bookingRepo repos.BookingRepoInterface, roomRepo repos.RoomRepoInterface, hotelRepo repos.HotelRepoInterface) BookingServiceInterface {
+	return bookingService{bookingRepo: bookingRepo, roomRepo: roomRepo, hotelRepo: hotelRepo}
+}
+
+// parseStayDates разбирает даты заезда/выезда в формате YYYY-MM-DD.
+// Выезд должен быть строго позже заезда, заезд — не раньше сегодняшнего дня.
+func parseStayDates(checkin, checkout string) (time.Time, time.Time, error) {
+	in, err := time.Parse(dateLayout, strings.TrimSpace(checkin))
+	if err != nil {
+		return time.Time{}, time.Time{}, erors.ErrInvalidInput
+	}
+	out, err := time.Parse(dateLayout, strings.TrimSpace(checkout))
+	if err != nil {
+		return time.Time{}, time.Time{}, erors.ErrInvalidInput
+	}
+	if !out.After(in) {
+		return time.Time{}, time.Time{}, erors.ErrInvalidInput
+	}
+	today, _ := time.Parse(dateLayout, time.Now().Format(dateLayout))
+	if in.Before(today) {
+		return time.Time{}, time.Time{}, erors.ErrInvalidInput
+	}
+	return in, out, nil
+}
+
+func (s bookingService) CreateBooking(ctx context.Context, userID int64, dto models.CreateBookingDTO) (models.Booking, error) {
+	if userID <= 0 || dto.RoomID <= 0 || dto.Guests <= 0 {
+		return models.Booking{}, erors.ErrInvalidInput
+	}
+	in, out, err := parseStayDates(dto.CheckIn, dto.CheckOut)
+	if err != nil {
+		return models.Booking{}, err
+	}
+
+	room, err := s.roomRepo.GetRoomByID(ctx, dto.RoomID)
+	if err != nil {
+		if errors.Is(err, erors.ErrNotFound) {
+			return models.Booking{}, erors.ErrInvalidInput
+		}
+		return models.Booking{}, err
+	}
+	if dto.Guests > room.Beds {
+		return models.Booking{}, erors.ErrInvalidInput
+	}
+
+	nights := int(out.Sub(in).Hours() / 24)
+	booking := models.Booking{
+		RoomID:     room.ID,
+		HotelID:    room.HotelID,
+		UserID:     userID,
+		CheckIn:    in.Format(dateLayout),
+		CheckOut:   out.Format(dateLayout),
+		Guests:     dto.Guests,
+		TotalPrice: nights * room.Price,
+		Status:     models.BookingStatusPending,
+	}
+	if err := s.bookingRepo.Create(ctx, &booking); err != nil {
+		return models.Booking{}, err
+	}
+	return booking, nil
+}
+
+// GetByID возвращает бронь, только если она принадлежит пользователю;
+// чужие брони неотличимы от несуществующих.
+func (s bookingService) GetByID(ctx context.Context, userID, bookingID int64) (models.Booking, error) {
+	if bookingID <= 0 {
+		return models.Booking{}, erors.ErrInvalidInput
+	}
+	booking, err := s.bookingRepo.GetByID(ctx, bookingID)
+	if err != nil {
+		return models.Booking{}, err
+	}
+	if booking.UserID != userID {
+		return models.Booking{}, erors.ErrNotFound
+	}
+	return booking, nil
+}
+
+func (s bookingService) ListByUserID(ctx context.Context, userID int64) ([]models.Booking, error) {
+	if userID <= 0 {
+		return nil, erors.ErrInvalidInput
+	}
+	return s.bookingRepo.ListByUserID(ctx, userID)
+}
+
+func (s bookingService) CancelBooking(ctx context.Context, userID, bookingID int64) (models.Booking, error) {
+	if _, err := s.GetByID(ctx, userID, bookingID); err != nil {
+		return models.Booking{}, err
+	}
+	booking, err := s.bookingRepo.UpdateStatus(ctx, bookingID,
+		[]string{models.BookingStatusPending, models.BookingStatusConfirmed},
+		models.BookingStatusCancelled,
+	)
+	if errors.Is(err, erors.ErrConflict) {
+		return models.Booking{}, erors.ErrBookingNotCancellable
+	}
+	return booking, err
+}
+
+// managedBooking проверяет, что пользователь вправе управлять бронью от имени отеля:
+// администратор платформы — любой, владелец — только бронями своих отелей
+func (s bookingService) managedBooking(ctx context.Context, userID int64, role string, bookingID int64) (models.Booking, error) {
+	if userID <= 0 || bookingID <= 0 {
+		return models.Booking{}, erors.ErrInvalidInput
+	}
+	booking, err := s.bookingRepo.GetByID(ctx, bookingID)
+	if err != nil {
+		return models.Booking{}, err
+	}
+	if models.RoleHasPermission(role, models.PermManageHotels) {
+		return booking, nil
+	}
+	if _, err := EnsureHotelOwner(ctx, s.hotelRepo, userID, booking.HotelID); err != nil {
+		if errors.Is(err, erors.ErrNotFound) {
+			return models.Booking{}, erors.ErrNotHotelOwner
+		}
+		return models.Booking{}, err
+	}
+	return booking, nil
+}
+
+// ConfirmBooking отель подтверждает бронь; подтвердить можно только ожидающую
+func (s bookingService) ConfirmBooking(ctx context.Context, userID int64, role string, bookingID int64) (models.Booking, error) {
+	if _, err := s.managedBooking(ctx, userID, role, bookingID); err != nil {
+		return models.Booking{}, err
+	}
+	booking, err := s.bookingRepo.UpdateStatus(ctx, bookingID,
+		[]string{models.BookingStatusPending},
+		models.BookingStatusConfirmed,
+	)
+	if errors.Is(err, erors.ErrConflict) {
+		return models.Booking{}, erors.ErrBookingNotConfirmable
+	}
+	return booking, err
+}
+
+// CompleteBooking отмечает проживание завершенным: бронь должна быть подтверждена,
+// а дата выезда — наступить
+func (s bookingService) CompleteBooking(ctx context.Context, userID int64, role string, bookingID int64) (models.Booking, error) {
+	current, err := s.managedBooking(ctx, userID, role, bookingID)
+	if err != nil {
+		return models.Booking{}, err
+	}
+	out, err := time.Parse(dateLayout, current.CheckOut)
+	if err != nil {
+		return models.Booking{}, err
+	}
+	today, _ := time.Parse(dateLayout, time.Now().Format(dateLayout))
+	if out.After(today) {
+		return models.Booking{}, erors.ErrBookingNotCompletable
+	}
+	booking, err := s.bookingRepo.UpdateStatus(ctx, bookingID,
+		[]string{models.BookingStatusConfirmed},
+		models.BookingStatusCompleted,
+	)
+	if errors.Is(err, erors.ErrConflict) {
+		return models.Booking{}, erors.ErrBookingNotCompletable
+	}
+	return booking, err
+}
@@ -0,0 +1,86 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"encoding/base64"
+	"strings"
+	"time"
+
+	"backend/internal/erors"
+	"backend/internal/models"
+	"backend/internal/repos"
+)
+
+const (
+	defaultFeedLimit = 20
+	maxFeedLimit     = 50
+
+	// feedCursorLayout время события без зоны, с точностью timestamp в Postgres
+	feedCursorLayout = "2006-01-02T15:04:05.999999"
+)
+
+type FeedServiceInterface interface {
+	List(ctx context.Context, userID int64, cursor string, limit int) (models.FeedResponse, error)
+}
+
+type feedService struct {
+	repo repos.FeedRepoInterface
+}
+
+func NewFeedService(repo repos.FeedRepoInterface) FeedServiceInterface {
+	return feedService{repo: repo}
+}
+
+// Курсор — время и ключ последнего показанного события, закодированные в base64url
+func encodeFeedCursor(item models.FeedItem) string {
+	raw := item.Time.Format(feedCursorLayout) + "|" + item.ID
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+func decodeFeedCursor(cursor string) (sql.NullString, string, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return sql.NullString{}, "", erors.ErrInvalidInput
+	}
+	ts, key, ok := strings.Cut(string(raw), "|")
+	if !ok || key == "" {
+		return sql.NullString{}, "", erors.ErrInvalidInput
+	}
+	if _, err := time.Parse(feedCursorLayout, ts); err != nil {
+		return sql.NullString{}, "", erors.ErrInvalidInput
+	}
+	return sql.NullString{String: ts, Valid: true}, key, nil
+}
+
+// List лента друзей: новые отзывы, избранное и завершенные проживания по убыванию времени.
+// Пустой cursor — первая страница.
+func (s feedService) List(ctx context.Context, userID int64, cursor string, limit int) (models.FeedResponse, error) {
+	if limit == 0 {
+		limit = defaultFeedLimit
+	}
+	if userID <= 0 || limit < 1 || limit > maxFeedLimit {
+		return models.FeedResponse{}, erors.ErrInvalidInput
+	}
+
+	var before sql.NullString
+	var beforeKey string
+	if cursor != "" {
+		var err error
+		if before, beforeKey, err = decodeFeedCursor(cursor); err != nil {
+			return models.FeedResponse{}, err
+		}
+	}
+
+	// Лишний элемент показывает, есть ли следующая страница
+	items, err := s.repo.ListFriendActivity(ctx, userID, before, beforeKey, limit+1)
+	if err != nil {
+		return models.FeedResponse{}, err
+	}
+	res := models.FeedResponse{Data: items}
+	if len(items) > limit {
+		res.Data = items[:limit]
+		res.NextCursor = encodeFeedCursor(res.Data[limit-1])
+	}
+	return res, nil
+}
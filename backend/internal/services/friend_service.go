@@ -0,0 +1,138 @@
+package services
+
+import (
+	"context"
+	"strings"
+
+	"backend/internal/erors"
+	"backend/internal/models"
+	"backend/internal/repos"
+)
+
+type FriendServiceInterface interface {
+	SendRequest(ctx context.Context, userID int64, dto models.FriendRequestDTO) (models.Friend, error)
+	AcceptRequest(ctx context.Context, userID, fromID int64) (models.Friend, error)
+	DeclineRequest(ctx context.Context, userID, fromID int64) error
+	RemoveFriend(ctx context.Context, userID, otherID int64) error
+	Block(ctx context.Context, userID, otherID int64) error
+	Unblock(ctx context.Context, userID, otherID int64) error
+	ListFriends(ctx context.Context, userID int64) ([]models.Friend, error)
+	ListIncoming(ctx context.Context, userID int64) ([]models.Friend, error)
+	ListOutgoing(ctx context.Context, userID int64) ([]models.Friend, error)
+	ListBlocked(ctx context.Context, userID int64) ([]models.Friend, error)
+}
+
+type friendService struct {
+	repo repos.FriendRepoInterface
+}
+
+func NewFriendService(repo repos.FriendRepoInterface) FriendServiceInterface {
+	return friendService{repo: repo}
+}
+
+// resolveTarget получатель заявки: ровно одно из полей email / user_id
+func (s friendService) resolveTarget(ctx context.Context, dto models.FriendRequestDTO) (int64, error) {
+	email := strings.TrimSpace(dto.Email)
+	switch {
+	case email != "" && dto.UserID != 0, email == "" && dto.UserID <= 0:
+		return 0, erors.ErrInvalidInput
+	case email != "":
+		return s.repo.FindUserIDByEmail(ctx, email)
+	default:
+		return dto.UserID, nil
+	}
+}
+
+// SendRequest отправляет заявку в друзья. Если вторая сторона уже прислала встречную
+// заявку, она принимается. Блокировка в любую сторону — ErrForbidden.
+func (s friendService) SendRequest(ctx context.Context, userID int64, dto models.FriendRequestDTO) (models.Friend, error) {
+	targetID, err := s.resolveTarget(ctx, dto)
+	if err != nil {
+		return models.Friend{}, err
+	}
+	if targetID == userID {
+		return models.Friend{}, erors.ErrInvalidInput
+	}
+
+	relations, err := s.repo.GetRelations(ctx, userID, targetID)
+	if err != nil {
+		return models.Friend{}, err
+	}
+	for _, rel := range relations {
+		if rel.Status == models.FriendStatusBlocked {
+			return models.Friend{}, erors.ErrForbidden
+		}
+	}
+	// Без блокировок у пары может быть не больше одной связи
+	if len(relations) > 0 {
+		rel := relations[0]
+		if rel.Status == models.FriendStatusPending && rel.UserID == targetID {
+			return s.AcceptRequest(ctx, userID, targetID)
+		}
+		// Уже друзья или заявка уже отправлена
+		return models.Friend{}, erors.ErrConflict
+	}
+
+	if err := s.repo.CreateRequest(ctx, userID, targetID); err != nil {
+		return models.Friend{}, err
+	}
+	return s.repo.GetFriend(ctx, userID, targetID)
+}
+
+// AcceptRequest принимает входящую заявку от fromID
+func (s friendService) AcceptRequest(ctx context.Context, userID, fromID int64) (models.Friend, error) {
+	if fromID <= 0 || fromID == userID {
+		return models.Friend{}, erors.ErrInvalidInput
+	}
+	if err := s.repo.Accept(ctx, fromID, userID); err != nil {
+		return models.Friend{}, err
+	}
+	return s.repo.GetFriend(ctx, userID, fromID)
+}
+
+// DeclineRequest отклоняет входящую заявку; отправитель может прислать ее снова
+func (s friendService) DeclineRequest(ctx context.Context, userID, fromID int64) error {
+	if fromID <= 0 || fromID == userID {
+		return erors.ErrInvalidInput
+	}
+	return s.repo.DeletePending(ctx, fromID, userID)
+}
+
+// RemoveFriend удаляет друга или отменяет заявку в любом направлении
+func (s friendService) RemoveFriend(ctx context.Context, userID, otherID int64) error {
+	if otherID <= 0 || otherID == userID {
+		return erors.ErrInvalidInput
+	}
+	return s.repo.DeleteRelation(ctx, userID, otherID)
+}
+
+// Block разрывает дружбу и запрещает пользователю otherID присылать заявки
+func (s friendService) Block(ctx context.Context, userID, otherID int64) error {
+	if otherID <= 0 || otherID == userID {
+		return erors.ErrInvalidInput
+	}
+	return s.repo.Block(ctx, userID, otherID)
+}
+
+func (s friendService) Unblock(ctx context.Context, userID, otherID int64) error {
+	if otherID <= 0 || otherID == userID {
+		return erors.ErrInvalidInput
+	}
+	return s.repo.Unblock(ctx, userID, otherID)
+}
+
+func (s friendService) ListFriends(ctx context.Context, userID int64) ([]models.Friend, error) {
+	return s.repo.ListFriends(ctx, userID)
+}
+
+func (s friendService) ListIncoming(ctx context.Context, userID int64) ([]models.Friend, error) {
+	return s.repo.ListIncoming(ctx, userID)
+}
+
+func (s friendService) ListOutgoing(ctx context.Context, userID int64) ([]models.Friend, error) {
+	return s.repo.ListOutgoing(ctx, userID)
+}
+
+func (s friendService) ListBlocked(ctx context.Context, userID int64) ([]models.Friend, error) {
+	return s.repo.ListBlocked(ctx, userID)
+}
@@ -5,14 +5,29 @@ import (
 	"backend/internal/models"
 	"backend/internal/repos"
 	"context"
+	"math"
 	"strings"
+	"unicode/utf8"
 )
 
 type HotelServiceInterface interface {
 	CreateHotel(ctx context.Context, hotel *models.Hotel) error
-	GetAll(ctx context.Context) ([]models.Hotel, error)
+	List(ctx context.Context, filter models.HotelFilter) (models.HotelListResponse, error)
 	GetByID(ctx context.Context, hotelID int64) (models.Hotel, error)
+	RatingSummary(ctx context.Context, hotelID int64) (models.RatingSummary, error)
+	ListNearby(ctx context.Context, filter models.NearbyFilter) ([]models.Hotel, error)
+	ListInBoundingBox(ctx context.Context, box models.BoundingBox) ([]models.Hotel, error)
 	ListByCity(ctx context.Context, city string) ([]models.Hotel, error) 
+	Search(ctx context.Context, query string, page, limit int) (models.HotelSearchResponse, error)
+	UpdateHotel(ctx context.Context, hotelID int64, dto models.UpdateHotelDTO) (models.Hotel, error)
+	ReplaceHotel(ctx context.Context, hotelID int64, hotel models.Hotel) (models.Hotel, error)
+	DeleteHotel(ctx context.Context, hotelID int64, hard bool) error
+
+	// Партнерский API: операции только над отелями владельца
+	CreateOwnedHotel(ctx context.Context, ownerID int64, hotel *models.Hotel) error
+	ListOwnedHotels(ctx context.Context, ownerID int64) ([]models.Hotel, error)
+	UpdateOwnedHotel(ctx context.Context, ownerID, hotelID int64, dto models.UpdateHotelDTO) (models.Hotel, error)
+	DeleteOwnedHotel(ctx context.Context, ownerID, hotelID int64) error
 }
 
 type hotelService struct {
@@ -24,21 +39,266 @@ func NewHotelService(hotelRepo repos.HotelRepoInterface) HotelServiceInterface {
 }
 
 func (s hotelService) CreateHotel(ctx context.Context, hotel *models.Hotel) error {
+	if err := validateCoordinates(hotel.Latitude, hotel.Longitude); err != nil {
+		return err
+	}
 	return s.hotelRepo.Create(ctx, hotel)
 }
 
-func (s hotelService) GetAll(ctx context.Context) ([]models.Hotel, error) {
-	return s.hotelRepo.GetAll(ctx)
+const (
+	defaultHotelPageLimit = 20
+	maxHotelPageLimit     = 100
+)
+
+// List возвращает страницу отелей; некорректные параметры сортировки и пагинации
+// отклоняются, отсутствующие заменяются значениями по умолчанию
+func (s hotelService) List(ctx context.Context, filter models.HotelFilter) (models.HotelListResponse, error) {
+	if filter.Page == 0 {
+		filter.Page = 1
+	}
+	if filter.Limit == 0 {
+		filter.Limit = defaultHotelPageLimit
+	}
+	if filter.SortOrder == "" {
+		filter.SortOrder = "asc"
+	}
+	if filter.Page < 1 || filter.Limit < 1 || filter.Limit > maxHotelPageLimit ||
+		filter.MinStars < 0 || filter.MinPrice < 0 || filter.MaxPrice < 0 || !isFinite(filter.MinRating) || filter.MinRating < 0 ||
+		(filter.MaxPrice > 0 && filter.MaxPrice < filter.MinPrice) {
+		return models.HotelListResponse{}, erors.ErrInvalidInput
+	}
+	amenities, err := normalizeAmenityCodes(filter.Amenities)
+	if err != nil {
+		return models.HotelListResponse{}, err
+	}
+	filter.Amenities = amenities
+	switch filter.SortBy {
+	case "", "price", "rating", "stars":
+	default:
+		return models.HotelListResponse{}, erors.ErrInvalidInput
+	}
+	if filter.SortOrder != "asc" && filter.SortOrder != "desc" {
+		return models.HotelListResponse{}, erors.ErrInvalidInput
+	}
+
+	hotels, total, err := s.hotelRepo.List(ctx, filter)
+	if err != nil {
+		return models.HotelListResponse{}, err
+	}
+	return models.HotelListResponse{
+		Data: hotels,
+		Pagination: models.Pagination{
+			Total:      total,
+			Page:       filter.Page,
+			Limit:      filter.Limit,
+			TotalPages: (total + filter.Limit - 1) / filter.Limit,
+		},
+	}, nil
 }
 
 func (s hotelService) GetByID(ctx context.Context, hotelID int64) (models.Hotel, error) {
 	return s.hotelRepo.GetByID(ctx, hotelID)
 }
 
+func (s hotelService) RatingSummary(ctx context.Context, hotelID int64) (models.RatingSummary, error) {
+	if hotelID <= 0 {
+		return models.RatingSummary{}, erors.ErrInvalidInput
+	}
+	return s.hotelRepo.RatingSummary(ctx, hotelID)
+}
+
 func (s hotelService) ListByCity(ctx context.Context, city string) ([]models.Hotel, error) {
     city = strings.TrimSpace(city)
     if city == "" {
         return nil, erors.ErrInvalidInput
     }
     return s.hotelRepo.ListByCity(ctx, city)
-}
\ No newline at end of file
+}
+
+const (
+	defaultNearbyRadiusKm = 10
+	maxNearbyRadiusKm     = 500
+	defaultGeoLimit       = 50
+	maxGeoLimit           = 500
+)
+
+// validateCoordinates координаты необязательны, но задаются парой и в допустимых пределах
+func validateCoordinates(lat, lng *float64) error {
+	if (lat == nil) != (lng == nil) {
+		return erors.ErrInvalidInput
+	}
+	if lat != nil && !validLatLng(*lat, *lng) {
+		return erors.ErrInvalidInput
+	}
+	return nil
+}
+
+func validLatLng(lat, lng float64) bool {
+	return isFinite(lat) && isFinite(lng) && lat >= -90 && lat <= 90 && lng >= -180 && lng <= 180
+}
+
+// isFinite отсекает NaN и ±Inf: strconv.ParseFloat их принимает, а NaN проходит любые
+// сравнения в Go как false, тогда как в PostgreSQL он больше любого числа
+func isFinite(v float64) bool {
+	return !math.IsNaN(v) && !math.IsInf(v, 0)
+}
+
+// ListNearby отели в радиусе от точки, ближайшие первыми
+func (s hotelService) ListNearby(ctx context.Context, filter models.NearbyFilter) ([]models.Hotel, error) {
+	if filter.RadiusKm == 0 {
+		filter.RadiusKm = defaultNearbyRadiusKm
+	}
+	if filter.Limit == 0 {
+		filter.Limit = defaultGeoLimit
+	}
+	if !validLatLng(filter.Lat, filter.Lng) ||
+		!isFinite(filter.RadiusKm) || filter.RadiusKm <= 0 || filter.RadiusKm > maxNearbyRadiusKm ||
+		filter.Limit < 1 || filter.Limit > maxGeoLimit {
+		return nil, erors.ErrInvalidInput
+	}
+	return s.hotelRepo.ListNearby(ctx, filter)
+}
+
+// ListInBoundingBox отели в видимой области карты
+func (s hotelService) ListInBoundingBox(ctx context.Context, box models.BoundingBox) ([]models.Hotel, error) {
+	if box.Limit == 0 {
+		box.Limit = defaultGeoLimit
+	}
+	if !validLatLng(box.MinLat, box.MinLng) || !validLatLng(box.MaxLat, box.MaxLng) ||
+		box.MinLat > box.MaxLat || box.Limit < 1 || box.Limit > maxGeoLimit {
+		return nil, erors.ErrInvalidInput
+	}
+	return s.hotelRepo.ListInBoundingBox(ctx, box)
+}
+
+// maxSearchQueryLength ограничение длины поискового запроса в символах
+const maxSearchQueryLength = 200
+
+// Search полнотекстовый поиск отелей с пагинацией
+func (s hotelService) Search(ctx context.Context, query string, page, limit int) (models.HotelSearchResponse, error) {
+	query = strings.TrimSpace(query)
+	if page == 0 {
+		page = 1
+	}
+	if limit == 0 {
+		limit = defaultHotelPageLimit
+	}
+	if query == "" || utf8.RuneCountInString(query) > maxSearchQueryLength ||
+		page < 1 || limit < 1 || limit > maxHotelPageLimit {
+		return models.HotelSearchResponse{}, erors.ErrInvalidInput
+	}
+
+	hits, total, err := s.hotelRepo.Search(ctx, query, limit, (page-1)*limit)
+	if err != nil {
+		return models.HotelSearchResponse{}, err
+	}
+	return models.HotelSearchResponse{
+		Data: hits,
+		Pagination: models.Pagination{
+			Total:      total,
+			Page:       page,
+			Limit:      limit,
+			TotalPages: (total + limit - 1) / limit,
+		},
+	}, nil
+}
+
+// EnsureHotelOwner проверяет, что отель существует и принадлежит ownerID
+func EnsureHotelOwner(ctx context.Context, hotelRepo repos.HotelRepoInterface, ownerID, hotelID int64) (models.Hotel, error) {
+	if ownerID <= 0 || hotelID <= 0 {
+		return models.Hotel{}, erors.ErrInvalidInput
+	}
+	hotel, err := hotelRepo.GetByID(ctx, hotelID)
+	if err != nil {
+		return models.Hotel{}, err
+	}
+	if hotel.OwnerID != ownerID {
+		return models.Hotel{}, erors.ErrNotHotelOwner
+	}
+	return hotel, nil
+}
+
+func validateHotel(hotel models.Hotel) error {
+	if strings.TrimSpace(hotel.Name) == "" || hotel.Stars < 0 || hotel.Stars > 5 {
+		return erors.ErrInvalidInput
+	}
+	return validateCoordinates(hotel.Latitude, hotel.Longitude)
+}
+
+func validateHotelUpdate(dto models.UpdateHotelDTO) error {
+	if dto.Name == nil && dto.City == nil && dto.Description == nil && dto.Stars == nil && dto.Address == nil &&
+		dto.Latitude == nil && dto.Longitude == nil {
+		return erors.ErrInvalidInput
+	}
+	if dto.Name != nil && strings.TrimSpace(*dto.Name) == "" {
+		return erors.ErrInvalidInput
+	}
+	if dto.Stars != nil && (*dto.Stars < 0 || *dto.Stars > 5) {
+		return erors.ErrInvalidInput
+	}
+	return validateCoordinates(dto.Latitude, dto.Longitude)
+}
+
+func (s hotelService) UpdateHotel(ctx context.Context, hotelID int64, dto models.UpdateHotelDTO) (models.Hotel, error) {
+	if hotelID <= 0 {
+		return models.Hotel{}, erors.ErrInvalidInput
+	}
+	if err := validateHotelUpdate(dto); err != nil {
+		return models.Hotel{}, err
+	}
+	return s.hotelRepo.Update(ctx, hotelID, dto)
+}
+
+// ReplaceHotel полная замена редактируемых полей отеля (PUT)
+func (s hotelService) ReplaceHotel(ctx context.Context, hotelID int64, hotel models.Hotel) (models.Hotel, error) {
+	if hotelID <= 0 {
+		return models.Hotel{}, erors.ErrInvalidInput
+	}
+	if err := validateHotel(hotel); err != nil {
+		return models.Hotel{}, err
+	}
+	return s.hotelRepo.Replace(ctx, hotelID, hotel)
+}
+
+// DeleteHotel по умолчанию удаляет мягко; hard=true удаляет физически вместе со всей историей
+func (s hotelService) DeleteHotel(ctx context.Context, hotelID int64, hard bool) error {
+	if hotelID <= 0 {
+		return erors.ErrInvalidInput
+	}
+	if hard {
+		return s.hotelRepo.HardDelete(ctx, hotelID)
+	}
+	return s.hotelRepo.Delete(ctx, hotelID)
+}
+
+func (s hotelService) CreateOwnedHotel(ctx context.Context, ownerID int64, hotel *models.Hotel) error {
+	if ownerID <= 0 {
+		return erors.ErrInvalidInput
+	}
+	if err := validateHotel(*hotel); err != nil {
+		return err
+	}
+	hotel.OwnerID = ownerID
+	return s.hotelRepo.Create(ctx, hotel)
+}
+
+func (s hotelService) ListOwnedHotels(ctx context.Context, ownerID int64) ([]models.Hotel, error) {
+	if ownerID <= 0 {
+		return nil, erors.ErrInvalidInput
+	}
+	return s.hotelRepo.ListByOwner(ctx, ownerID)
+}
+
+func (s hotelService) UpdateOwnedHotel(ctx context.Context, ownerID, hotelID int64, dto models.UpdateHotelDTO) (models.Hotel, error) {
+	if _, err := EnsureHotelOwner(ctx, s.hotelRepo, ownerID, hotelID); err != nil {
+		return models.Hotel{}, err
+	}
+	return s.UpdateHotel(ctx, hotelID, dto)
+}
+
+func (s hotelService) DeleteOwnedHotel(ctx context.Context, ownerID, hotelID int64) error {
+	if _, err := EnsureHotelOwner(ctx, s.hotelRepo, ownerID, hotelID); err != nil {
+		return err
+	}
+	return s.DeleteHotel(ctx, hotelID, false)
+}
@@ -0,0 +1,264 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"image"
+	"image/color"
+	"image/jpeg"
+	_ "image/png"
+	"io"
+	"net/http"
+	"strings"
+
+	"backend/internal/erors"
+	"backend/internal/logger"
+	"backend/internal/models"
+	"backend/internal/repos"
+	"backend/internal/storage"
+
+	"go.uber.org/zap"
+)
+
+const (
+	// DefaultMaxImageSize лимит размера загружаемого файла, если в конфиге не задан иной
+	DefaultMaxImageSize = 10 << 20
+	// maxImagePixels защита от «бомб» распаковки: маленький файл с огромным разрешением
+	maxImagePixels = 40_000_000
+	// thumbnailMaxSide длинная сторона превью в пикселях
+	thumbnailMaxSide  = 400
+	thumbnailQuality  = 80
+	maxImageAltLength = 200
+)
+
+// allowedImageTypes тип определяется по содержимому файла, заголовку клиента не доверяем
+var allowedImageTypes = map[string]string{
+	"image/jpeg": ".jpg",
+	"image/png":  ".png",
+}
+
+type ImageServiceInterface interface {
+	Upload(ctx context.Context, owner models.ImageOwner, file io.Reader, alt string, primary bool) (models.Image, error)
+	List(ctx context.Context, owner models.ImageOwner) ([]models.Image, error)
+	Reorder(ctx context.Context, owner models.ImageOwner, ids []int64) ([]models.Image, error)
+	SetPrimary(ctx context.Context, owner models.ImageOwner, imageID int64) ([]models.Image, error)
+	Delete(ctx context.Context, owner models.ImageOwner, imageID int64) error
+}
+
+type imageService struct {
+	imageRepo repos.ImageRepoInterface
+	hotelRepo repos.HotelRepoInterface
+	roomRepo  repos.RoomRepoInterface
+	storage   storage.Storage
+	maxSize   int64
+	logger    logger.Logger
+}
+
+func NewImageService(imageRepo repos.ImageRepoInterface, hotelRepo repos.HotelRepoInterface, roomRepo repos.RoomRepoInterface, store storage.Storage, maxSize int64, logger logger.Logger) ImageServiceInterface {
+	if maxSize <= 0 {
+		maxSize = DefaultMaxImageSize
+	}
+	return imageService{
+		imageRepo: imageRepo,
+		hotelRepo: hotelRepo,
+		roomRepo:  roomRepo,
+		storage:   store,
+		maxSize:   maxSize,
+		logger:    logger,
+	}
+}
+
+// ensureOwner проверяет, что отель или комната существует и не удалены
+func (s imageService) ensureOwner(ctx context.Context, owner models.ImageOwner) error {
+	if owner.ID <= 0 {
+		return erors.ErrInvalidInput
+	}
+	var err error
+	switch owner.Kind {
+	case models.ImageOwnerHotel:
+		_, err = s.hotelRepo.GetByID(ctx, owner.ID)
+	case models.ImageOwnerRoom:
+		_, err = s.roomRepo.GetRoomByID(ctx, owner.ID)
+	default:
+		return erors.ErrInvalidInput
+	}
+	return err
+}
+
+// Upload проверяет файл, строит превью и сохраняет оба файла в хранилище
+func (s imageService) Upload(ctx context.Context, owner models.ImageOwner, file io.Reader, alt string, primary bool) (models.Image, error) {
+	alt = strings.TrimSpace(alt)
+	if len([]rune(alt)) > maxImageAltLength {
+		return models.Image{}, erors.ErrInvalidInput
+	}
+	if err := s.ensureOwner(ctx, owner); err != nil {
+		return models.Image{}, err
+	}
+
+	data, err := io.ReadAll(io.LimitReader(file, s.maxSize+1))
+	if err != nil {
+		return models.Image{}, fmt.Errorf("upload image: read: %w", err)
+	}
+	if int64(len(data)) > s.maxSize {
+		return models.Image{}, erors.ErrFileTooLarge
+	}
+	contentType := http.DetectContentType(data)
+	ext, ok := allowedImageTypes[contentType]
+	if !ok {
+		return models.Image{}, erors.ErrUnsupportedMediaType
+	}
+
+	cfg, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil || cfg.Width <= 0 || cfg.Height <= 0 {
+		return models.Image{}, erors.ErrUnsupportedMediaType
+	}
+	if cfg.Width*cfg.Height > maxImagePixels {
+		return models.Image{}, erors.ErrFileTooLarge
+	}
+	src, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return models.Image{}, erors.ErrUnsupportedMediaType
+	}
+	var thumb bytes.Buffer
+	if err := jpeg.Encode(&thumb, thumbnail(src, thumbnailMaxSide), &jpeg.Options{Quality: thumbnailQuality}); err != nil {
+		return models.Image{}, fmt.Errorf("upload image: thumbnail: %w", err)
+	}
+
+	name, err := randomHex(16)
+	if err != nil {
+		return models.Image{}, err
+	}
+	prefix := fmt.Sprintf("%ss/%d/%s", owner.Kind, owner.ID, name)
+	img := models.Image{
+		Alt:          alt,
+		IsPrimary:    primary,
+		Width:        cfg.Width,
+		Height:       cfg.Height,
+		StorageKey:   prefix + ext,
+		ThumbnailKey: prefix + "_thumb.jpg",
+		ContentType:  contentType,
+		SizeBytes:    len(data),
+	}
+
+	if img.URL, err = s.storage.Save(ctx, img.StorageKey, bytes.NewReader(data), contentType); err != nil {
+		return models.Image{}, err
+	}
+	if img.ThumbnailURL, err = s.storage.Save(ctx, img.ThumbnailKey, &thumb, "image/jpeg"); err != nil {
+		s.removeFiles(ctx, img.StorageKey)
+		return models.Image{}, err
+	}
+	if err := s.imageRepo.Create(ctx, owner, &img); err != nil {
+		s.removeFiles(ctx, img.StorageKey, img.ThumbnailKey)
+		return models.Image{}, err
+	}
+	return img, nil
+}
+
+func (s imageService) List(ctx context.Context, owner models.ImageOwner) ([]models.Image, error) {
+	if err := s.ensureOwner(ctx, owner); err != nil {
+		return nil, err
+	}
+	return s.imageRepo.ListByOwner(ctx, owner)
+}
+
+// Reorder задает порядок галереи и возвращает ее в новом порядке
+func (s imageService) Reorder(ctx context.Context, owner models.ImageOwner, ids []int64) ([]models.Image, error) {
+	seen := make(map[int64]struct{}, len(ids))
+	for _, id := range ids {
+		if _, dup := seen[id]; dup || id <= 0 {
+			return nil, erors.ErrInvalidInput
+		}
+		seen[id] = struct{}{}
+	}
+	if err := s.ensureOwner(ctx, owner); err != nil {
+		return nil, err
+	}
+	if err := s.imageRepo.Reorder(ctx, owner, ids); err != nil {
+		return nil, err
+	}
+	return s.imageRepo.ListByOwner(ctx, owner)
+}
+
+// SetPrimary выбирает обложку и возвращает обновленную галерею
+func (s imageService) SetPrimary(ctx context.Context, owner models.ImageOwner, imageID int64) ([]models.Image, error) {
+	if imageID <= 0 {
+		return nil, erors.ErrInvalidInput
+	}
+	if err := s.ensureOwner(ctx, owner); err != nil {
+		return nil, err
+	}
+	if err := s.imageRepo.SetPrimary(ctx, owner, imageID); err != nil {
+		return nil, err
+	}
+	return s.imageRepo.ListByOwner(ctx, owner)
+}
+
+// Delete удаляет запись и файлы; ошибка удаления файла только логируется — запись уже удалена
+func (s imageService) Delete(ctx context.Context, owner models.ImageOwner, imageID int64) error {
+	if imageID <= 0 {
+		return erors.ErrInvalidInput
+	}
+	if err := s.ensureOwner(ctx, owner); err != nil {
+		return err
+	}
+	img, err := s.imageRepo.Delete(ctx, owner, imageID)
+	if err != nil {
+		return err
+	}
+	s.removeFiles(ctx, img.StorageKey, img.ThumbnailKey)
+	return nil
+}
+
+func (s imageService) removeFiles(ctx context.Context, keys ...string) {
+	for _, key := range keys {
+		if err := s.storage.Delete(ctx, key); err != nil && !errors.Is(err, context.Canceled) {
+			s.logger.Warn("failed to delete stored file", zap.String("key", key), zap.Error(err))
+		}
+	}
+}
+
+// thumbnail уменьшает изображение так, чтобы длинная сторона была не больше maxSide.
+// Цвет пикселя превью — среднее по сетке до 4x4 точек исходной области; прозрачность
+// накладывается на белый фон, так как превью сохраняется в JPEG.
+func thumbnail(src image.Image, maxSide int) image.Image {
+	b := src.Bounds()
+	w, h := b.Dx(), b.Dy()
+	tw, th := w, h
+	if w > maxSide || h > maxSide {
+		if w >= h {
+			tw, th = maxSide, max(1, h*maxSide/w)
+		} else {
+			tw, th = max(1, w*maxSide/h), maxSide
+		}
+	}
+
+	dst := image.NewRGBA(image.Rect(0, 0, tw, th))
+	for y := 0; y < th; y++ {
+		y0, y1 := y*h/th, max((y+1)*h/th, y*h/th+1)
+		for x := 0; x < tw; x++ {
+			x0, x1 := x*w/tw, max((x+1)*w/tw, x*w/tw+1)
+			stepX, stepY := max(1, (x1-x0)/4), max(1, (y1-y0)/4)
+
+			var r, g, bl, n uint32
+			for sy := y0; sy < y1; sy += stepY {
+				for sx := x0; sx < x1; sx += stepX {
+					pr, pg, pb, pa := src.At(b.Min.X+sx, b.Min.Y+sy).RGBA()
+					// Цвета premultiplied: добавляем белый фон под прозрачной частью
+					r += pr + 0xffff - pa
+					g += pg + 0xffff - pa
+					bl += pb + 0xffff - pa
+					n++
+				}
+			}
+			dst.SetRGBA(x, y, color.RGBA{
+				R: uint8(r / n >> 8),
+				G: uint8(g / n >> 8),
+				B: uint8(bl / n >> 8),
+				A: 0xff,
+			})
+		}
+	}
+	return dst
+}
@@ -9,14 +9,21 @@ import (
 	"github.com/golang-jwt/jwt/v4"
 )
 
+// Типы токенов; старые токены без типа считаются access
+const (
+	TokenTypeAccess  = "access"
+	TokenTypeRefresh = "refresh"
+)
+
 type JWTService struct {
 	config config.Config
 }
 
 type Claims struct {
-	UserID int64  `json:"userid"`
-	Email  string `json:"email"`
-	Role   string `json:"role"`
+	UserID    int64  `json:"userid"`
+	Email     string `json:"email"`
+	Role      string `json:"role"`
+	TokenType string `json:"typ,omitempty"`
 	jwt.RegisteredClaims
 }
 
@@ -24,14 +31,23 @@ func NewJWTService(cfg config.Config) JWTService {
 	return JWTService{config: cfg}
 }
 
-func (j JWTService) GenerateTokenPair(user models.User) (string, string, error) {
+// RefreshTokenTTL время жизни refresh-токена
+func (j JWTService) RefreshTokenTTL() time.Duration {
+	return time.Duration(j.config.JWT.RefreshTokenTTL) * time.Second
+}
+
+// GenerateTokenPair выпускает пару токенов в рамках сессии sessionID;
+// jti access-токена равен sessionID, по нему middleware проверяет отзыв сессии.
+func (j JWTService) GenerateTokenPair(user models.User, sessionID string) (string, string, error) {
 	now := time.Now()
 
 	accessClaims := Claims{
-		UserID: user.ID,
-		Email:  user.Email,
-		Role:   user.Role,
+		UserID:    user.ID,
+		Email:     user.Email,
+		Role:      user.Role,
+		TokenType: TokenTypeAccess,
 		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        sessionID,
 			ExpiresAt: jwt.NewNumericDate(now.Add(time.Duration(j.config.JWT.AccessTokenTTL) * time.Second)),
 			IssuedAt:  jwt.NewNumericDate(now),
 		},
@@ -42,12 +58,19 @@ func (j JWTService) GenerateTokenPair(user models.User) (string, string, error)
 		return "", "", err
 	}
 
+	// Уникальный jti, чтобы два refresh-токена, выданных в одну секунду, имели разные хэши
+	jti, err := randomHex(16)
+	if err != nil {
+		return "", "", err
+	}
 	refreshClaims := Claims{
-		UserID: user.ID,
-		Email:  user.Email,
-		Role:   user.Role,
+		UserID:    user.ID,
+		Email:     user.Email,
+		Role:      user.Role,
+		TokenType: TokenTypeRefresh,
 		RegisteredClaims: jwt.RegisteredClaims{
-			ExpiresAt: jwt.NewNumericDate(now.Add(time.Duration(j.config.JWT.RefreshTokenTTL) * time.Second)),
+			ID:        jti,
+			ExpiresAt: jwt.NewNumericDate(now.Add(j.RefreshTokenTTL())),
 			IssuedAt:  jwt.NewNumericDate(now),
 		},
 	}
@@ -62,6 +85,9 @@ func (j JWTService) GenerateTokenPair(user models.User) (string, string, error)
 
 func (j JWTService) ValidateToken(tokenString string) (*Claims, error) {
 	token, err := jwt.ParseWithClaims(tokenString, &Claims{}, func(token *jwt.Token) (interface{}, error) {
+		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
+			return nil, errors.New("unexpected signing method")
+		}
 		return []byte(j.config.JWT.Secret), nil
 	})
 	if err != nil {
@@ -72,3 +98,15 @@ func (j JWTService) ValidateToken(tokenString string) (*Claims, error) {
 	}
 	return nil, errors.New("invalid token")
 }
+
+// ValidateRefreshToken проверяет подпись и срок действия и убеждается, что это refresh-токен
+func (j JWTService) ValidateRefreshToken(tokenString string) (*Claims, error) {
+	claims, err := j.ValidateToken(tokenString)
+	if err != nil {
+		return nil, err
+	}
+	if claims.TokenType != TokenTypeRefresh {
+		return nil, errors.New("not a refresh token")
+	}
+	return claims, nil
+}
@@ -0,0 +1,265 @@
+package services
+
+import (
+	"context"
+	"net"
+	"strings"
+	"time"
+
+	"backend/internal/config"
+	"backend/internal/erors"
+	"backend/internal/logger"
+	"backend/internal/models"
+	"backend/internal/repos"
+
+	"go.uber.org/zap"
+)
+
+const (
+	defaultSecurityEventPageLimit = 50
+	maxSecurityEventPageLimit     = 200
+)
+
+// LoginGuardServiceInterface защита входа по паролю от перебора: счетчики неудач по email
+// и по IP, растущая задержка между попытками и временная блокировка
+type LoginGuardServiceInterface interface {
+	// Check *erors.LoginLockedError, если вход для email или IP сейчас запрещен
+	Check(ctx context.Context, email, ip string) error
+	// Failure учитывает неудачную попытку; userID — владелец email, 0 если email не зарегистрирован
+	Failure(ctx context.Context, email, ip string, userID int64) error
+	// Success сбрасывает счетчик email; счетчик IP убывает только со временем,
+	// иначе перебор чужих паролей можно чередовать со входом в свой аккаунт
+	Success(ctx context.Context, email string) error
+
+	Unlock(ctx context.Context, adminID int64, dto models.UnlockLoginDTO) error
+	ListEvents(ctx context.Context, eventType string, page, limit int) (models.SecurityEventListResponse, error)
+}
+
+// loginLimit правила для одного вида ключей
+type loginLimit struct {
+	freeAttempts    int
+	baseDelay       time.Duration
+	maxDelay        time.Duration
+	lockoutAfter    int
+	lockoutDuration time.Duration
+	resetAfter      time.Duration
+}
+
+func newLoginLimit(cfg config.LoginLimitConfig, def loginLimit) loginLimit {
+	l := loginLimit{
+		freeAttempts:    cfg.FreeAttempts,
+		baseDelay:       time.Duration(cfg.BaseDelay) * time.Second,
+		maxDelay:        time.Duration(cfg.MaxDelay) * time.Second,
+		lockoutAfter:    cfg.LockoutAfter,
+		lockoutDuration: time.Duration(cfg.LockoutDuration) * time.Second,
+		resetAfter:      time.Duration(cfg.ResetAfter) * time.Second,
+	}
+	if l.freeAttempts <= 0 {
+		l.freeAttempts = def.freeAttempts
+	}
+	if l.baseDelay <= 0 {
+		l.baseDelay = def.baseDelay
+	}
+	if l.maxDelay <= 0 {
+		l.maxDelay = def.maxDelay
+	}
+	if l.lockoutAfter <= 0 {
+		l.lockoutAfter = def.lockoutAfter
+	}
+	if l.lockoutDuration <= 0 {
+		l.lockoutDuration = def.lockoutDuration
+	}
+	if l.resetAfter <= 0 {
+		l.resetAfter = def.resetAfter
+	}
+	return l
+}
+
+// delay на сколько запретить вход после failures неудач подряд; lockout — это блокировка
+func (l loginLimit) delay(failures int) (d time.Duration, lockout bool) {
+	if failures >= l.lockoutAfter {
+		return l.lockoutDuration, true
+	}
+	if failures <= l.freeAttempts {
+		return 0, false
+	}
+	d = l.baseDelay
+	for i := l.freeAttempts + 1; i < failures && d < l.maxDelay; i++ {
+		d *= 2
+	}
+	return min(d, l.maxDelay), false
+}
+
+var (
+	defaultAccountLoginLimit = loginLimit{
+		freeAttempts: 3, baseDelay: time.Second, maxDelay: 5 * time.Minute,
+		lockoutAfter: 10, lockoutDuration: 15 * time.Minute, resetAfter: time.Hour,
+	}
+	defaultIPLoginLimit = loginLimit{
+		freeAttempts: 10, baseDelay: time.Second, maxDelay: time.Minute,
+		lockoutAfter: 50, lockoutDuration: 15 * time.Minute, resetAfter: time.Hour,
+	}
+)
+
+type loginGuardService struct {
+	attempts repos.LoginAttemptRepoInterface
+	events   repos.SecurityEventRepoInterface
+	account  loginLimit
+	ip       loginLimit
+	logger   logger.Logger
+}
+
+func NewLoginGuardService(cfg config.SecurityConfig, attempts repos.LoginAttemptRepoInterface, events repos.SecurityEventRepoInterface, logger logger.Logger) LoginGuardServiceInterface {
+	return &loginGuardService{
+		attempts: attempts,
+		events:   events,
+		account:  newLoginLimit(cfg.Account, defaultAccountLoginLimit),
+		ip:       newLoginLimit(cfg.IP, defaultIPLoginLimit),
+		logger:   logger,
+	}
+}
+
+func accountKey(email string) string {
+	return "account:" + strings.ToLower(strings.TrimSpace(email))
+}
+
+func ipKey(ip string) string {
+	return "ip:" + ip
+}
+
+func (s *loginGuardService) Check(ctx context.Context, email, ip string) error {
+	now := time.Now()
+	keys := []string{accountKey(email)}
+	if ip != "" {
+		keys = append(keys, ipKey(ip))
+	}
+
+	var wait time.Duration
+	for _, key := range keys {
+		a, err := s.attempts.Get(ctx, key)
+		if err != nil {
+			return err
+		}
+		wait = max(wait, a.LockedUntil.Sub(now))
+	}
+	if wait > 0 {
+		return &erors.LoginLockedError{RetryAfter: wait}
+	}
+	return nil
+}
+
+func (s *loginGuardService) Failure(ctx context.Context, email, ip string, userID int64) error {
+	email = strings.TrimSpace(email)
+	event := models.SecurityEvent{Type: models.SecurityEventAccountLocked, Email: email, IP: ip}
+	if userID > 0 {
+		event.UserID = &userID
+	}
+	if err := s.registerFailure(ctx, accountKey(email), s.account, event); err != nil {
+		return err
+	}
+	if ip == "" {
+		return nil
+	}
+	event.Type = models.SecurityEventIPLocked
+	return s.registerFailure(ctx, ipKey(ip), s.ip, event)
+}
+
+// registerFailure учитывает неудачу по ключу и при необходимости запрещает вход;
+// каждая блокировка попадает в журнал
+func (s *loginGuardService) registerFailure(ctx context.Context, key string, limit loginLimit, event models.SecurityEvent) error {
+	now := time.Now()
+	failures, err := s.attempts.RegisterFailure(ctx, key, now, limit.resetAfter)
+	if err != nil {
+		return err
+	}
+	d, lockout := limit.delay(failures)
+	if d <= 0 {
+		return nil
+	}
+	until := now.Add(d)
+	if err := s.attempts.Lock(ctx, key, until); err != nil {
+		return err
+	}
+	if !lockout {
+		return nil
+	}
+
+	s.logger.Warn("login locked",
+		zap.String("type", event.Type),
+		zap.String("email", event.Email),
+		zap.String("ip", event.IP),
+		zap.Int("failures", failures),
+		zap.Time("locked_until", until),
+	)
+	event.Failures = failures
+	event.LockedUntil = &until
+	return s.events.Create(ctx, &event)
+}
+
+func (s *loginGuardService) Success(ctx context.Context, email string) error {
+	return s.attempts.Reset(ctx, accountKey(email))
+}
+
+// Unlock снимает задержку и блокировку для email и/или IP и сбрасывает их счетчики
+func (s *loginGuardService) Unlock(ctx context.Context, adminID int64, dto models.UnlockLoginDTO) error {
+	dto.Email = strings.TrimSpace(dto.Email)
+	dto.IP = strings.TrimSpace(dto.IP)
+	if dto.Email == "" && dto.IP == "" {
+		return erors.ErrInvalidInput
+	}
+	if dto.IP != "" {
+		parsed := net.ParseIP(dto.IP)
+		if parsed == nil {
+			return erors.ErrInvalidInput
+		}
+		// Тот же вид, что у gin.Context.ClientIP, иначе ключ не совпадет
+		dto.IP = parsed.String()
+	}
+
+	if dto.Email != "" {
+		if err := s.attempts.Reset(ctx, accountKey(dto.Email)); err != nil {
+			return err
+		}
+	}
+	if dto.IP != "" {
+		if err := s.attempts.Reset(ctx, ipKey(dto.IP)); err != nil {
+			return err
+		}
+	}
+
+	s.logger.Info("login unlocked", zap.Int64("admin_id", adminID), zap.String("email", dto.Email), zap.String("ip", dto.IP))
+	return s.events.Create(ctx, &models.SecurityEvent{
+		Type:    models.SecurityEventLoginUnlocked,
+		Email:   dto.Email,
+		IP:      dto.IP,
+		ActorID: &adminID,
+	})
+}
+
+// ListEvents журнал безопасности от новых событий к старым
+func (s *loginGuardService) ListEvents(ctx context.Context, eventType string, page, limit int) (models.SecurityEventListResponse, error) {
+	if page == 0 {
+		page = 1
+	}
+	if limit == 0 {
+		limit = defaultSecurityEventPageLimit
+	}
+	if (eventType != "" && !models.IsValidSecurityEventType(eventType)) ||
+		page < 1 || limit < 1 || limit > maxSecurityEventPageLimit {
+		return models.SecurityEventListResponse{}, erors.ErrInvalidInput
+	}
+
+	events, total, err := s.events.List(ctx, eventType, limit, (page-1)*limit)
+	if err != nil {
+		return models.SecurityEventListResponse{}, err
+	}
+	return models.SecurityEventListResponse{
+		Data: events,
+		Pagination: models.Pagination{
+			Total:      total,
+			Page:       page,
+			Limit:      limit,
+			TotalPages: (total + limit - 1) / limit,
+		},
+	}, nil
+}
@@ -0,0 +1,379 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"backend/internal/config"
+	"backend/internal/erors"
+	"backend/internal/logger"
+	"backend/internal/models"
+	"backend/internal/repos"
+
+	"go.uber.org/zap"
+)
+
+const (
+	defaultOAuthStateTTL = 10 * time.Minute
+
+	// maxOAuthResponseBytes ограничение на размер ответов провайдера
+	maxOAuthResponseBytes = 1 << 20
+)
+
+type OAuthServiceInterface interface {
+	Providers() []string
+	Start(ctx context.Context, provider string, currentUserID int64) (models.OAuthStartResponse, error)
+	Callback(ctx context.Context, provider string, currentUserID int64, dto models.OAuthCallbackDTO) (models.User, error)
+	ListIdentities(ctx context.Context, userID int64) ([]models.UserIdentity, error)
+}
+
+type oauthService struct {
+	cfg          config.OAuthConfig
+	identityRepo repos.IdentityRepoInterface
+	authRepo     repos.AuthRepoInterface
+	client       *http.Client
+	logger       logger.Logger
+}
+
+func NewOAuthService(cfg config.OAuthConfig, identityRepo repos.IdentityRepoInterface, authRepo repos.AuthRepoInterface, client *http.Client, logger logger.Logger) OAuthServiceInterface {
+	if client == nil {
+		client = &http.Client{Timeout: 10 * time.Second}
+	}
+	return &oauthService{
+		cfg:          cfg,
+		identityRepo: identityRepo,
+		authRepo:     authRepo,
+		client:       client,
+		logger:       logger,
+	}
+}
+
+// provider настройки включенного провайдера; выключенный или неизвестный — ErrOAuthProviderDisabled
+func (s *oauthService) provider(name string) (config.OAuthProviderConfig, error) {
+	p, ok := s.cfg.Providers[name]
+	if !ok || p.ClientID == "" || p.ClientSecret == "" || p.AuthURL == "" || p.TokenURL == "" || p.UserInfoURL == "" {
+		return config.OAuthProviderConfig{}, erors.ErrOAuthProviderDisabled
+	}
+	return p, nil
+}
+
+// Providers имена включенных провайдеров по алфавиту
+func (s *oauthService) Providers() []string {
+	names := []string{}
+	for name := range s.cfg.Providers {
+		if _, err := s.provider(name); err == nil {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+func randomURLToken(n int) (string, error) {
+	b := make([]byte, n)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// Start готовит вход: одноразовый state и PKCE code_verifier сохраняются, в адрес
+// авторизации уходят state и code_challenge (S256). currentUserID > 0 — привязка к текущему аккаунту.
+func (s *oauthService) Start(ctx context.Context, providerName string, currentUserID int64) (models.OAuthStartResponse, error) {
+	p, err := s.provider(providerName)
+	if err != nil {
+		return models.OAuthStartResponse{}, err
+	}
+
+	state, err := randomURLToken(32)
+	if err != nil {
+		return models.OAuthStartResponse{}, fmt.Errorf("oauth start: state: %w", err)
+	}
+	verifier, err := randomURLToken(32)
+	if err != nil {
+		return models.OAuthStartResponse{}, fmt.Errorf("oauth start: verifier: %w", err)
+	}
+	challenge := sha256.Sum256([]byte(verifier))
+
+	ttl := defaultOAuthStateTTL
+	if s.cfg.StateTTL > 0 {
+		ttl = time.Duration(s.cfg.StateTTL) * time.Second
+	}
+	err = s.identityRepo.SaveState(ctx, hashToken(state), models.OAuthState{
+		Provider:     providerName,
+		CodeVerifier: verifier,
+		UserID:       currentUserID,
+		ExpiresAt:    time.Now().Add(ttl),
+	})
+	if err != nil {
+		return models.OAuthStartResponse{}, err
+	}
+
+	authURL, err := url.Parse(p.AuthURL)
+	if err != nil {
+		return models.OAuthStartResponse{}, fmt.Errorf("oauth start: auth url: %w", err)
+	}
+	q := authURL.Query()
+	q.Set("response_type", "code")
+	q.Set("client_id", p.ClientID)
+	q.Set("redirect_uri", p.RedirectURL)
+	q.Set("state", state)
+	q.Set("code_challenge", base64.RawURLEncoding.EncodeToString(challenge[:]))
+	q.Set("code_challenge_method", "S256")
+	if len(p.Scopes) > 0 {
+		q.Set("scope", strings.Join(p.Scopes, " "))
+	}
+	authURL.RawQuery = q.Encode()
+
+	return models.OAuthStartResponse{AuthorizationURL: authURL.String(), State: state}, nil
+}
+
+// Callback завершает вход: проверяет state, обменивает code на токен, читает профиль и находит
+// пользователя. Порядок: уже привязанный аккаунт; привязка к пользователю, начавшему вход;
+// существующий пользователь с тем же подтвержденным email; новый пользователь.
+// Привязку завершает только тот же пользователь (currentUserID), что ее начал: иначе чужой
+// аккаунт провайдера можно было бы привязать к себе, подсунув жертве свой адрес авторизации.
+func (s *oauthService) Callback(ctx context.Context, providerName string, currentUserID int64, dto models.OAuthCallbackDTO) (models.User, error) {
+	p, err := s.provider(providerName)
+	if err != nil {
+		return models.User{}, err
+	}
+	state, err := s.identityRepo.ConsumeState(ctx, hashToken(dto.State))
+	if err != nil {
+		return models.User{}, err
+	}
+	if state.Provider != providerName || time.Now().After(state.ExpiresAt) {
+		return models.User{}, erors.ErrOAuthStateInvalid
+	}
+	if state.UserID > 0 && state.UserID != currentUserID {
+		s.logger.Warn("oauth link completed by another user",
+			zap.String("provider", providerName), zap.Int64("user_id", state.UserID), zap.Int64("current_user_id", currentUserID))
+		return models.User{}, erors.ErrOAuthStateInvalid
+	}
+
+	accessToken, err := s.exchangeCode(ctx, p, dto, state.CodeVerifier)
+	if err != nil {
+		s.logger.Warn("oauth code exchange failed", zap.String("provider", providerName), zap.Error(err))
+		return models.User{}, erors.ErrOAuthExchange
+	}
+	profile, err := s.fetchProfile(ctx, p, accessToken)
+	if err != nil {
+		s.logger.Warn("oauth userinfo failed", zap.String("provider", providerName), zap.Error(err))
+		return models.User{}, erors.ErrOAuthExchange
+	}
+
+	userID, err := s.identityRepo.FindUserID(ctx, providerName, profile.Subject)
+	switch {
+	case err == nil:
+		if state.UserID > 0 && state.UserID != userID {
+			return models.User{}, erors.ErrConflict
+		}
+		return s.authRepo.GetUserByID(ctx, userID)
+	case !errors.Is(err, erors.ErrUserNotFound):
+		return models.User{}, err
+	}
+
+	if state.UserID > 0 {
+		if err := s.identityRepo.Link(ctx, state.UserID, providerName, profile); err != nil {
+			return models.User{}, err
+		}
+		return s.authRepo.GetUserByID(ctx, state.UserID)
+	}
+
+	if profile.EmailVerified && profile.Email != "" {
+		existing, err := s.authRepo.GetUserByEmail(ctx, profile.Email)
+		switch {
+		case err == nil:
+			// Неподтвержденный адрес мог зарегистрировать кто угодно; привязав к такому
+			// аккаунту вход владельца адреса, мы отдали бы этот вход регистратору
+			if !existing.EmailVerified {
+				s.logger.Warn("oauth email matches unverified account", zap.String("provider", providerName), zap.Int64("user_id", existing.ID))
+				return models.User{}, erors.ErrOAuthEmailUnverified
+			}
+			if err := s.identityRepo.Link(ctx, existing.ID, providerName, profile); err != nil {
+				return models.User{}, err
+			}
+			s.logger.Info("oauth identity linked by email", zap.String("provider", providerName), zap.Int64("user_id", existing.ID))
+			return s.authRepo.GetUserByID(ctx, existing.ID)
+		case !errors.Is(err, erors.ErrInvalidCredentials):
+			return models.User{}, err
+		}
+	}
+
+	return s.createUser(ctx, providerName, profile)
+}
+
+func (s *oauthService) createUser(ctx context.Context, providerName string, profile models.OAuthProfile) (models.User, error) {
+	name := strings.TrimSpace(profile.Name)
+	if name == "" {
+		name = fmt.Sprintf("%s %s", providerName, profile.Subject)
+	}
+	// Неподтвержденный адрес не закрепляем за учетной записью: иначе его владелец
+	// не сможет зарегистрироваться. Вместо него — адрес в зарезервированной зоне .invalid
+	email := profile.Email
+	if !profile.EmailVerified || email == "" {
+		email = fmt.Sprintf("%s_%s@oauth.invalid", providerName, url.PathEscape(profile.Subject))
+	}
+
+	userID, err := s.identityRepo.CreateUserWithIdentity(ctx, models.CreateUserDTO{
+		Name:     name,
+		Email:    email,
+		Password: unusablePasswordHash,
+		Role:     models.RoleUser,
+	}, providerName, profile)
+	switch {
+	case errors.Is(err, erors.ErrConflict):
+		// Параллельный вход с тем же аккаунтом провайдера успел создать пользователя
+		if userID, err = s.identityRepo.FindUserID(ctx, providerName, profile.Subject); err != nil {
+			return models.User{}, err
+		}
+	case err != nil:
+		return models.User{}, err
+	default:
+		s.logger.Info("user registered via oauth", zap.String("provider", providerName), zap.Int64("user_id", userID))
+	}
+	return s.authRepo.GetUserByID(ctx, userID)
+}
+
+// exchangeCode обменивает authorization code на access token (RFC 6749 §4.1.3 + PKCE)
+func (s *oauthService) exchangeCode(ctx context.Context, p config.OAuthProviderConfig, dto models.OAuthCallbackDTO, verifier string) (string, error) {
+	form := url.Values{
+		"grant_type":    {"authorization_code"},
+		"code":          {dto.Code},
+		"redirect_uri":  {p.RedirectURL},
+		"client_id":     {p.ClientID},
+		"client_secret": {p.ClientSecret},
+		"code_verifier": {verifier},
+	}
+	if dto.DeviceID != "" {
+		form.Set("device_id", dto.DeviceID)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, p.TokenURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+
+	var token struct {
+		AccessToken string `json:"access_token"`
+	}
+	if err := s.doJSON(req, &token); err != nil {
+		return "", fmt.Errorf("token: %w", err)
+	}
+	if token.AccessToken == "" {
+		return "", errors.New("token: empty access_token")
+	}
+	return token.AccessToken, nil
+}
+
+// fetchProfile читает userinfo и достает поля профиля по путям из настроек провайдера
+func (s *oauthService) fetchProfile(ctx context.Context, p config.OAuthProviderConfig, accessToken string) (models.OAuthProfile, error) {
+	var req *http.Request
+	var err error
+	switch p.UserInfoAuth {
+	case "form":
+		form := url.Values{"access_token": {accessToken}, "client_id": {p.ClientID}}
+		req, err = http.NewRequestWithContext(ctx, http.MethodPost, p.UserInfoURL, strings.NewReader(form.Encode()))
+		if err == nil {
+			req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		}
+	case "oauth":
+		req, err = http.NewRequestWithContext(ctx, http.MethodGet, p.UserInfoURL, nil)
+		if err == nil {
+			req.Header.Set("Authorization", "OAuth "+accessToken)
+		}
+	default:
+		req, err = http.NewRequestWithContext(ctx, http.MethodGet, p.UserInfoURL, nil)
+		if err == nil {
+			req.Header.Set("Authorization", "Bearer "+accessToken)
+		}
+	}
+	if err != nil {
+		return models.OAuthProfile{}, err
+	}
+	req.Header.Set("Accept", "application/json")
+
+	var info map[string]any
+	if err := s.doJSON(req, &info); err != nil {
+		return models.OAuthProfile{}, fmt.Errorf("userinfo: %w", err)
+	}
+
+	profile := models.OAuthProfile{
+		Subject: jsonPathString(info, orDefault(p.SubjectField, "sub")),
+		Email:   strings.TrimSpace(jsonPathString(info, orDefault(p.EmailField, "email"))),
+		Name:    jsonPathString(info, orDefault(p.NameField, "name")),
+	}
+	if profile.Subject == "" {
+		return models.OAuthProfile{}, errors.New("userinfo: empty subject")
+	}
+	verified, _ := strconv.ParseBool(jsonPathString(info, orDefault(p.EmailVerifiedField, "email_verified")))
+	profile.EmailVerified = profile.Email != "" && (p.TrustEmail || verified)
+	return profile, nil
+}
+
+func (s *oauthService) doJSON(req *http.Request, dst any) error {
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxOAuthResponseBytes))
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected status %d", resp.StatusCode)
+	}
+	dec := json.NewDecoder(bytes.NewReader(body))
+	dec.UseNumber()
+	return dec.Decode(dst)
+}
+
+func orDefault(v, def string) string {
+	if v == "" {
+		return def
+	}
+	return v
+}
+
+// jsonPathString значение по пути через точку (user.email) в виде строки; числа и bool
+// приводятся к строке, отсутствующее поле — пустая строка
+func jsonPathString(data map[string]any, path string) string {
+	var cur any = data
+	for _, key := range strings.Split(path, ".") {
+		obj, ok := cur.(map[string]any)
+		if !ok {
+			return ""
+		}
+		cur = obj[key]
+	}
+	switch v := cur.(type) {
+	case string:
+		return v
+	case json.Number:
+		return v.String()
+	case bool:
+		return strconv.FormatBool(v)
+	default:
+		return ""
+	}
+}
+
+func (s *oauthService) ListIdentities(ctx context.Context, userID int64) ([]models.UserIdentity, error) {
+	return s.identityRepo.ListByUserID(ctx, userID)
+}
@@ -0,0 +1,278 @@
+package services
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+	"testing"
+
+	"backend/internal/config"
+	"backend/internal/erors"
+	"backend/internal/models"
+
+	"go.uber.org/zap"
+)
+
+// mockOIDC провайдер с token и userinfo; запоминает присланный code_verifier
+type mockOIDC struct {
+	*httptest.Server
+
+	mu       sync.Mutex
+	verifier string
+	userinfo map[string]any
+}
+
+func newMockOIDC(t *testing.T, userinfo map[string]any) *mockOIDC {
+	t.Helper()
+	m := &mockOIDC{userinfo: userinfo}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/token", func(w http.ResponseWriter, r *http.Request) {
+		if err := r.ParseForm(); err != nil || r.PostForm.Get("grant_type") != "authorization_code" ||
+			r.PostForm.Get("code") != "good-code" || r.PostForm.Get("client_secret") != "secret" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		m.mu.Lock()
+		m.verifier = r.PostForm.Get("code_verifier")
+		m.mu.Unlock()
+		json.NewEncoder(w).Encode(map[string]string{"access_token": "access", "token_type": "Bearer"})
+	})
+	mux.HandleFunc("/userinfo", func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer access" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		json.NewEncoder(w).Encode(m.userinfo)
+	})
+	m.Server = httptest.NewServer(mux)
+	t.Cleanup(m.Close)
+	return m
+}
+
+func (m *mockOIDC) receivedVerifier() string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.verifier
+}
+
+type fakeIdentityRepo struct {
+	states     map[string]models.OAuthState
+	identities map[string]int64 // provider:subject → user_id
+	nextUserID int64
+}
+
+func newFakeIdentityRepo() *fakeIdentityRepo {
+	return &fakeIdentityRepo{states: map[string]models.OAuthState{}, identities: map[string]int64{}, nextUserID: 100}
+}
+
+func (r *fakeIdentityRepo) SaveState(_ context.Context, stateHash string, state models.OAuthState) error {
+	r.states[stateHash] = state
+	return nil
+}
+
+func (r *fakeIdentityRepo) ConsumeState(_ context.Context, stateHash string) (models.OAuthState, error) {
+	s, ok := r.states[stateHash]
+	if !ok {
+		return models.OAuthState{}, erors.ErrOAuthStateInvalid
+	}
+	delete(r.states, stateHash)
+	return s, nil
+}
+
+func (r *fakeIdentityRepo) FindUserID(_ context.Context, provider, subject string) (int64, error) {
+	if id, ok := r.identities[provider+":"+subject]; ok {
+		return id, nil
+	}
+	return 0, erors.ErrUserNotFound
+}
+
+func (r *fakeIdentityRepo) Link(_ context.Context, userID int64, provider string, profile models.OAuthProfile) error {
+	key := provider + ":" + profile.Subject
+	if _, ok := r.identities[key]; ok {
+		return erors.ErrConflict
+	}
+	r.identities[key] = userID
+	return nil
+}
+
+func (r *fakeIdentityRepo) CreateUserWithIdentity(ctx context.Context, _ models.CreateUserDTO, provider string, profile models.OAuthProfile) (int64, error) {
+	r.nextUserID++
+	if err := r.Link(ctx, r.nextUserID, provider, profile); err != nil {
+		return 0, err
+	}
+	return r.nextUserID, nil
+}
+
+func (r *fakeIdentityRepo) ListByUserID(context.Context, int64) ([]models.UserIdentity, error) {
+	return nil, nil
+}
+
+type fakeAuthRepo struct {
+	users []models.User
+}
+
+func (r *fakeAuthRepo) CreateUser(context.Context, models.CreateUserDTO) (int64, error) {
+	return 0, errors.New("not implemented")
+}
+
+func (r *fakeAuthRepo) GetUserByEmail(_ context.Context, email string) (models.User, error) {
+	for _, u := range r.users {
+		if u.Email == email {
+			return u, nil
+		}
+	}
+	return models.User{}, erors.ErrInvalidCredentials
+}
+
+func (r *fakeAuthRepo) GetUserByID(_ context.Context, userID int64) (models.User, error) {
+	for _, u := range r.users {
+		if u.ID == userID {
+			return u, nil
+		}
+	}
+	return models.User{ID: userID}, nil
+}
+
+type oauthFixture struct {
+	svc        OAuthServiceInterface
+	provider   *mockOIDC
+	identities *fakeIdentityRepo
+}
+
+func newOAuthFixture(t *testing.T, userinfo map[string]any, users ...models.User) oauthFixture {
+	t.Helper()
+	provider := newMockOIDC(t, userinfo)
+	identities := newFakeIdentityRepo()
+	cfg := config.OAuthConfig{Providers: map[string]config.OAuthProviderConfig{
+		"mock": {
+			ClientID:     "client",
+			ClientSecret: "secret",
+			AuthURL:      provider.URL + "/authorize",
+			TokenURL:     provider.URL + "/token",
+			UserInfoURL:  provider.URL + "/userinfo",
+			RedirectURL:  "http://localhost/oauth/mock",
+		},
+	}}
+	svc := NewOAuthService(cfg, identities, &fakeAuthRepo{users: users}, provider.Client(), zap.NewNop())
+	return oauthFixture{svc: svc, provider: provider, identities: identities}
+}
+
+func (f oauthFixture) start(t *testing.T, currentUserID int64) models.OAuthStartResponse {
+	t.Helper()
+	res, err := f.svc.Start(context.Background(), "mock", currentUserID)
+	if err != nil {
+		t.Fatalf("Start: %v", err)
+	}
+	return res
+}
+
+func (f oauthFixture) callback(state string, currentUserID int64) (models.User, error) {
+	return f.svc.Callback(context.Background(), "mock", currentUserID, models.OAuthCallbackDTO{Code: "good-code", State: state})
+}
+
+func verifiedProfile(email string) map[string]any {
+	return map[string]any{"sub": "subject-1", "email": email, "email_verified": true, "name": "Alice"}
+}
+
+func TestOAuthCallbackStateIsSingleUse(t *testing.T) {
+	f := newOAuthFixture(t, verifiedProfile("alice@example.com"))
+	res := f.start(t, 0)
+
+	if _, err := f.callback(res.State, 0); err != nil {
+		t.Fatalf("first callback: %v", err)
+	}
+	if _, err := f.callback(res.State, 0); !errors.Is(err, erors.ErrOAuthStateInvalid) {
+		t.Fatalf("second callback: got %v, want ErrOAuthStateInvalid", err)
+	}
+}
+
+func TestOAuthCallbackSendsPKCEVerifier(t *testing.T) {
+	f := newOAuthFixture(t, verifiedProfile("alice@example.com"))
+	res := f.start(t, 0)
+
+	authURL, err := url.Parse(res.AuthorizationURL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	q := authURL.Query()
+	if q.Get("code_challenge_method") != "S256" || q.Get("state") != res.State {
+		t.Fatalf("unexpected authorization url %s", res.AuthorizationURL)
+	}
+	if _, err := f.callback(res.State, 0); err != nil {
+		t.Fatalf("callback: %v", err)
+	}
+
+	verifier := f.provider.receivedVerifier()
+	if verifier == "" {
+		t.Fatal("code_verifier was not sent to the token endpoint")
+	}
+	sum := sha256.Sum256([]byte(verifier))
+	if got := base64.RawURLEncoding.EncodeToString(sum[:]); got != q.Get("code_challenge") {
+		t.Fatalf("code_verifier does not match code_challenge: %s != %s", got, q.Get("code_challenge"))
+	}
+}
+
+func TestOAuthCallbackLinksByVerifiedEmail(t *testing.T) {
+	existing := models.User{ID: 7, Email: "alice@example.com", EmailVerified: true}
+	f := newOAuthFixture(t, verifiedProfile(existing.Email), existing)
+
+	user, err := f.callback(f.start(t, 0).State, 0)
+	if err != nil {
+		t.Fatalf("callback: %v", err)
+	}
+	if user.ID != existing.ID {
+		t.Fatalf("got user %d, want existing user %d", user.ID, existing.ID)
+	}
+	if f.identities.identities["mock:subject-1"] != existing.ID {
+		t.Fatal("identity was not linked to the existing user")
+	}
+}
+
+func TestOAuthCallbackRefusesUnverifiedEmail(t *testing.T) {
+	existing := models.User{ID: 7, Email: "alice@example.com"}
+	f := newOAuthFixture(t, verifiedProfile(existing.Email), existing)
+
+	if _, err := f.callback(f.start(t, 0).State, 0); !errors.Is(err, erors.ErrOAuthEmailUnverified) {
+		t.Fatalf("got %v, want ErrOAuthEmailUnverified", err)
+	}
+	if _, ok := f.identities.identities["mock:subject-1"]; ok {
+		t.Fatal("identity must not be linked to an account with unverified email")
+	}
+}
+
+func TestOAuthCallbackIdentityLinkedToAnotherUser(t *testing.T) {
+	f := newOAuthFixture(t, verifiedProfile("alice@example.com"))
+	f.identities.identities["mock:subject-1"] = 2
+
+	if _, err := f.callback(f.start(t, 1).State, 1); !errors.Is(err, erors.ErrConflict) {
+		t.Fatalf("got %v, want ErrConflict", err)
+	}
+}
+
+func TestOAuthCallbackLinkRequiresInitiator(t *testing.T) {
+	for _, currentUserID := range []int64{0, 2} {
+		f := newOAuthFixture(t, verifiedProfile("victim@example.com"))
+
+		if _, err := f.callback(f.start(t, 1).State, currentUserID); !errors.Is(err, erors.ErrOAuthStateInvalid) {
+			t.Fatalf("current user %d: got %v, want ErrOAuthStateInvalid", currentUserID, err)
+		}
+		if _, ok := f.identities.identities["mock:subject-1"]; ok {
+			t.Fatalf("current user %d: identity must not be linked", currentUserID)
+		}
+	}
+
+	f := newOAuthFixture(t, verifiedProfile("alice@example.com"))
+	user, err := f.callback(f.start(t, 1).State, 1)
+	if err != nil {
+		t.Fatalf("initiator callback: %v", err)
+	}
+	if user.ID != 1 || f.identities.identities["mock:subject-1"] != 1 {
+		t.Fatal("identity was not linked to the initiator")
+	}
+}
@@ -0,0 +1,215 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+
+	"backend/internal/erors"
+	"backend/internal/models"
+	"backend/internal/repos"
+)
+
+const (
+	defaultRecommendationLimit = 10
+	maxRecommendationLimit     = 50
+
+	// Сигналы старше этого не учитываются вовсе
+	maxSignalAgeDays = 730
+	// Через столько дней вклад сигнала уменьшается вдвое
+	signalHalfLifeDays = 90.0
+
+	maxRecommendationFriends = 3
+)
+
+// signalWeights базовый вес действия друга: высокая оценка говорит больше, чем избранное
+var signalWeights = map[string]float64{
+	models.SignalReview:   3,
+	models.SignalVisit:    2,
+	models.SignalFavorite: 1,
+}
+
+// signalReasons формулировки объяснения в порядке приоритета при равном числе друзей
+var signalReasons = []struct {
+	kind, verb string
+}{
+	{models.SignalReview, "rated this highly"},
+	{models.SignalVisit, "stayed here"},
+	{models.SignalFavorite, "liked this"},
+}
+
+type RecommendationServiceInterface interface {
+	Recommend(ctx context.Context, userID int64, recType string, limit int) ([]models.Recommendation, error)
+}
+
+type recommendationService struct {
+	repo repos.RecommendationRepoInterface
+}
+
+func NewRecommendationService(repo repos.RecommendationRepoInterface) RecommendationServiceInterface {
+	return recommendationService{repo: repo}
+}
+
+// recCandidate накопитель оценки одной комнаты или отеля
+type recCandidate struct {
+	rec models.Recommendation
+
+	// Вклад пары (друг, вид действия): несколько комнат одного отеля, отмеченных
+	// одним другом, не должны многократно усиливать отель
+	contrib map[int64]map[string]float64
+	names   map[int64]string
+}
+
+func newRecCandidate(rec models.Recommendation) *recCandidate {
+	return &recCandidate{rec: rec, contrib: map[int64]map[string]float64{}, names: map[int64]string{}}
+}
+
+func (c *recCandidate) add(s models.FriendSignal, weight float64) {
+	kinds, ok := c.contrib[s.FriendID]
+	if !ok {
+		kinds = map[string]float64{}
+		c.contrib[s.FriendID] = kinds
+		c.names[s.FriendID] = s.FriendName
+	}
+	kinds[s.Kind] = math.Max(kinds[s.Kind], weight)
+}
+
+// signalWeight вклад сигнала с учетом близости друга (общие друзья) и давности действия
+func signalWeight(s models.FriendSignal) float64 {
+	closeness := 1 + math.Log1p(float64(s.MutualFriends))
+	recency := math.Pow(0.5, math.Max(s.AgeDays, 0)/signalHalfLifeDays)
+	return signalWeights[s.Kind] * closeness * recency
+}
+
+// finish считает итоговую оценку, список друзей и объяснение
+func (c *recCandidate) finish() models.Recommendation {
+	rec := c.rec
+	perFriend := make(map[int64]float64, len(c.contrib))
+	kindFriends := map[string]int{}
+	for friendID, kinds := range c.contrib {
+		for kind, w := range kinds {
+			perFriend[friendID] += w
+			kindFriends[kind]++
+		}
+		rec.Score += perFriend[friendID]
+	}
+	rec.Score = math.Round(rec.Score*100) / 100
+
+	ids := make([]int64, 0, len(perFriend))
+	for id := range perFriend {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool {
+		if perFriend[ids[i]] != perFriend[ids[j]] {
+			return perFriend[ids[i]] > perFriend[ids[j]]
+		}
+		return ids[i] < ids[j]
+	})
+	rec.FriendCount = len(ids)
+	rec.Friends = []string{}
+	for _, id := range ids {
+		if len(rec.Friends) == maxRecommendationFriends {
+			break
+		}
+		rec.Friends = append(rec.Friends, c.names[id])
+	}
+
+	best := signalReasons[0]
+	for _, r := range signalReasons[1:] {
+		if kindFriends[r.kind] > kindFriends[best.kind] {
+			best = r
+		}
+	}
+	if n := kindFriends[best.kind]; n == 1 {
+		// Единственного друга с таким действием называем по имени
+		for _, id := range ids {
+			if _, ok := c.contrib[id][best.kind]; ok {
+				rec.Reason = fmt.Sprintf("%s %s", c.names[id], best.verb)
+				break
+			}
+		}
+	} else {
+		rec.Reason = fmt.Sprintf("%d friends %s", n, best.verb)
+	}
+	return rec
+}
+
+// Recommend комнаты и отели, которыми интересовались друзья пользователя.
+// recType: room, hotel или пусто — оба вида в общей выдаче по убыванию оценки.
+func (s recommendationService) Recommend(ctx context.Context, userID int64, recType string, limit int) ([]models.Recommendation, error) {
+	if limit == 0 {
+		limit = defaultRecommendationLimit
+	}
+	switch recType {
+	case "", models.RecommendationTypeRoom, models.RecommendationTypeHotel:
+	default:
+		return nil, erors.ErrInvalidInput
+	}
+	if userID <= 0 || limit < 1 || limit > maxRecommendationLimit {
+		return nil, erors.ErrInvalidInput
+	}
+
+	signals, err := s.repo.ListFriendSignals(ctx, userID, maxSignalAgeDays)
+	if err != nil {
+		return nil, err
+	}
+
+	rooms := map[int64]*recCandidate{}
+	hotels := map[int64]*recCandidate{}
+	for _, sig := range signals {
+		w := signalWeight(sig)
+		if recType != models.RecommendationTypeHotel {
+			c, ok := rooms[sig.RoomID]
+			if !ok {
+				c = newRecCandidate(models.Recommendation{
+					Type:          models.RecommendationTypeRoom,
+					ID:            sig.RoomID,
+					HotelID:       sig.HotelID,
+					HotelName:     sig.HotelName,
+					City:          sig.City,
+					Price:         sig.RoomPrice,
+					AverageRating: sig.RoomAverageRating,
+				})
+				rooms[sig.RoomID] = c
+			}
+			c.add(sig, w)
+		}
+		if recType != models.RecommendationTypeRoom && !sig.HotelKnown {
+			c, ok := hotels[sig.HotelID]
+			if !ok {
+				c = newRecCandidate(models.Recommendation{
+					Type:          models.RecommendationTypeHotel,
+					ID:            sig.HotelID,
+					HotelID:       sig.HotelID,
+					HotelName:     sig.HotelName,
+					City:          sig.City,
+					AverageRating: sig.HotelAverageRating,
+				})
+				hotels[sig.HotelID] = c
+			}
+			c.add(sig, w)
+		}
+	}
+
+	res := make([]models.Recommendation, 0, len(rooms)+len(hotels))
+	for _, c := range rooms {
+		res = append(res, c.finish())
+	}
+	for _, c := range hotels {
+		res = append(res, c.finish())
+	}
+	sort.Slice(res, func(i, j int) bool {
+		if res[i].Score != res[j].Score {
+			return res[i].Score > res[j].Score
+		}
+		if res[i].Type != res[j].Type {
+			return res[i].Type == models.RecommendationTypeHotel
+		}
+		return res[i].ID < res[j].ID
+	})
+	if len(res) > limit {
+		res = res[:limit]
+	}
+	return res, nil
+}
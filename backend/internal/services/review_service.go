@@ -1,30 +1,206 @@
 package services
 
 import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+	"unicode/utf8"
+
 	"backend/internal/erors"
 	"backend/internal/models"
 	"backend/internal/repos"
-	"context"
 )
 
-type ReviewService struct {
-    repo *repos.ReviewRepo
+const (
+	defaultReviewPageLimit = 20
+	maxReviewPageLimit     = 100
+	maxReviewLength        = 5000
+	maxRejectReasonLength  = 500
+	maxReplyLength         = 2000
+
+	maxReportReasonLength = 500
+
+	// defaultReviewEditWindow сколько автор может править отзыв, если в конфиге не задано иное
+	defaultReviewEditWindow = 48 * time.Hour
+
+	// defaultReportThreshold после стольких жалоб отзыв возвращается на модерацию
+	defaultReportThreshold = 3
+)
+
+type ReviewServiceInterface interface {
+	AddReview(ctx context.Context, review *models.Review) error
+	UpdateReview(ctx context.Context, reviewID, userID int64, dto models.UpdateReviewDTO) (models.Review, error)
+	ListMyReviews(ctx context.Context, userID int64) ([]models.Review, error)
+	ListByUserID(ctx context.Context, userID, viewerID int64) ([]models.Review, error)
+	ListByRoomID(ctx context.Context, roomID, viewerID int64, sort string) ([]models.Review, error)
+	ListForModeration(ctx context.Context, status string, page, limit int) (models.ReviewListResponse, error)
+	Approve(ctx context.Context, reviewID, moderatorID int64) (models.Review, error)
+	Reject(ctx context.Context, reviewID, moderatorID int64, reason string) (models.Review, error)
+	DeleteByID(ctx context.Context, reviewID int64) error
+	MarkHelpful(ctx context.Context, reviewID, userID int64) (models.Review, error)
+	Report(ctx context.Context, reviewID, userID int64, reason string) (models.Review, error)
+
+	// Ответы администрации отеля: владелец отеля или администратор платформы
+	CreateReply(ctx context.Context, reviewID, userID int64, role, body string) (models.ReviewReply, error)
+	UpdateReply(ctx context.Context, reviewID, userID int64, role, body string) (models.ReviewReply, error)
+	DeleteReply(ctx context.Context, reviewID, userID int64, role string) error
+}
+
+type reviewService struct {
+	repo       repos.ReviewRepoInterface
+	roomRepo   repos.RoomRepoInterface
+	hotelRepo  repos.HotelRepoInterface
+	accounts   AccountServiceInterface
+	editWindow time.Duration
+
+	reportThreshold int
 }
 
-func NewReviewService(repo *repos.ReviewRepo) *ReviewService {
-    return &ReviewService{repo: repo}
+func NewReviewService(repo repos.ReviewRepoInterface, roomRepo repos.RoomRepoInterface, hotelRepo repos.HotelRepoInterface, accounts AccountServiceInterface, editWindow time.Duration, reportThreshold int) ReviewServiceInterface {
+	if editWindow <= 0 {
+		editWindow = defaultReviewEditWindow
+	}
+	if reportThreshold <= 0 {
+		reportThreshold = defaultReportThreshold
+	}
+	return reviewService{
+		repo:            repo,
+		roomRepo:        roomRepo,
+		hotelRepo:       hotelRepo,
+		accounts:        accounts,
+		editWindow:      editWindow,
+		reportThreshold: reportThreshold,
+	}
 }
 
-func (s *ReviewService) AddReview(ctx context.Context, review models.Review) error {
-    return s.repo.Create(&review)
+func validRating(v int) bool {
+	return v >= 1 && v <= 5
 }
 
-func (s *ReviewService) ListMyReviews(ctx context.Context, userID int64) ([]models.Review, error) {
+// AddReview сохраняет отзыв в статусе pending: флаг approved от клиента не принимается.
+// Отзыв может оставить только гость с подтвержденным email, который жил в комнате, и только один на комнату.
+func (s reviewService) AddReview(ctx context.Context, review *models.Review) error {
+	review.Description = strings.TrimSpace(review.Description)
+	if review.RoomID <= 0 || review.UserID <= 0 ||
+		!validRating(review.RoomRating) || !validRating(review.HotelRating) ||
+		utf8.RuneCountInString(review.Description) > maxReviewLength {
+		return erors.ErrInvalidInput
+	}
+	if err := s.accounts.EnsureVerified(ctx, review.UserID); err != nil {
+		return err
+	}
+
+	stayed, err := s.repo.HasCompletedStay(ctx, review.UserID, review.RoomID)
+	if err != nil {
+		return err
+	}
+	if !stayed {
+		return erors.ErrStayRequired
+	}
+	return s.repo.Create(ctx, review)
+}
+
+// UpdateReview правка собственного отзыва в пределах окна редактирования
+func (s reviewService) UpdateReview(ctx context.Context, reviewID, userID int64, dto models.UpdateReviewDTO) (models.Review, error) {
+	if reviewID <= 0 {
+		return models.Review{}, erors.ErrInvalidInput
+	}
+	if dto.Description == nil && dto.RoomRating == nil && dto.HotelRating == nil {
+		return models.Review{}, erors.ErrInvalidInput
+	}
+	if dto.Description != nil {
+		d := strings.TrimSpace(*dto.Description)
+		if utf8.RuneCountInString(d) > maxReviewLength {
+			return models.Review{}, erors.ErrInvalidInput
+		}
+		dto.Description = &d
+	}
+	if (dto.RoomRating != nil && !validRating(*dto.RoomRating)) ||
+		(dto.HotelRating != nil && !validRating(*dto.HotelRating)) {
+		return models.Review{}, erors.ErrInvalidInput
+	}
+	if err := s.accounts.EnsureVerified(ctx, userID); err != nil {
+		return models.Review{}, err
+	}
+	return s.repo.Update(ctx, reviewID, userID, dto, s.editWindow)
+}
+
+// ListMyReviews все отзывы автора, включая ожидающие модерации и отклоненные
+func (s reviewService) ListMyReviews(ctx context.Context, userID int64) ([]models.Review, error) {
 	// userID валиден, т.к. берётся из middleware; доп. проверка опциональна
-	return s.repo.ListByUserID(ctx, userID)
+	return s.repo.ListByUserID(ctx, userID, false)
+}
+
+// ListByUserID отзывы пользователя: чужие — только опубликованные, свои — все
+func (s reviewService) ListByUserID(ctx context.Context, userID, viewerID int64) ([]models.Review, error) {
+	if userID <= 0 {
+		return nil, erors.ErrInvalidInput
+	}
+	return s.repo.ListByUserID(ctx, userID, userID != viewerID)
+}
+
+// ListByRoomID опубликованные отзывы о комнате и собственные отзывы зрителя на модерации.
+// sort: newest, helpful, rating; пустое значение — в порядке создания.
+func (s reviewService) ListByRoomID(ctx context.Context, roomID, viewerID int64, sort string) ([]models.Review, error) {
+	switch sort {
+	case "", models.ReviewSortNewest, models.ReviewSortHelpful, models.ReviewSortRating:
+	default:
+		return nil, erors.ErrInvalidInput
+	}
+	if roomID <= 0 {
+		return nil, erors.ErrInvalidInput
+	}
+	return s.repo.ListByRoomID(ctx, roomID, viewerID, sort)
+}
+
+// ListForModeration очередь модерации; по умолчанию — ожидающие решения отзывы
+func (s reviewService) ListForModeration(ctx context.Context, status string, page, limit int) (models.ReviewListResponse, error) {
+	if status == "" {
+		status = models.ReviewStatusPending
+	}
+	if page == 0 {
+		page = 1
+	}
+	if limit == 0 {
+		limit = defaultReviewPageLimit
+	}
+	if !models.IsValidReviewStatus(status) || page < 1 || limit < 1 || limit > maxReviewPageLimit {
+		return models.ReviewListResponse{}, erors.ErrInvalidInput
+	}
+
+	reviews, total, err := s.repo.ListByStatus(ctx, status, limit, (page-1)*limit)
+	if err != nil {
+		return models.ReviewListResponse{}, err
+	}
+	return models.ReviewListResponse{
+		Data: reviews,
+		Pagination: models.Pagination{
+			Total:      total,
+			Page:       page,
+			Limit:      limit,
+			TotalPages: (total + limit - 1) / limit,
+		},
+	}, nil
+}
+
+func (s reviewService) Approve(ctx context.Context, reviewID, moderatorID int64) (models.Review, error) {
+	if reviewID <= 0 {
+		return models.Review{}, erors.ErrInvalidInput
+	}
+	return s.repo.Approve(ctx, reviewID, moderatorID)
 }
 
-func (s *ReviewService) DeleteByID(ctx context.Context, reviewID int64) error {
+// Reject отклоняет отзыв; причина обязательна, ее увидит автор
+func (s reviewService) Reject(ctx context.Context, reviewID, moderatorID int64, reason string) (models.Review, error) {
+	reason = strings.TrimSpace(reason)
+	if reviewID <= 0 || reason == "" || utf8.RuneCountInString(reason) > maxRejectReasonLength {
+		return models.Review{}, erors.ErrInvalidInput
+	}
+	return s.repo.Reject(ctx, reviewID, moderatorID, reason)
+}
+
+func (s reviewService) DeleteByID(ctx context.Context, reviewID int64) error {
 	if reviewID <= 0 {
 		return erors.ErrInvalidInput
 	}
@@ -32,17 +208,94 @@ func (s *ReviewService) DeleteByID(ctx context.Context, reviewID int64) error {
 	return s.repo.DeleteByID(ctx, reviewID)
 }
 
-func (s *ReviewService) ListByRoomID(ctx context.Context, roomID int64) ([]models.Review, error) {
-    if roomID <= 0 {
-        return nil, erors.ErrInvalidInput
-    }
-    return s.repo.ListByRoomID(ctx, roomID)
+// MarkHelpful отметка «полезно» от пользователя; за свой отзыв голосовать нельзя
+func (s reviewService) MarkHelpful(ctx context.Context, reviewID, userID int64) (models.Review, error) {
+	if reviewID <= 0 || userID <= 0 {
+		return models.Review{}, erors.ErrInvalidInput
+	}
+	return s.repo.AddHelpfulVote(ctx, reviewID, userID)
 }
 
+// Report жалоба на опубликованный отзыв; после reportThreshold жалоб отзыв снова уходит на модерацию
+func (s reviewService) Report(ctx context.Context, reviewID, userID int64, reason string) (models.Review, error) {
+	reason = strings.TrimSpace(reason)
+	if reviewID <= 0 || userID <= 0 || utf8.RuneCountInString(reason) > maxReportReasonLength {
+		return models.Review{}, erors.ErrInvalidInput
+	}
+	return s.repo.AddReport(ctx, reviewID, userID, reason, s.reportThreshold)
+}
 
-func (s *ReviewService) ListByUserID(ctx context.Context, userID int64) ([]models.Review, error) {
-    if userID <= 0 {
-        return nil, erors.ErrInvalidInput
-    }
-    return s.repo.ListByUserID(ctx, userID)
-}
\ No newline at end of file
+// replyTarget проверяет, что пользователь вправе отвечать от имени отеля, к которому
+// относится отзыв: администратор платформы — на любой, владелец — только на отзывы своих отелей
+func (s reviewService) replyTarget(ctx context.Context, reviewID, userID int64, role string) (models.Review, error) {
+	if reviewID <= 0 || userID <= 0 {
+		return models.Review{}, erors.ErrInvalidInput
+	}
+	review, err := s.repo.GetByID(ctx, reviewID)
+	if err != nil {
+		return models.Review{}, err
+	}
+	if models.RoleHasPermission(role, models.PermManageHotels) {
+		return review, nil
+	}
+	room, err := s.roomRepo.GetRoomByID(ctx, review.RoomID)
+	if err != nil {
+		if errors.Is(err, erors.ErrNotFound) {
+			return models.Review{}, erors.ErrNotHotelOwner
+		}
+		return models.Review{}, err
+	}
+	if _, err := EnsureHotelOwner(ctx, s.hotelRepo, userID, room.HotelID); err != nil {
+		if errors.Is(err, erors.ErrNotFound) {
+			return models.Review{}, erors.ErrNotHotelOwner
+		}
+		return models.Review{}, err
+	}
+	return review, nil
+}
+
+func normalizeReplyBody(body string) (string, error) {
+	body = strings.TrimSpace(body)
+	if body == "" || utf8.RuneCountInString(body) > maxReplyLength {
+		return "", erors.ErrInvalidInput
+	}
+	return body, nil
+}
+
+// CreateReply публикует ответ на отзыв. Отвечать можно только на опубликованные отзывы.
+func (s reviewService) CreateReply(ctx context.Context, reviewID, userID int64, role, body string) (models.ReviewReply, error) {
+	body, err := normalizeReplyBody(body)
+	if err != nil {
+		return models.ReviewReply{}, err
+	}
+	review, err := s.replyTarget(ctx, reviewID, userID, role)
+	if err != nil {
+		return models.ReviewReply{}, err
+	}
+	if !review.Approved {
+		return models.ReviewReply{}, erors.ErrNotFound
+	}
+	reply := models.ReviewReply{ReviewID: reviewID, AuthorID: &userID, Body: body}
+	if err := s.repo.CreateReply(ctx, &reply); err != nil {
+		return models.ReviewReply{}, err
+	}
+	return reply, nil
+}
+
+func (s reviewService) UpdateReply(ctx context.Context, reviewID, userID int64, role, body string) (models.ReviewReply, error) {
+	body, err := normalizeReplyBody(body)
+	if err != nil {
+		return models.ReviewReply{}, err
+	}
+	if _, err := s.replyTarget(ctx, reviewID, userID, role); err != nil {
+		return models.ReviewReply{}, err
+	}
+	return s.repo.UpdateReply(ctx, reviewID, userID, body)
+}
+
+func (s reviewService) DeleteReply(ctx context.Context, reviewID, userID int64, role string) error {
+	if _, err := s.replyTarget(ctx, reviewID, userID, role); err != nil {
+		return err
+	}
+	return s.repo.DeleteReply(ctx, reviewID)
+}
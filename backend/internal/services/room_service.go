@@ -5,6 +5,7 @@ import (
 	"backend/internal/models"
 	"backend/internal/repos"
 	"context"
+	"errors"
 	"strings"
 )
 
@@ -12,18 +13,34 @@ type RoomServiceInterface interface {
 	CreateRoom(ctx context.Context, room *models.Room) error
 	GetRoomsByHotelID(ctx context.Context, hotelID int64) ([]models.Room, error)
 	GetByID(ctx context.Context, roomID int64) (models.Room, error)
-	SearchRooms(ctx context.Context, city string, guests int, checkin, checkout string) ([]models.Room, error)
+	SearchRooms(ctx context.Context, city string, guests int, checkin, checkout string, amenities []string) ([]models.Room, error)
+	UpdateRoom(ctx context.Context, roomID int64, dto models.UpdateRoomDTO) (models.Room, error)
+	ReplaceRoom(ctx context.Context, roomID int64, room models.Room) (models.Room, error)
+	DeleteRoom(ctx context.Context, roomID int64, hard bool) error
+
+	// Партнерский API: операции только над комнатами в отелях владельца
+	CreateOwnedRoom(ctx context.Context, ownerID int64, room *models.Room) error
+	UpdateOwnedRoom(ctx context.Context, ownerID, hotelID, roomID int64, dto models.UpdateRoomDTO) (models.Room, error)
+	DeleteOwnedRoom(ctx context.Context, ownerID, hotelID, roomID int64) error
 }
 
 type roomService struct {
-	roomRepo repos.RoomRepoInterface
+	roomRepo  repos.RoomRepoInterface
+	hotelRepo repos.HotelRepoInterface
 }
 
-func NewRoomService(roomRepo repos.RoomRepoInterface) RoomServiceInterface {
-	return roomService{roomRepo: roomRepo}
+func NewRoomService(roomRepo repos.RoomRepoInterface, hotelRepo repos.HotelRepoInterface) RoomServiceInterface {
+	return roomService{roomRepo: roomRepo, hotelRepo: hotelRepo}
 }
 
 func (s roomService) CreateRoom(ctx context.Context, room *models.Room) error {
+	// Нельзя добавить комнату в несуществующий или удаленный отель
+	if _, err := s.hotelRepo.GetByID(ctx, room.HotelID); err != nil {
+		if errors.Is(err, erors.ErrNotFound) {
+			return erors.ErrInvalidHotelID
+		}
+		return err
+	}
 	return s.roomRepo.Create(ctx, room)
 }
 
@@ -35,9 +52,113 @@ func (s roomService) GetByID(ctx context.Context, roomID int64) (models.Room, er
 	return s.roomRepo.GetRoomByID(ctx, roomID)
 }
 
-func (s roomService) SearchRooms(ctx context.Context, city string, guests int, checkin, checkout string) ([]models.Room, error) {
+func (s roomService) SearchRooms(ctx context.Context, city string, guests int, checkin, checkout string, amenities []string) ([]models.Room, error) {
 	if strings.TrimSpace(city) == "" || guests <= 0 {
 		return nil, erors.ErrInvalidInput
 	}
-	return s.roomRepo.SearchRooms(ctx, city, guests, checkin, checkout)
+	amenities, err := normalizeAmenityCodes(amenities)
+	if err != nil {
+		return nil, err
+	}
+
+	checkin, checkout = strings.TrimSpace(checkin), strings.TrimSpace(checkout)
+	if checkin == "" && checkout == "" {
+		return s.roomRepo.SearchRooms(ctx, city, guests, "", "", amenities)
+	}
+	// Даты указываются только парой: одна без другой не задает период
+	in, out, err := parseStayDates(checkin, checkout)
+	if err != nil {
+		return nil, err
+	}
+	return s.roomRepo.SearchRooms(ctx, city, guests, in.Format(dateLayout), out.Format(dateLayout), amenities)
+}
+
+func validateRoom(room models.Room) error {
+	if room.HotelID <= 0 || room.Beds <= 0 || room.Price < 0 || strings.TrimSpace(room.Description) == "" {
+		return erors.ErrInvalidInput
+	}
+	return nil
+}
+
+func validateRoomUpdate(dto models.UpdateRoomDTO) error {
+	if dto.Beds == nil && dto.Price == nil && dto.Description == nil {
+		return erors.ErrInvalidInput
+	}
+	if (dto.Beds != nil && *dto.Beds <= 0) ||
+		(dto.Price != nil && *dto.Price < 0) ||
+		(dto.Description != nil && strings.TrimSpace(*dto.Description) == "") {
+		return erors.ErrInvalidInput
+	}
+	return nil
+}
+
+func (s roomService) UpdateRoom(ctx context.Context, roomID int64, dto models.UpdateRoomDTO) (models.Room, error) {
+	if roomID <= 0 {
+		return models.Room{}, erors.ErrInvalidInput
+	}
+	if err := validateRoomUpdate(dto); err != nil {
+		return models.Room{}, err
+	}
+	return s.roomRepo.Update(ctx, roomID, dto)
+}
+
+// ReplaceRoom полная замена редактируемых полей комнаты (PUT); отель и рейтинг не меняются
+func (s roomService) ReplaceRoom(ctx context.Context, roomID int64, room models.Room) (models.Room, error) {
+	return s.UpdateRoom(ctx, roomID, models.UpdateRoomDTO{
+		Beds:        &room.Beds,
+		Price:       &room.Price,
+		Description: &room.Description,
+	})
+}
+
+// DeleteRoom по умолчанию удаляет мягко; hard=true удаляет физически вместе со всей историей
+func (s roomService) DeleteRoom(ctx context.Context, roomID int64, hard bool) error {
+	if roomID <= 0 {
+		return erors.ErrInvalidInput
+	}
+	if hard {
+		return s.roomRepo.HardDelete(ctx, roomID)
+	}
+	return s.roomRepo.Delete(ctx, roomID)
+}
+
+// ownedRoom проверяет, что отель принадлежит ownerID, а комната относится к этому отелю
+func (s roomService) ownedRoom(ctx context.Context, ownerID, hotelID, roomID int64) (models.Room, error) {
+	if _, err := EnsureHotelOwner(ctx, s.hotelRepo, ownerID, hotelID); err != nil {
+		return models.Room{}, err
+	}
+	room, err := s.roomRepo.GetRoomByID(ctx, roomID)
+	if err != nil {
+		return models.Room{}, err
+	}
+	if room.HotelID != hotelID {
+		return models.Room{}, erors.ErrNotFound
+	}
+	return room, nil
+}
+
+func (s roomService) CreateOwnedRoom(ctx context.Context, ownerID int64, room *models.Room) error {
+	if err := validateRoom(*room); err != nil {
+		return err
+	}
+	if _, err := EnsureHotelOwner(ctx, s.hotelRepo, ownerID, room.HotelID); err != nil {
+		return err
+	}
+	// Рейтинг считается из отзывов, партнер задать его не может
+	room.Rating = 0
+	return s.roomRepo.Create(ctx, room)
+}
+
+func (s roomService) UpdateOwnedRoom(ctx context.Context, ownerID, hotelID, roomID int64, dto models.UpdateRoomDTO) (models.Room, error) {
+	if _, err := s.ownedRoom(ctx, ownerID, hotelID, roomID); err != nil {
+		return models.Room{}, err
+	}
+	return s.UpdateRoom(ctx, roomID, dto)
+}
+
+func (s roomService) DeleteOwnedRoom(ctx context.Context, ownerID, hotelID, roomID int64) error {
+	if _, err := s.ownedRoom(ctx, ownerID, hotelID, roomID); err != nil {
+		return err
+	}
+	return s.DeleteRoom(ctx, roomID, false)
 }
@@ -0,0 +1,155 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"backend/internal/erors"
+	"backend/internal/models"
+	"backend/internal/repos"
+)
+
+// defaultSessionCacheTTL сколько проверка сессии живет в кэше, если в конфиге не задано иное.
+// Отзыв, сделанный на другой реплике, станет виден здесь не позже чем через этот интервал.
+const defaultSessionCacheTTL = 30 * time.Second
+
+// maxSessionCacheSize при превышении из кэша вычищаются просроченные записи
+const maxSessionCacheSize = 10000
+
+type SessionServiceInterface interface {
+	Open(ctx context.Context, userID int64, ttl time.Duration) (string, error)
+	IsActive(ctx context.Context, jti string) (bool, error)
+	Extend(ctx context.Context, jti string, ttl time.Duration) error
+	Revoke(ctx context.Context, userID int64, jti string) error
+	RevokeAll(ctx context.Context, userID int64) error
+}
+
+type sessionCacheEntry struct {
+	userID    int64
+	active    bool
+	expiresAt time.Time
+}
+
+type sessionService struct {
+	repo     repos.SessionRepoInterface
+	cacheTTL time.Duration
+
+	mu    sync.RWMutex
+	cache map[string]sessionCacheEntry
+}
+
+func NewSessionService(repo repos.SessionRepoInterface, cacheTTL time.Duration) SessionServiceInterface {
+	if cacheTTL <= 0 {
+		cacheTTL = defaultSessionCacheTTL
+	}
+	return &sessionService{
+		repo:     repo,
+		cacheTTL: cacheTTL,
+		cache:    make(map[string]sessionCacheEntry),
+	}
+}
+
+// Open создает новую сессию и возвращает ее jti
+func (s *sessionService) Open(ctx context.Context, userID int64, ttl time.Duration) (string, error) {
+	jti, err := randomHex(16)
+	if err != nil {
+		return "", err
+	}
+	session := models.Session{
+		JTI:       jti,
+		UserID:    userID,
+		ExpiresAt: time.Now().Add(ttl),
+	}
+	if err := s.repo.Create(ctx, session); err != nil {
+		return "", err
+	}
+	s.remember(jti, userID, true)
+	return jti, nil
+}
+
+// IsActive проверяет, что сессия существует, не отозвана и не истекла.
+// Результат кэшируется в памяти процесса, чтобы не ходить в БД на каждый запрос.
+func (s *sessionService) IsActive(ctx context.Context, jti string) (bool, error) {
+	if jti == "" {
+		return false, nil
+	}
+
+	s.mu.RLock()
+	entry, ok := s.cache[jti]
+	s.mu.RUnlock()
+	if ok && time.Now().Before(entry.expiresAt) {
+		return entry.active, nil
+	}
+
+	session, err := s.repo.GetByJTI(ctx, jti)
+	if err != nil {
+		if errors.Is(err, erors.ErrNotFound) {
+			return false, nil
+		}
+		return false, err
+	}
+	active := !session.RevokedAt.Valid && time.Now().Before(session.ExpiresAt)
+	s.remember(jti, session.UserID, active)
+	return active, nil
+}
+
+// Extend сдвигает срок жизни сессии на ttl от текущего момента. Вызывается при каждой
+// ротации refresh-токена, чтобы активная сессия не истекала раньше выданных в ней токенов.
+func (s *sessionService) Extend(ctx context.Context, jti string, ttl time.Duration) error {
+	return s.repo.Extend(ctx, jti, time.Now().Add(ttl))
+}
+
+// Revoke отзывает одну сессию пользователя (logout с текущего устройства)
+func (s *sessionService) Revoke(ctx context.Context, userID int64, jti string) error {
+	session, err := s.repo.GetByJTI(ctx, jti)
+	if err != nil {
+		return err
+	}
+	if session.UserID != userID {
+		return erors.ErrNotFound
+	}
+	if err := s.repo.Revoke(ctx, jti); err != nil {
+		return err
+	}
+	s.remember(jti, userID, false)
+	return nil
+}
+
+// RevokeAll отзывает все сессии пользователя (например, при краже устройства)
+func (s *sessionService) RevokeAll(ctx context.Context, userID int64) error {
+	if err := s.repo.RevokeAllByUserID(ctx, userID); err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	for jti, entry := range s.cache {
+		if entry.userID == userID {
+			entry.active = false
+			s.cache[jti] = entry
+		}
+	}
+	s.mu.Unlock()
+	return nil
+}
+
+func (s *sessionService) remember(jti string, userID int64, active bool) {
+	now := time.Now()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if len(s.cache) >= maxSessionCacheSize {
+		for k, e := range s.cache {
+			if now.After(e.expiresAt) {
+				delete(s.cache, k)
+			}
+		}
+	}
+	s.cache[jti] = sessionCacheEntry{
+		userID:    userID,
+		active:    active,
+		expiresAt: now.Add(s.cacheTTL),
+	}
+}
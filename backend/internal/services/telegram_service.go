@@ -0,0 +1,189 @@
+package services
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"backend/internal/config"
+	"backend/internal/erors"
+	"backend/internal/logger"
+	"backend/internal/models"
+	"backend/internal/repos"
+
+	"go.uber.org/zap"
+)
+
+const (
+	defaultTelegramAuthMaxAge = 24 * time.Hour
+
+	// telegramClockSkew допустимое опережение auth_date относительно часов сервера
+	telegramClockSkew = time.Minute
+
+	// unusablePasswordHash не является bcrypt-хешем: вход по паролю для такой учетной записи невозможен
+	unusablePasswordHash = "!"
+)
+
+type TelegramServiceInterface interface {
+	Login(ctx context.Context, data map[string]string, currentUserID int64) (models.User, error)
+	BotInfo() models.TelegramBotInfo
+}
+
+type telegramService struct {
+	cfg         config.TelegramConfig
+	networkRepo repos.NetworkRepoInterface
+	authRepo    repos.AuthRepoInterface
+	logger      logger.Logger
+}
+
+func NewTelegramService(cfg config.TelegramConfig, networkRepo repos.NetworkRepoInterface, authRepo repos.AuthRepoInterface, logger logger.Logger) TelegramServiceInterface {
+	return &telegramService{
+		cfg:         cfg,
+		networkRepo: networkRepo,
+		authRepo:    authRepo,
+		logger:      logger,
+	}
+}
+
+// VerifyTelegramAuth проверяет данные Telegram Login Widget: HMAC-SHA256 строки проверки
+// (поля кроме hash, key=value по алфавиту через \n) с ключом SHA256(токен бота) и свежесть auth_date.
+func VerifyTelegramAuth(data map[string]string, botToken string, maxAge time.Duration, now time.Time) (models.TelegramUser, error) {
+	hash := strings.ToLower(data["hash"])
+	if hash == "" {
+		return models.TelegramUser{}, erors.ErrTelegramAuthInvalid
+	}
+
+	keys := make([]string, 0, len(data))
+	for k := range data {
+		if k != "hash" {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+	lines := make([]string, len(keys))
+	for i, k := range keys {
+		lines[i] = k + "=" + data[k]
+	}
+
+	secret := sha256.Sum256([]byte(botToken))
+	mac := hmac.New(sha256.New, secret[:])
+	mac.Write([]byte(strings.Join(lines, "\n")))
+	expected := hex.EncodeToString(mac.Sum(nil))
+	if !hmac.Equal([]byte(expected), []byte(hash)) {
+		return models.TelegramUser{}, erors.ErrTelegramAuthInvalid
+	}
+
+	id, err := strconv.ParseInt(data["id"], 10, 64)
+	if err != nil || id <= 0 {
+		return models.TelegramUser{}, erors.ErrTelegramAuthInvalid
+	}
+	authDate, err := strconv.ParseInt(data["auth_date"], 10, 64)
+	if err != nil {
+		return models.TelegramUser{}, erors.ErrTelegramAuthInvalid
+	}
+	issued := time.Unix(authDate, 0)
+	if issued.After(now.Add(telegramClockSkew)) {
+		return models.TelegramUser{}, erors.ErrTelegramAuthInvalid
+	}
+	if now.Sub(issued) > maxAge {
+		return models.TelegramUser{}, erors.ErrTelegramAuthExpired
+	}
+
+	return models.TelegramUser{
+		ID:        id,
+		FirstName: data["first_name"],
+		LastName:  data["last_name"],
+		Username:  data["username"],
+		AuthDate:  authDate,
+	}, nil
+}
+
+func (s *telegramService) maxAge() time.Duration {
+	if s.cfg.AuthMaxAge <= 0 {
+		return defaultTelegramAuthMaxAge
+	}
+	return time.Duration(s.cfg.AuthMaxAge) * time.Second
+}
+
+// Login проверяет данные виджета и возвращает пользователя для выдачи токенов.
+// currentUserID > 0 — уже вошедший пользователь привязывает Telegram к своей учетной записи.
+// Иначе ищется пользователь с этим Telegram ID; если его нет, создается новый.
+func (s *telegramService) Login(ctx context.Context, data map[string]string, currentUserID int64) (models.User, error) {
+	if s.cfg.BotToken == "" {
+		return models.User{}, erors.ErrTelegramDisabled
+	}
+	tg, err := VerifyTelegramAuth(data, s.cfg.BotToken, s.maxAge(), time.Now())
+	if err != nil {
+		s.logger.Warn("telegram login rejected", zap.Error(err))
+		return models.User{}, err
+	}
+
+	userID := currentUserID
+	if userID <= 0 {
+		userID, err = s.networkRepo.FindUserIDByTelegramID(ctx, tg.ID)
+		if errors.Is(err, erors.ErrUserNotFound) {
+			return s.createUser(ctx, tg)
+		}
+		if err != nil {
+			return models.User{}, err
+		}
+	}
+
+	// Привязка заодно обновляет username, который пользователь мог сменить в Telegram
+	if err := s.networkRepo.LinkTelegram(ctx, userID, tg); err != nil {
+		return models.User{}, err
+	}
+	return s.authRepo.GetUserByID(ctx, userID)
+}
+
+func (s *telegramService) createUser(ctx context.Context, tg models.TelegramUser) (models.User, error) {
+	name := strings.TrimSpace(tg.FirstName + " " + tg.LastName)
+	if name == "" {
+		name = tg.Username
+	}
+	if name == "" {
+		name = fmt.Sprintf("Telegram %d", tg.ID)
+	}
+
+	// Telegram не сообщает email, а в users он обязателен и уникален: используем адрес
+	// в зарезервированной зоне .invalid, по которому нельзя ни войти, ни отправить письмо
+	dto := models.CreateUserDTO{
+		Name:     name,
+		Email:    fmt.Sprintf("telegram_%d@telegram.invalid", tg.ID),
+		Password: unusablePasswordHash,
+		Role:     models.RoleUser,
+	}
+	userID, err := s.networkRepo.CreateTelegramUser(ctx, dto, tg)
+	switch {
+	case errors.Is(err, erors.ErrConflict), errors.Is(err, erors.ErrUserAlreadyExists):
+		// Параллельный вход с тем же Telegram ID успел создать пользователя
+		if userID, err = s.networkRepo.FindUserIDByTelegramID(ctx, tg.ID); err != nil {
+			return models.User{}, err
+		}
+	case err != nil:
+		return models.User{}, err
+	default:
+		s.logger.Info("user registered via telegram", zap.Int64("user_id", userID), zap.Int64("telegram_id", tg.ID))
+	}
+	return s.authRepo.GetUserByID(ctx, userID)
+}
+
+// BotInfo параметры виджета; ID бота — часть токена до двоеточия, он не секретен
+func (s *telegramService) BotInfo() models.TelegramBotInfo {
+	info := models.TelegramBotInfo{
+		Enabled:     s.cfg.BotToken != "",
+		BotUsername: s.cfg.BotUsername,
+		AuthURL:     "/auth/telegram",
+	}
+	if id, _, ok := strings.Cut(s.cfg.BotToken, ":"); ok {
+		info.BotID, _ = strconv.ParseInt(id, 10, 64)
+	}
+	return info
+}
@@ -0,0 +1,113 @@
+package services
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"maps"
+	"sort"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"backend/internal/erors"
+)
+
+const testBotToken = "123456:TEST-fake-bot-token"
+
+// signTelegram подписывает поля так же, как Telegram подписывает данные виджета
+func signTelegram(data map[string]string, botToken string) map[string]string {
+	keys := make([]string, 0, len(data))
+	for k := range data {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	lines := make([]string, len(keys))
+	for i, k := range keys {
+		lines[i] = k + "=" + data[k]
+	}
+	secret := sha256.Sum256([]byte(botToken))
+	mac := hmac.New(sha256.New, secret[:])
+	mac.Write([]byte(strings.Join(lines, "\n")))
+
+	signed := maps.Clone(data)
+	signed["hash"] = hex.EncodeToString(mac.Sum(nil))
+	return signed
+}
+
+func TestVerifyTelegramAuth(t *testing.T) {
+	now := time.Unix(1735689600, 0)
+	maxAge := 24 * time.Hour
+	fields := func(authDate time.Time) map[string]string {
+		return map[string]string{
+			"id":         "42",
+			"first_name": "Alice",
+			"username":   "alice_dev",
+			"auth_date":  strconv.FormatInt(authDate.Unix(), 10),
+		}
+	}
+
+	tests := []struct {
+		name    string
+		data    map[string]string
+		wantErr error
+	}{
+		{
+			name: "valid hash",
+			data: signTelegram(fields(now.Add(-time.Minute)), testBotToken),
+		},
+		{
+			name: "tampered field",
+			data: func() map[string]string {
+				d := signTelegram(fields(now), testBotToken)
+				d["id"] = "43"
+				return d
+			}(),
+			wantErr: erors.ErrTelegramAuthInvalid,
+		},
+		{
+			name:    "another bot token",
+			data:    signTelegram(fields(now), "654321:OTHER-bot-token"),
+			wantErr: erors.ErrTelegramAuthInvalid,
+		},
+		{
+			name:    "missing hash",
+			data:    fields(now),
+			wantErr: erors.ErrTelegramAuthInvalid,
+		},
+		{
+			name:    "stale auth_date",
+			data:    signTelegram(fields(now.Add(-maxAge-time.Second)), testBotToken),
+			wantErr: erors.ErrTelegramAuthExpired,
+		},
+		{
+			name: "future auth_date within clock skew",
+			data: signTelegram(fields(now.Add(telegramClockSkew)), testBotToken),
+		},
+		{
+			name:    "future auth_date beyond clock skew",
+			data:    signTelegram(fields(now.Add(telegramClockSkew+time.Second)), testBotToken),
+			wantErr: erors.ErrTelegramAuthInvalid,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			user, err := VerifyTelegramAuth(tt.data, testBotToken, maxAge, now)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("got %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if user.ID != 42 || user.FirstName != "Alice" || user.Username != "alice_dev" {
+				t.Fatalf("unexpected user %+v", user)
+			}
+		})
+	}
+}
@@ -0,0 +1,169 @@
+package services
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"time"
+
+	"backend/internal/erors"
+	"backend/internal/logger"
+	"backend/internal/models"
+	"backend/internal/repos"
+
+	"go.uber.org/zap"
+)
+
+type TokenServiceInterface interface {
+	IssueTokenPair(ctx context.Context, user models.User) (models.AuthResponse, error)
+	Refresh(ctx context.Context, refreshToken string) (models.AuthResponse, error)
+}
+
+type tokenService struct {
+	jwtService  JWTService
+	refreshRepo repos.RefreshTokenRepoInterface
+	authRepo    repos.AuthRepoInterface
+	sessions    SessionServiceInterface
+	logger      logger.Logger
+}
+
+func NewTokenService(jwtService JWTService, refreshRepo repos.RefreshTokenRepoInterface, authRepo repos.AuthRepoInterface, sessions SessionServiceInterface, logger logger.Logger) TokenServiceInterface {
+	return &tokenService{
+		jwtService:  jwtService,
+		refreshRepo: refreshRepo,
+		authRepo:    authRepo,
+		sessions:    sessions,
+		logger:      logger,
+	}
+}
+
+// randomHex возвращает n криптографически случайных байт в hex
+func randomHex(n int) (string, error) {
+	b := make([]byte, n)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
+
+// hashToken SHA-256 от токена; в БД сами токены не хранятся
+func hashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+// IssueTokenPair открывает новую сессию (новый логин) и выдает первую пару токенов в ней.
+// jti сессии одновременно служит идентификатором семейства refresh-токенов.
+func (s *tokenService) IssueTokenPair(ctx context.Context, user models.User) (models.AuthResponse, error) {
+	sessionID, err := s.sessions.Open(ctx, user.ID, s.jwtService.RefreshTokenTTL())
+	if err != nil {
+		return models.AuthResponse{}, err
+	}
+	return s.issue(ctx, user, sessionID)
+}
+
+func (s *tokenService) issue(ctx context.Context, user models.User, familyID string) (models.AuthResponse, error) {
+	accessToken, refreshToken, err := s.jwtService.GenerateTokenPair(user, familyID)
+	if err != nil {
+		return models.AuthResponse{}, err
+	}
+
+	record := models.RefreshToken{
+		UserID:    user.ID,
+		FamilyID:  familyID,
+		TokenHash: hashToken(refreshToken),
+		ExpiresAt: time.Now().Add(s.jwtService.RefreshTokenTTL()),
+	}
+	if err := s.refreshRepo.Create(ctx, record); err != nil {
+		return models.AuthResponse{}, err
+	}
+
+	return models.AuthResponse{
+		AccessToken:  accessToken,
+		RefreshToken: refreshToken,
+	}, nil
+}
+
+// Refresh обменивает refresh-токен на новую пару (ротация). Каждый refresh-токен
+// одноразовый: повторное предъявление уже использованного токена означает, что он
+// утек, поэтому отзывается всё семейство и владельцу придется войти заново.
+func (s *tokenService) Refresh(ctx context.Context, refreshToken string) (models.AuthResponse, error) {
+	claims, err := s.jwtService.ValidateRefreshToken(refreshToken)
+	if err != nil {
+		return models.AuthResponse{}, erors.ErrInvalidToken
+	}
+
+	record, err := s.refreshRepo.GetByHash(ctx, hashToken(refreshToken))
+	if err != nil {
+		if errors.Is(err, erors.ErrNotFound) {
+			return models.AuthResponse{}, erors.ErrInvalidToken
+		}
+		return models.AuthResponse{}, err
+	}
+	if record.UserID != claims.UserID || time.Now().After(record.ExpiresAt) {
+		return models.AuthResponse{}, erors.ErrInvalidToken
+	}
+
+	if record.RevokedAt.Valid {
+		return models.AuthResponse{}, erors.ErrInvalidToken
+	}
+	if record.UsedAt.Valid {
+		return models.AuthResponse{}, s.revokeReused(ctx, record)
+	}
+
+	// Отозванная или истекшая сессия не принимается и в middleware, поэтому новая пара
+	// была бы бесполезна: клиент получил бы токены, которые сразу отвергаются
+	active, err := s.sessions.IsActive(ctx, record.FamilyID)
+	if err != nil {
+		return models.AuthResponse{}, err
+	}
+	if !active {
+		return models.AuthResponse{}, erors.ErrInvalidToken
+	}
+
+	ok, err := s.refreshRepo.MarkUsed(ctx, record.ID)
+	if err != nil {
+		return models.AuthResponse{}, err
+	}
+	if !ok {
+		// Токен успели использовать параллельно — такое же повторное предъявление
+		return models.AuthResponse{}, s.revokeReused(ctx, record)
+	}
+
+	// Роль и email берем из БД, а не из старого токена: они могли измениться
+	user, err := s.authRepo.GetUserByID(ctx, record.UserID)
+	if err != nil {
+		if errors.Is(err, erors.ErrUserNotFound) {
+			return models.AuthResponse{}, erors.ErrInvalidToken
+		}
+		return models.AuthResponse{}, err
+	}
+
+	// Сессия скользящая: продлеваем ее вместе с ротацией, иначе через RefreshTokenTTL
+	// после логина новые access-токены начнут отвергаться при живом refresh-токене
+	if err := s.sessions.Extend(ctx, record.FamilyID, s.jwtService.RefreshTokenTTL()); err != nil {
+		return models.AuthResponse{}, err
+	}
+
+	return s.issue(ctx, user, record.FamilyID)
+}
+
+// revokeReused отзывает сессию целиком: и refresh-семейство, и уже выданные access-токены
+func (s *tokenService) revokeReused(ctx context.Context, record models.RefreshToken) error {
+	s.logger.Warn("refresh token reuse detected, revoking family",
+		zap.Int64("user_id", record.UserID),
+		zap.String("family_id", record.FamilyID),
+	)
+	err := s.sessions.Revoke(ctx, record.UserID, record.FamilyID)
+	if errors.Is(err, erors.ErrNotFound) {
+		// У семейств, выданных до появления таблицы sessions, строки сессии нет —
+		// refresh-токены семейства отзываем напрямую
+		err = s.refreshRepo.RevokeFamily(ctx, record.FamilyID)
+	}
+	if err != nil {
+		return err
+	}
+	return erors.ErrTokenReused
+}
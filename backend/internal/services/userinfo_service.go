@@ -12,16 +12,21 @@ import (
 
 type userInfoServ struct {
 	userRepo repos.UserRepoInterface
+	sessions SessionServiceInterface
 }
 
 type UserServInterface interface {
 	GetUserInfo(ctx context.Context, userID int64) (models.UserInfoDTO, error)
 	UpdateUserInfo(ctx context.Context, user models.UserUpdateDTO) error
+	UpdateRole(ctx context.Context, userID int64, role string) error
+	GetPrivacy(ctx context.Context, userID int64) (models.PrivacySettings, error)
+	UpdatePrivacy(ctx context.Context, userID int64, dto models.UpdatePrivacyDTO) (models.PrivacySettings, error)
 }
 
-func NewUserInfoServ(userServ repos.UserRepoInterface) UserServInterface {
+func NewUserInfoServ(userServ repos.UserRepoInterface, sessions SessionServiceInterface) UserServInterface {
 	return &userInfoServ{
 		userRepo: userServ,
+		sessions: sessions,
 	}
 }
 
@@ -36,15 +41,18 @@ func (u *userInfoServ) GetUserInfo(ctx context.Context, userID int64) (models.Us
 	}
 
 	user := models.UserInfoDTO{
-		Name:        res.Name,
-		City:        res.City,
-		Email:       res.Email,
-		DateOfBirth: res.DateOfBirth,
-		CreatedAt:   res.CreatedAt,
+		Name:          res.Name,
+		City:          res.City,
+		Email:         res.Email,
+		EmailVerified: res.EmailVerified,
+		DateOfBirth:   res.DateOfBirth,
+		CreatedAt:     res.CreatedAt,
 	}
 	return user, nil
 }
 
+// UpdateUserInfo меняет профиль; имя и email проверяются так же, как при регистрации.
+// Пустые имя и email остаются прежними.
 func (u *userInfoServ) UpdateUserInfo(ctx context.Context, user models.UserUpdateDTO) error {
 	// Минимальная валидация: нужны поля для обновления
 	if strings.TrimSpace(user.Name) == "" &&
@@ -52,6 +60,9 @@ func (u *userInfoServ) UpdateUserInfo(ctx context.Context, user models.UserUpdat
 		strings.TrimSpace(user.City) == "" {
 		return erors.ErrInvalidInput
 	}
+	if err := validateProfileUpdate(&user); err != nil {
+		return err
+	}
 
 	userInfo := models.User{
 		ID:    user.ID,
@@ -74,3 +85,29 @@ func (u *userInfoServ) UpdateUserInfo(ctx context.Context, user models.UserUpdat
 
 	return nil
 }
+
+// UpdateRole меняет роль пользователя. Роль зашита в выданные JWT, поэтому
+// все сессии пользователя отзываются — новая роль начнет действовать после входа.
+func (u *userInfoServ) UpdateRole(ctx context.Context, userID int64, role string) error {
+	role = strings.TrimSpace(role)
+	if userID <= 0 || !models.IsValidRole(role) {
+		return erors.ErrInvalidInput
+	}
+
+	if err := u.userRepo.UpdateRole(ctx, userID, role); err != nil {
+		return err
+	}
+	return u.sessions.RevokeAll(ctx, userID)
+}
+
+func (u *userInfoServ) GetPrivacy(ctx context.Context, userID int64) (models.PrivacySettings, error) {
+	return u.userRepo.GetPrivacy(ctx, userID)
+}
+
+// UpdatePrivacy меняет видимость активности для друзей; пустой запрос — ошибка
+func (u *userInfoServ) UpdatePrivacy(ctx context.Context, userID int64, dto models.UpdatePrivacyDTO) (models.PrivacySettings, error) {
+	if dto.ShareReviews == nil && dto.ShareFavorites == nil && dto.ShareStays == nil {
+		return models.PrivacySettings{}, erors.ErrInvalidInput
+	}
+	return u.userRepo.UpdatePrivacy(ctx, userID, dto)
+}
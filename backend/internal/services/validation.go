@@ -0,0 +1,252 @@
+package services
+
+import (
+	_ "embed"
+	"fmt"
+	"net/mail"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	"backend/internal/erors"
+	"backend/internal/models"
+)
+
+const (
+	minPasswordLength  = 8
+	maxPasswordBytes   = 72 // bcrypt не принимает пароли длиннее
+	minPasswordClasses = 3
+
+	minNameLength  = 2
+	maxNameLength  = 100
+	maxCityLength  = 100
+	maxEmailLength = 254
+
+	// defaultMinAge минимальный возраст при регистрации, если в конфиге не задано иное
+	defaultMinAge = 14
+	maxAge        = 120
+)
+
+//go:embed common_passwords.txt
+var commonPasswordsFile string
+
+var commonPasswords = parseBlocklist(commonPasswordsFile)
+
+func parseBlocklist(data string) map[string]struct{} {
+	res := make(map[string]struct{})
+	for _, line := range strings.Split(data, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		res[strings.ToLower(line)] = struct{}{}
+	}
+	return res
+}
+
+// fieldErrors накапливает ошибки по полям, чтобы вернуть их все одним ответом
+type fieldErrors []erors.FieldError
+
+func (f *fieldErrors) add(field, code, message string) {
+	*f = append(*f, erors.FieldError{Field: field, Code: code, Message: message})
+}
+
+func (f fieldErrors) err() error {
+	if len(f) == 0 {
+		return nil
+	}
+	return &erors.ValidationError{Fields: f}
+}
+
+// validateRegistration проверяет и нормализует (обрезает пробелы) данные регистрации.
+// Дата рождения необязательна, но если указана — возраст не меньше minAge.
+func validateRegistration(dto *models.CreateUserDTO, minAge int, now time.Time) error {
+	if minAge <= 0 {
+		minAge = defaultMinAge
+	}
+	var errs fieldErrors
+
+	dto.Name = strings.TrimSpace(dto.Name)
+	if fe := checkName(dto.Name); fe != nil {
+		errs = append(errs, *fe)
+	}
+
+	dto.Email = strings.TrimSpace(dto.Email)
+	if fe := checkEmail(dto.Email); fe != nil {
+		errs = append(errs, *fe)
+	}
+
+	if fe := checkPassword(dto.Password, dto.Email, dto.Name); fe != nil {
+		errs = append(errs, *fe)
+	}
+
+	dto.City = strings.TrimSpace(dto.City)
+	if fe := checkCity(dto.City); fe != nil {
+		errs = append(errs, *fe)
+	}
+
+	dto.DateOfBirth = strings.TrimSpace(dto.DateOfBirth)
+	if dto.DateOfBirth != "" {
+		dob, err := time.Parse(time.DateOnly, dto.DateOfBirth)
+		switch age := ageAt(dob, now); {
+		case err != nil:
+			errs.add("date_of_birth", "invalid_format", "Дата рождения должна быть в формате ГГГГ-ММ-ДД")
+		case dob.After(now) || age > maxAge:
+			errs.add("date_of_birth", "invalid", "Некорректная дата рождения")
+		case age < minAge:
+			errs.add("date_of_birth", "too_young", fmt.Sprintf("Регистрация доступна с %d лет", minAge))
+		}
+	}
+
+	return errs.err()
+}
+
+// validateProfileUpdate проверяет и нормализует изменения профиля по тем же правилам,
+// что и регистрацию. Пустые имя и email означают «не менять».
+func validateProfileUpdate(dto *models.UserUpdateDTO) error {
+	var errs fieldErrors
+
+	dto.Name = strings.TrimSpace(dto.Name)
+	if dto.Name != "" {
+		if fe := checkName(dto.Name); fe != nil {
+			errs = append(errs, *fe)
+		}
+	}
+	dto.Email = strings.TrimSpace(dto.Email)
+	if dto.Email != "" {
+		if fe := checkEmail(dto.Email); fe != nil {
+			errs = append(errs, *fe)
+		}
+	}
+	dto.City = strings.TrimSpace(dto.City)
+	if fe := checkCity(dto.City); fe != nil {
+		errs = append(errs, *fe)
+	}
+
+	return errs.err()
+}
+
+func checkName(name string) *erors.FieldError {
+	fe := func(code, message string) *erors.FieldError {
+		return &erors.FieldError{Field: "name", Code: code, Message: message}
+	}
+	switch n := utf8.RuneCountInString(name); {
+	case n == 0:
+		return fe("required", "Укажите имя")
+	case n < minNameLength:
+		return fe("too_short", fmt.Sprintf("Имя должно быть не короче %d символов", minNameLength))
+	case n > maxNameLength:
+		return fe("too_long", fmt.Sprintf("Имя должно быть не длиннее %d символов", maxNameLength))
+	case strings.IndexFunc(name, unicode.IsControl) >= 0:
+		return fe("invalid", "Имя содержит недопустимые символы")
+	}
+	return nil
+}
+
+func checkCity(city string) *erors.FieldError {
+	if utf8.RuneCountInString(city) > maxCityLength {
+		return &erors.FieldError{Field: "city", Code: "too_long", Message: fmt.Sprintf("Название города должно быть не длиннее %d символов", maxCityLength)}
+	}
+	return nil
+}
+
+// ageAt полных лет на момент now
+func ageAt(dob, now time.Time) int {
+	age := now.Year() - dob.Year()
+	if now.Month() < dob.Month() || (now.Month() == dob.Month() && now.Day() < dob.Day()) {
+		age--
+	}
+	return age
+}
+
+// checkEmail формат адреса: только сам адрес без имени, домен с точкой.
+// Домен .invalid зарезервирован под служебные адреса Telegram и OAuth.
+func checkEmail(email string) *erors.FieldError {
+	if email == "" {
+		return &erors.FieldError{Field: "email", Code: "required", Message: "Укажите email"}
+	}
+	if len(email) > maxEmailLength {
+		return &erors.FieldError{Field: "email", Code: "too_long", Message: fmt.Sprintf("Email должен быть не длиннее %d символов", maxEmailLength)}
+	}
+	invalid := &erors.FieldError{Field: "email", Code: "invalid", Message: "Некорректный email"}
+	addr, err := mail.ParseAddress(email)
+	if err != nil || addr.Name != "" || addr.Address != email {
+		return invalid
+	}
+	domain := email[strings.LastIndex(email, "@")+1:]
+	if !strings.Contains(domain, ".") || strings.HasPrefix(domain, ".") || strings.HasSuffix(domain, ".") ||
+		isPlaceholderEmail(email) {
+		return invalid
+	}
+	return nil
+}
+
+// checkPassword политика паролей: длина, минимум три вида символов из четырех, отсутствие
+// в списке распространенных паролей и личных данных (email, имя), если они известны
+func checkPassword(password, email, name string) *erors.FieldError {
+	fe := func(code, message string) *erors.FieldError {
+		return &erors.FieldError{Field: "password", Code: code, Message: message}
+	}
+	if password == "" {
+		return fe("required", "Укажите пароль")
+	}
+	if utf8.RuneCountInString(password) < minPasswordLength {
+		return fe("too_short", fmt.Sprintf("Пароль должен быть не короче %d символов", minPasswordLength))
+	}
+	if len(password) > maxPasswordBytes {
+		return fe("too_long", fmt.Sprintf("Пароль должен быть не длиннее %d байт", maxPasswordBytes))
+	}
+	if passwordClasses(password) < minPasswordClasses {
+		return fe("weak", "Пароль должен содержать хотя бы три вида символов из четырех: строчные буквы, заглавные буквы, цифры, другие символы")
+	}
+	if isCommonPassword(password) {
+		return fe("common", "Этот пароль слишком распространен, выберите другой")
+	}
+
+	lower := strings.ToLower(password)
+	local, _, _ := strings.Cut(strings.ToLower(email), "@")
+	for _, personal := range []string{local, strings.ToLower(name)} {
+		if utf8.RuneCountInString(personal) >= 4 && strings.Contains(lower, personal) {
+			return fe("personal", "Пароль не должен содержать имя или email")
+		}
+	}
+	return nil
+}
+
+func passwordClasses(password string) int {
+	var lower, upper, digit, other bool
+	for _, r := range password {
+		switch {
+		case unicode.IsLower(r):
+			lower = true
+		case unicode.IsUpper(r):
+			upper = true
+		case unicode.IsDigit(r):
+			digit = true
+		default:
+			other = true
+		}
+	}
+	n := 0
+	for _, ok := range []bool{lower, upper, digit, other} {
+		if ok {
+			n++
+		}
+	}
+	return n
+}
+
+// isCommonPassword пароль из списка целиком или с дописанными в конце цифрами и символами
+func isCommonPassword(password string) bool {
+	lower := strings.ToLower(password)
+	if _, ok := commonPasswords[lower]; ok {
+		return true
+	}
+	base := strings.TrimRightFunc(lower, func(r rune) bool { return !unicode.IsLetter(r) })
+	if utf8.RuneCountInString(base) < 4 {
+		return false
+	}
+	_, ok := commonPasswords[base]
+	return ok
+}
@@ -0,0 +1,15 @@
+// Package storage хранилища загруженных файлов. Сервисы работают только с интерфейсом
+// Storage, поэтому локальную файловую систему можно заменить S3-совместимым хранилищем.
+package storage
+
+import (
+	"context"
+	"io"
+)
+
+// Storage сохраняет и удаляет файлы по ключу вида "hotels/101/abc.jpg"
+type Storage interface {
+	// Save записывает содержимое и возвращает публичный URL файла
+	Save(ctx context.Context, key string, r io.Reader, contentType string) (string, error)
+	Delete(ctx context.Context, key string) error
+}
@@ -0,0 +1,23 @@
+CREATE EXTENSION IF NOT EXISTS btree_gist;
+
+CREATE TABLE bookings (
+    id SERIAL PRIMARY KEY,
+    room_id INTEGER NOT NULL REFERENCES rooms(id) ON DELETE CASCADE,
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    checkin DATE NOT NULL,
+    checkout DATE NOT NULL,
+    guests INTEGER NOT NULL DEFAULT 1,
+    total_price INTEGER NOT NULL DEFAULT 0,
+    status TEXT NOT NULL DEFAULT 'pending'
+        CHECK (status IN ('pending', 'confirmed', 'cancelled', 'completed')),
+    created_at TIMESTAMP DEFAULT now(),
+    updated_at TIMESTAMP DEFAULT now(),
+    CHECK (checkout > checkin),
+    -- Одна комната не может быть занята двумя активными бронями на пересекающиеся даты
+    CONSTRAINT bookings_no_overlap EXCLUDE USING gist (
+        room_id WITH =,
+        daterange(checkin, checkout, '[)') WITH &&
+    ) WHERE (status <> 'cancelled')
+);
+
+CREATE INDEX idx_bookings_user_id ON bookings(user_id);
@@ -0,0 +1,6 @@
+UPDATE users SET role = 'user'
+WHERE role IS NULL OR role NOT IN ('admin', 'moderator', 'hotel_owner', 'user');
+
+ALTER TABLE users ALTER COLUMN role SET NOT NULL;
+ALTER TABLE users
+    ADD CONSTRAINT users_role_check CHECK (role IN ('admin', 'moderator', 'hotel_owner', 'user'));
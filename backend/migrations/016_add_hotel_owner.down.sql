@@ -0,0 +1 @@
+ALTER TABLE hotels DROP COLUMN IF EXISTS owner_id;
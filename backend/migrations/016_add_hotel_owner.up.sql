@@ -0,0 +1,3 @@
+ALTER TABLE hotels ADD COLUMN owner_id INTEGER REFERENCES users(id) ON DELETE SET NULL;
+
+CREATE INDEX idx_hotels_owner_id ON hotels(owner_id);
@@ -0,0 +1,2 @@
+ALTER TABLE rooms DROP COLUMN IF EXISTS deleted_at;
+ALTER TABLE hotels DROP COLUMN IF EXISTS deleted_at;
@@ -0,0 +1,5 @@
+ALTER TABLE hotels ADD COLUMN deleted_at TIMESTAMP;
+ALTER TABLE rooms ADD COLUMN deleted_at TIMESTAMP;
+
+CREATE INDEX idx_hotels_not_deleted ON hotels(id) WHERE deleted_at IS NULL;
+CREATE INDEX idx_rooms_hotel_not_deleted ON rooms(hotel_id) WHERE deleted_at IS NULL;
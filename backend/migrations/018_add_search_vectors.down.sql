@@ -0,0 +1,10 @@
+DROP TRIGGER IF EXISTS rooms_search_vector_trg ON rooms;
+DROP FUNCTION IF EXISTS rooms_touch_hotel_search_vector();
+DROP TRIGGER IF EXISTS hotels_search_vector_trg ON hotels;
+DROP FUNCTION IF EXISTS hotels_search_vector_update();
+
+DROP INDEX IF EXISTS idx_hotels_search_vector;
+ALTER TABLE hotels DROP COLUMN IF EXISTS search_vector;
+
+DROP INDEX IF EXISTS idx_rooms_search_vector;
+ALTER TABLE rooms DROP COLUMN IF EXISTS search_vector;
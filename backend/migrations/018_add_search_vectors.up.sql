@@ -0,0 +1,50 @@
+-- Полнотекстовый поиск по отелям и комнатам (словарь russian)
+ALTER TABLE rooms
+    ADD COLUMN search_vector tsvector
+    GENERATED ALWAYS AS (to_tsvector('russian', coalesce(description, ''))) STORED;
+
+CREATE INDEX idx_rooms_search_vector ON rooms USING GIN (search_vector);
+
+-- Вектор отеля включает описания его комнат, поэтому поддерживается триггерами, а не generated-колонкой
+ALTER TABLE hotels ADD COLUMN search_vector tsvector;
+
+CREATE FUNCTION hotels_search_vector_update() RETURNS trigger AS $$
+BEGIN
+    NEW.search_vector :=
+        setweight(to_tsvector('russian', coalesce(NEW.name, '')), 'A') ||
+        setweight(to_tsvector('russian', coalesce(NEW.city, '') || ' ' || coalesce(NEW.address, '')), 'B') ||
+        setweight(to_tsvector('russian', coalesce(NEW.description, '')), 'C') ||
+        setweight(to_tsvector('russian', coalesce((
+            SELECT string_agg(r.description, ' ')
+            FROM rooms r
+            WHERE r.hotel_id = NEW.id AND r.deleted_at IS NULL
+        ), '')), 'D');
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER hotels_search_vector_trg
+    BEFORE INSERT OR UPDATE ON hotels
+    FOR EACH ROW EXECUTE FUNCTION hotels_search_vector_update();
+
+-- Изменение комнат пересчитывает вектор отеля: пустой UPDATE запускает триггер выше
+CREATE FUNCTION rooms_touch_hotel_search_vector() RETURNS trigger AS $$
+BEGIN
+    IF TG_OP = 'INSERT' THEN
+        UPDATE hotels SET search_vector = NULL WHERE id = NEW.hotel_id;
+    ELSIF TG_OP = 'DELETE' THEN
+        UPDATE hotels SET search_vector = NULL WHERE id = OLD.hotel_id;
+    ELSE
+        UPDATE hotels SET search_vector = NULL WHERE id IN (OLD.hotel_id, NEW.hotel_id);
+    END IF;
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER rooms_search_vector_trg
+    AFTER INSERT OR DELETE OR UPDATE OF description, hotel_id, deleted_at ON rooms
+    FOR EACH ROW EXECUTE FUNCTION rooms_touch_hotel_search_vector();
+
+UPDATE hotels SET search_vector = NULL;
+
+CREATE INDEX idx_hotels_search_vector ON hotels USING GIN (search_vector);
@@ -0,0 +1,8 @@
+DROP INDEX IF EXISTS idx_hotels_coordinates;
+
+ALTER TABLE hotels
+    DROP CONSTRAINT IF EXISTS hotels_coordinates_pair,
+    DROP CONSTRAINT IF EXISTS hotels_longitude_range,
+    DROP CONSTRAINT IF EXISTS hotels_latitude_range,
+    DROP COLUMN IF EXISTS longitude,
+    DROP COLUMN IF EXISTS latitude;
@@ -0,0 +1,10 @@
+ALTER TABLE hotels
+    ADD COLUMN latitude DOUBLE PRECISION,
+    ADD COLUMN longitude DOUBLE PRECISION,
+    ADD CONSTRAINT hotels_latitude_range CHECK (latitude BETWEEN -90 AND 90),
+    ADD CONSTRAINT hotels_longitude_range CHECK (longitude BETWEEN -180 AND 180),
+    ADD CONSTRAINT hotels_coordinates_pair CHECK ((latitude IS NULL) = (longitude IS NULL));
+
+-- Для поиска по радиусу и по области карты
+CREATE INDEX idx_hotels_coordinates ON hotels(latitude, longitude)
+    WHERE deleted_at IS NULL AND latitude IS NOT NULL;
@@ -0,0 +1,35 @@
+-- Справочник удобств; scope показывает, к чему удобство применимо
+CREATE TABLE amenities (
+    id SERIAL PRIMARY KEY,
+    code TEXT NOT NULL UNIQUE CHECK (code ~ '^[a-z0-9_]{1,32}$'),
+    name TEXT NOT NULL,
+    scope TEXT NOT NULL DEFAULT 'both' CHECK (scope IN ('hotel', 'room', 'both'))
+);
+
+CREATE TABLE hotel_amenities (
+    hotel_id INTEGER NOT NULL REFERENCES hotels(id) ON DELETE CASCADE,
+    amenity_id INTEGER NOT NULL REFERENCES amenities(id) ON DELETE CASCADE,
+    PRIMARY KEY (hotel_id, amenity_id)
+);
+
+CREATE TABLE room_amenities (
+    room_id INTEGER NOT NULL REFERENCES rooms(id) ON DELETE CASCADE,
+    amenity_id INTEGER NOT NULL REFERENCES amenities(id) ON DELETE CASCADE,
+    PRIMARY KEY (room_id, amenity_id)
+);
+
+CREATE INDEX idx_hotel_amenities_amenity ON hotel_amenities(amenity_id);
+CREATE INDEX idx_room_amenities_amenity ON room_amenities(amenity_id);
+
+-- Базовый набор; остальные коды фронтенда (в том числе camelCase) добавляет миграция 033
+INSERT INTO amenities (code, name, scope) VALUES
+    ('wifi', 'Бесплатный Wi-Fi', 'both'),
+    ('pool', 'Бассейн', 'hotel'),
+    ('parking', 'Бесплатная парковка', 'hotel'),
+    ('restaurant', 'Ресторан', 'hotel'),
+    ('ac', 'Кондиционер', 'both'),
+    ('tv', 'Телевизор', 'room'),
+    ('breakfast', 'Завтрак включен', 'both'),
+    ('gym', 'Тренажерный зал', 'hotel'),
+    ('spa', 'Спа', 'hotel'),
+    ('bar', 'Бар', 'hotel');
@@ -0,0 +1,26 @@
+-- Фотографии отелей и комнат; файлы лежат в хранилище, здесь только ключи и URL
+CREATE TABLE images (
+    id SERIAL PRIMARY KEY,
+    hotel_id INTEGER REFERENCES hotels(id) ON DELETE CASCADE,
+    room_id INTEGER REFERENCES rooms(id) ON DELETE CASCADE,
+    storage_key TEXT NOT NULL,
+    thumbnail_key TEXT NOT NULL,
+    url TEXT NOT NULL,
+    thumbnail_url TEXT NOT NULL,
+    alt TEXT NOT NULL DEFAULT '',
+    content_type TEXT NOT NULL,
+    size_bytes INTEGER NOT NULL,
+    width INTEGER NOT NULL,
+    height INTEGER NOT NULL,
+    position INTEGER NOT NULL DEFAULT 0,
+    is_primary BOOLEAN NOT NULL DEFAULT FALSE,
+    created_at TIMESTAMP NOT NULL DEFAULT now(),
+    CONSTRAINT images_single_owner CHECK ((hotel_id IS NULL) <> (room_id IS NULL))
+);
+
+CREATE INDEX idx_images_hotel ON images(hotel_id, position) WHERE hotel_id IS NOT NULL;
+CREATE INDEX idx_images_room ON images(room_id, position) WHERE room_id IS NOT NULL;
+
+-- Не больше одной главной фотографии у отеля и у комнаты
+CREATE UNIQUE INDEX idx_images_hotel_primary ON images(hotel_id) WHERE is_primary AND hotel_id IS NOT NULL;
+CREATE UNIQUE INDEX idx_images_room_primary ON images(room_id) WHERE is_primary AND room_id IS NOT NULL;
@@ -0,0 +1,10 @@
+DROP INDEX IF EXISTS idx_reviews_room_approved;
+DROP INDEX IF EXISTS idx_reviews_pending;
+
+ALTER TABLE reviews ALTER COLUMN approved DROP NOT NULL;
+
+ALTER TABLE reviews
+    DROP COLUMN IF EXISTS moderated_at,
+    DROP COLUMN IF EXISTS moderated_by,
+    DROP COLUMN IF EXISTS rejection_reason,
+    DROP COLUMN IF EXISTS rejected_at;
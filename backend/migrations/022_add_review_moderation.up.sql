@@ -0,0 +1,16 @@
+-- Модерация отзывов: approved остается признаком публикации,
+-- отклонение фиксируется отдельно вместе с причиной
+ALTER TABLE reviews
+    ADD COLUMN rejected_at      TIMESTAMP,
+    ADD COLUMN rejection_reason TEXT,
+    ADD COLUMN moderated_by     INTEGER REFERENCES users(id) ON DELETE SET NULL,
+    ADD COLUMN moderated_at     TIMESTAMP;
+
+UPDATE reviews SET approved = FALSE WHERE approved IS NULL;
+ALTER TABLE reviews ALTER COLUMN approved SET NOT NULL;
+
+-- Очередь модерации: ожидающие решения отзывы
+CREATE INDEX idx_reviews_pending ON reviews (created_at)
+    WHERE approved = FALSE AND rejected_at IS NULL;
+
+CREATE INDEX idx_reviews_room_approved ON reviews (room_id) WHERE approved = TRUE;
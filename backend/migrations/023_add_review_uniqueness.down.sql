@@ -0,0 +1,14 @@
+DROP INDEX IF EXISTS idx_bookings_user_room_status;
+
+ALTER TABLE reviews DROP COLUMN IF EXISTS updated_at;
+
+DROP INDEX IF EXISTS uq_reviews_user_room;
+
+-- Возвращаем дубли, убранные up-миграцией; отзывы удаленных с тех пор пользователей
+-- и комнат вернуть нельзя из-за внешних ключей
+INSERT INTO reviews
+SELECT a.* FROM review_duplicates_archive a
+WHERE EXISTS (SELECT 1 FROM users u WHERE u.id = a.user_id)
+  AND EXISTS (SELECT 1 FROM rooms rm WHERE rm.id = a.room_id);
+
+DROP TABLE IF EXISTS review_duplicates_archive;
@@ -0,0 +1,26 @@
+-- Один отзыв от пользователя на комнату. Из существующих дублей остается опубликованный,
+-- при равенстве — не отклоненный, затем самый свежий. Остальные не удаляются безвозвратно,
+-- а переносятся в review_duplicates_archive; down-миграция возвращает их в reviews.
+CREATE TABLE review_duplicates_archive (LIKE reviews INCLUDING DEFAULTS);
+
+WITH ranked AS (
+    SELECT id, row_number() OVER (
+        PARTITION BY user_id, room_id
+        ORDER BY approved DESC, (rejected_at IS NULL) DESC, created_at DESC NULLS LAST, id DESC
+    ) AS rn
+    FROM reviews
+    WHERE user_id IS NOT NULL AND room_id IS NOT NULL
+), moved AS (
+    DELETE FROM reviews r
+    USING ranked
+    WHERE r.id = ranked.id AND ranked.rn > 1
+    RETURNING r.*
+)
+INSERT INTO review_duplicates_archive SELECT * FROM moved;
+
+CREATE UNIQUE INDEX uq_reviews_user_room ON reviews (user_id, room_id);
+
+ALTER TABLE reviews ADD COLUMN updated_at TIMESTAMP;
+
+-- Проверка проживания перед публикацией отзыва
+CREATE INDEX idx_bookings_user_room_status ON bookings (user_id, room_id, status);
@@ -0,0 +1,16 @@
+DROP INDEX IF EXISTS idx_hotels_average_rating;
+
+DROP TRIGGER IF EXISTS rooms_refresh_hotel_rating ON rooms;
+DROP FUNCTION IF EXISTS rooms_refresh_hotel_rating();
+DROP TRIGGER IF EXISTS reviews_refresh_ratings ON reviews;
+DROP FUNCTION IF EXISTS reviews_refresh_ratings();
+DROP FUNCTION IF EXISTS refresh_hotel_rating(INTEGER);
+DROP FUNCTION IF EXISTS refresh_room_rating(INTEGER);
+
+ALTER TABLE hotels
+    DROP COLUMN IF EXISTS review_count,
+    DROP COLUMN IF EXISTS average_rating;
+
+ALTER TABLE rooms
+    DROP COLUMN IF EXISTS review_count,
+    DROP COLUMN IF EXISTS average_rating;
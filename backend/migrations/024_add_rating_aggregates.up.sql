@@ -0,0 +1,86 @@
+-- Агрегированные рейтинги по одобренным отзывам: средняя оценка и число отзывов.
+-- rooms.rating остается округленной средней оценкой комнаты для старых клиентов.
+ALTER TABLE rooms
+    ADD COLUMN average_rating DOUBLE PRECISION NOT NULL DEFAULT 0,
+    ADD COLUMN review_count   INTEGER NOT NULL DEFAULT 0;
+
+ALTER TABLE hotels
+    ADD COLUMN average_rating DOUBLE PRECISION NOT NULL DEFAULT 0,
+    ADD COLUMN review_count   INTEGER NOT NULL DEFAULT 0;
+
+CREATE OR REPLACE FUNCTION refresh_room_rating(p_room_id INTEGER) RETURNS void AS $$
+    UPDATE rooms r
+    SET average_rating = COALESCE(s.avg_rating, 0),
+        review_count   = s.cnt,
+        rating         = ROUND(COALESCE(s.avg_rating, 0))::int
+    FROM (
+        SELECT AVG(room_rating)::float8 AS avg_rating, COUNT(*)::int AS cnt
+        FROM reviews
+        WHERE room_id = p_room_id AND approved
+    ) s
+    WHERE r.id = p_room_id;
+$$ LANGUAGE sql;
+
+-- Оценка отеля — средняя hotel_rating по отзывам на все его комнаты
+CREATE OR REPLACE FUNCTION refresh_hotel_rating(p_hotel_id INTEGER) RETURNS void AS $$
+    UPDATE hotels h
+    SET average_rating = COALESCE(s.avg_rating, 0),
+        review_count   = s.cnt
+    FROM (
+        SELECT AVG(rv.hotel_rating)::float8 AS avg_rating, COUNT(*)::int AS cnt
+        FROM reviews rv
+        JOIN rooms r ON r.id = rv.room_id
+        WHERE r.hotel_id = p_hotel_id AND rv.approved
+    ) s
+    WHERE h.id = p_hotel_id;
+$$ LANGUAGE sql;
+
+CREATE OR REPLACE FUNCTION reviews_refresh_ratings() RETURNS trigger AS $$
+DECLARE
+    v_hotel_id INTEGER;
+BEGIN
+    IF TG_OP IN ('UPDATE', 'DELETE') THEN
+        PERFORM refresh_room_rating(OLD.room_id);
+        SELECT hotel_id INTO v_hotel_id FROM rooms WHERE id = OLD.room_id;
+        IF v_hotel_id IS NOT NULL THEN
+            PERFORM refresh_hotel_rating(v_hotel_id);
+        END IF;
+    END IF;
+    IF TG_OP IN ('INSERT', 'UPDATE') THEN
+        IF TG_OP = 'INSERT' OR NEW.room_id IS DISTINCT FROM OLD.room_id THEN
+            PERFORM refresh_room_rating(NEW.room_id);
+            SELECT hotel_id INTO v_hotel_id FROM rooms WHERE id = NEW.room_id;
+            IF v_hotel_id IS NOT NULL THEN
+                PERFORM refresh_hotel_rating(v_hotel_id);
+            END IF;
+        END IF;
+    END IF;
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+-- Пересчет при создании, одобрении/отклонении, правке оценок и удалении отзыва
+CREATE TRIGGER reviews_refresh_ratings
+    AFTER INSERT OR DELETE OR UPDATE OF approved, room_rating, hotel_rating, room_id ON reviews
+    FOR EACH ROW EXECUTE FUNCTION reviews_refresh_ratings();
+
+-- Перенос комнаты в другой отель меняет рейтинги обоих отелей
+CREATE OR REPLACE FUNCTION rooms_refresh_hotel_rating() RETURNS trigger AS $$
+BEGIN
+    PERFORM refresh_hotel_rating(OLD.hotel_id);
+    PERFORM refresh_hotel_rating(NEW.hotel_id);
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER rooms_refresh_hotel_rating
+    AFTER UPDATE OF hotel_id ON rooms
+    FOR EACH ROW
+    WHEN (OLD.hotel_id IS DISTINCT FROM NEW.hotel_id)
+    EXECUTE FUNCTION rooms_refresh_hotel_rating();
+
+-- Заполнение для существующих данных
+SELECT refresh_room_rating(id) FROM rooms;
+SELECT refresh_hotel_rating(id) FROM hotels;
+
+CREATE INDEX idx_hotels_average_rating ON hotels (average_rating);
@@ -0,0 +1,9 @@
+-- Публичный ответ администрации отеля на отзыв: не больше одного на отзыв
+CREATE TABLE review_replies (
+    id SERIAL PRIMARY KEY,
+    review_id INTEGER NOT NULL UNIQUE REFERENCES reviews(id) ON DELETE CASCADE,
+    author_id INTEGER REFERENCES users(id) ON DELETE SET NULL,
+    body TEXT NOT NULL CHECK (length(btrim(body)) > 0),
+    created_at TIMESTAMP NOT NULL DEFAULT now(),
+    updated_at TIMESTAMP
+);
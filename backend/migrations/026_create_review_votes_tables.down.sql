@@ -0,0 +1,8 @@
+DROP INDEX IF EXISTS idx_reviews_room_helpful;
+
+ALTER TABLE reviews
+    DROP COLUMN IF EXISTS report_count,
+    DROP COLUMN IF EXISTS helpful_count;
+
+DROP TABLE IF EXISTS review_reports;
+DROP TABLE IF EXISTS review_helpful_votes;
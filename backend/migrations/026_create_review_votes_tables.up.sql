@@ -0,0 +1,24 @@
+-- Отметки «полезно» и жалобы на отзывы: не больше одной от пользователя на отзыв
+CREATE TABLE review_helpful_votes (
+    review_id INTEGER NOT NULL REFERENCES reviews(id) ON DELETE CASCADE,
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    created_at TIMESTAMP NOT NULL DEFAULT now(),
+    PRIMARY KEY (review_id, user_id)
+);
+
+CREATE TABLE review_reports (
+    id SERIAL PRIMARY KEY,
+    review_id INTEGER NOT NULL REFERENCES reviews(id) ON DELETE CASCADE,
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    reason TEXT,
+    created_at TIMESTAMP NOT NULL DEFAULT now(),
+    UNIQUE (review_id, user_id)
+);
+
+-- Счетчики хранятся в отзыве, чтобы сортировать без агрегации.
+-- report_count — жалобы с момента последнего одобрения модератором.
+ALTER TABLE reviews
+    ADD COLUMN helpful_count INTEGER NOT NULL DEFAULT 0,
+    ADD COLUMN report_count  INTEGER NOT NULL DEFAULT 0;
+
+CREATE INDEX idx_reviews_room_helpful ON reviews (room_id, helpful_count DESC) WHERE approved = TRUE;
@@ -0,0 +1,10 @@
+DROP INDEX IF EXISTS idx_user_friends_friend_id;
+DROP INDEX IF EXISTS uq_user_friends_pair;
+
+DELETE FROM user_friends WHERE status <> 'accepted';
+
+ALTER TABLE user_friends
+    DROP CONSTRAINT IF EXISTS user_friends_not_self,
+    DROP COLUMN IF EXISTS updated_at,
+    DROP COLUMN IF EXISTS created_at,
+    DROP COLUMN IF EXISTS status;
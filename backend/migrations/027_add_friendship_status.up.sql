@@ -0,0 +1,25 @@
+-- Дружба: строка направлена от отправителя заявки (user_id) к получателю (friend_id).
+-- Существующие связи считаются принятыми; встречные дубли схлопываются в одну строку.
+DELETE FROM user_friends a
+USING user_friends b
+WHERE a.user_id = b.friend_id
+  AND a.friend_id = b.user_id
+  AND a.user_id > b.user_id;
+
+DELETE FROM user_friends WHERE user_id = friend_id OR user_id IS NULL OR friend_id IS NULL;
+
+ALTER TABLE user_friends
+    ADD COLUMN status     TEXT NOT NULL DEFAULT 'accepted'
+        CHECK (status IN ('pending', 'accepted', 'blocked')),
+    ADD COLUMN created_at TIMESTAMP NOT NULL DEFAULT now(),
+    ADD COLUMN updated_at TIMESTAMP NOT NULL DEFAULT now(),
+    ADD CONSTRAINT user_friends_not_self CHECK (user_id <> friend_id);
+
+ALTER TABLE user_friends ALTER COLUMN status SET DEFAULT 'pending';
+
+-- Заявка или дружба между двумя пользователями — одна на пару независимо от направления.
+-- Блокировки направленные и хранятся отдельными строками (user_id блокирует friend_id).
+CREATE UNIQUE INDEX uq_user_friends_pair ON user_friends (LEAST(user_id, friend_id), GREATEST(user_id, friend_id))
+    WHERE status <> 'blocked';
+
+CREATE INDEX idx_user_friends_friend_id ON user_friends (friend_id, status);
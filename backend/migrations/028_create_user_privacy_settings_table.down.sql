@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS idx_reviews_user_created;
+DROP INDEX IF EXISTS idx_user_visited_rooms_user_created;
+DROP INDEX IF EXISTS idx_user_favorite_rooms_user_created;
+
+DROP TABLE IF EXISTS user_privacy_settings;
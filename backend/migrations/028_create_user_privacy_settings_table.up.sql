@@ -0,0 +1,13 @@
+-- Что пользователь показывает друзьям в ленте и рекомендациях. Нет строки — все открыто.
+CREATE TABLE user_privacy_settings (
+    user_id         INTEGER PRIMARY KEY REFERENCES users(id) ON DELETE CASCADE,
+    share_reviews   BOOLEAN NOT NULL DEFAULT TRUE,
+    share_favorites BOOLEAN NOT NULL DEFAULT TRUE,
+    share_stays     BOOLEAN NOT NULL DEFAULT TRUE,
+    updated_at      TIMESTAMP NOT NULL DEFAULT now()
+);
+
+-- Лента друзей: выборка событий по автору в порядке убывания времени
+CREATE INDEX idx_user_favorite_rooms_user_created ON user_favorite_rooms (user_id, created_at DESC);
+CREATE INDEX idx_user_visited_rooms_user_created ON user_visited_rooms (user_id, created_at DESC);
+CREATE INDEX idx_reviews_user_created ON reviews (user_id, created_at DESC) WHERE approved;
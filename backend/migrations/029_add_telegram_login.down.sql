@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS idx_networks_id_user;
+DROP INDEX IF EXISTS uq_networks_telegram_id;
+
+ALTER TABLE networks DROP COLUMN IF EXISTS telegram_id;
@@ -0,0 +1,5 @@
+-- Вход через Telegram: пользователь привязывается к числовому Telegram ID, username может меняться
+ALTER TABLE networks ADD COLUMN telegram_id BIGINT;
+
+CREATE UNIQUE INDEX uq_networks_telegram_id ON networks (telegram_id) WHERE telegram_id IS NOT NULL;
+CREATE INDEX idx_networks_id_user ON networks (id_user);
@@ -0,0 +1,25 @@
+-- Внешние учетные записи (OAuth2/OIDC): у пользователя может быть несколько, по одной на провайдера и аккаунт
+CREATE TABLE user_identities (
+    id             SERIAL PRIMARY KEY,
+    user_id        INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    provider       TEXT NOT NULL,
+    subject        TEXT NOT NULL,
+    email          TEXT,
+    email_verified BOOLEAN NOT NULL DEFAULT FALSE,
+    created_at     TIMESTAMP NOT NULL DEFAULT now(),
+    last_login_at  TIMESTAMP,
+    UNIQUE (provider, subject)
+);
+
+CREATE INDEX idx_user_identities_user_id ON user_identities (user_id);
+
+-- Незавершенные входы: state одноразовый, в БД хранится только его хеш
+CREATE TABLE oauth_states (
+    state_hash    TEXT PRIMARY KEY,
+    provider      TEXT NOT NULL,
+    code_verifier TEXT NOT NULL,
+    user_id       INTEGER REFERENCES users(id) ON DELETE CASCADE,
+    expires_at    TIMESTAMP NOT NULL
+);
+
+CREATE INDEX idx_oauth_states_expires_at ON oauth_states (expires_at);
@@ -0,0 +1,20 @@
+-- NULL — адрес не подтвержден
+ALTER TABLE users ADD COLUMN email_verified_at TIMESTAMP;
+
+-- Аккаунты, созданные до появления подтверждения, считаем подтвержденными.
+-- Служебные адреса входа через Telegram и OAuth (домен .invalid) подтвердить нельзя.
+UPDATE users SET email_verified_at = now() WHERE email NOT LIKE '%.invalid';
+
+-- Одноразовые токены из писем (подтверждение email, сброс пароля); в БД хранится только хеш
+CREATE TABLE account_tokens (
+    token_hash TEXT PRIMARY KEY,
+    user_id    INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    purpose    TEXT NOT NULL CHECK (purpose IN ('verify_email', 'reset_password')),
+    email      TEXT NOT NULL, -- адрес, на который ушло письмо; после смены email токен недействителен
+    created_at TIMESTAMP NOT NULL DEFAULT now(),
+    expires_at TIMESTAMP NOT NULL,
+    used_at    TIMESTAMP
+);
+
+CREATE INDEX idx_account_tokens_user_purpose ON account_tokens (user_id, purpose) WHERE used_at IS NULL;
+CREATE INDEX idx_account_tokens_expires_at ON account_tokens (expires_at);
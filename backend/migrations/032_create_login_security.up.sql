@@ -0,0 +1,25 @@
+-- Счетчики неудачных входов для защиты от перебора паролей (хранилище security.login_store = postgres)
+CREATE TABLE login_attempts (
+    key             TEXT PRIMARY KEY, -- account:<email> или ip:<адрес>
+    failures        INTEGER NOT NULL DEFAULT 0,
+    last_failure_at TIMESTAMPTZ NOT NULL,
+    locked_until    TIMESTAMPTZ
+);
+
+CREATE INDEX idx_login_attempts_last_failure_at ON login_attempts (last_failure_at);
+
+-- Журнал событий безопасности: блокировки входа и их снятие администратором
+CREATE TABLE security_events (
+    id           SERIAL PRIMARY KEY,
+    type         TEXT NOT NULL,
+    user_id      INTEGER REFERENCES users(id) ON DELETE SET NULL,
+    email        TEXT,
+    ip           TEXT,
+    failures     INTEGER,
+    locked_until TIMESTAMPTZ,
+    actor_id     INTEGER REFERENCES users(id) ON DELETE SET NULL, -- администратор, снявший блокировку
+    created_at   TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX idx_security_events_created_at ON security_events (created_at DESC);
+CREATE INDEX idx_security_events_type ON security_events (type, created_at DESC);
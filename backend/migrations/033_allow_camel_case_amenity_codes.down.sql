@@ -0,0 +1,5 @@
+DELETE FROM amenities WHERE code IN ('laundry', 'roomService', 'airportShuttle', 'businessCenter', 'petFriendly');
+
+-- NOT VALID: коды с заглавными буквами, добавленные через админку, остаются как есть
+ALTER TABLE amenities DROP CONSTRAINT amenities_code_check;
+ALTER TABLE amenities ADD CONSTRAINT amenities_code_check CHECK (code ~ '^[a-z0-9_]{1,32}$') NOT VALID;
@@ -0,0 +1,12 @@
+-- Коды удобств совпадают с типом Amenity фронтенда (features/hotels/types.ts),
+-- среди которых есть camelCase: регистр сохраняется как есть
+ALTER TABLE amenities DROP CONSTRAINT amenities_code_check;
+ALTER TABLE amenities ADD CONSTRAINT amenities_code_check CHECK (code ~ '^[a-zA-Z0-9_]{1,32}$');
+
+INSERT INTO amenities (code, name, scope) VALUES
+    ('laundry', 'Прачечная', 'hotel'),
+    ('roomService', 'Обслуживание номеров', 'hotel'),
+    ('airportShuttle', 'Трансфер из аэропорта', 'hotel'),
+    ('businessCenter', 'Бизнес-центр', 'hotel'),
+    ('petFriendly', 'Можно с животными', 'both')
+ON CONFLICT (code) DO NOTHING;
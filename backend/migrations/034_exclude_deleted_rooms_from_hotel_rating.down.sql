@@ -0,0 +1,30 @@
+DROP TRIGGER IF EXISTS rooms_refresh_hotel_rating ON rooms;
+
+CREATE OR REPLACE FUNCTION rooms_refresh_hotel_rating() RETURNS trigger AS $$
+BEGIN
+    PERFORM refresh_hotel_rating(OLD.hotel_id);
+    PERFORM refresh_hotel_rating(NEW.hotel_id);
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER rooms_refresh_hotel_rating
+    AFTER UPDATE OF hotel_id ON rooms
+    FOR EACH ROW
+    WHEN (OLD.hotel_id IS DISTINCT FROM NEW.hotel_id)
+    EXECUTE FUNCTION rooms_refresh_hotel_rating();
+
+CREATE OR REPLACE FUNCTION refresh_hotel_rating(p_hotel_id INTEGER) RETURNS void AS $$
+    UPDATE hotels h
+    SET average_rating = COALESCE(s.avg_rating, 0),
+        review_count   = s.cnt
+    FROM (
+        SELECT AVG(rv.hotel_rating)::float8 AS avg_rating, COUNT(*)::int AS cnt
+        FROM reviews rv
+        JOIN rooms r ON r.id = rv.room_id
+        WHERE r.hotel_id = p_hotel_id AND rv.approved
+    ) s
+    WHERE h.id = p_hotel_id;
+$$ LANGUAGE sql;
+
+SELECT refresh_hotel_rating(id) FROM hotels;
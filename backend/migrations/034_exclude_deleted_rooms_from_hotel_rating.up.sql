@@ -0,0 +1,35 @@
+-- Оценка отеля учитывает только отзывы на неудаленные комнаты
+CREATE OR REPLACE FUNCTION refresh_hotel_rating(p_hotel_id INTEGER) RETURNS void AS $$
+    UPDATE hotels h
+    SET average_rating = COALESCE(s.avg_rating, 0),
+        review_count   = s.cnt
+    FROM (
+        SELECT AVG(rv.hotel_rating)::float8 AS avg_rating, COUNT(*)::int AS cnt
+        FROM reviews rv
+        JOIN rooms r ON r.id = rv.room_id
+        WHERE r.hotel_id = p_hotel_id AND r.deleted_at IS NULL AND rv.approved
+    ) s
+    WHERE h.id = p_hotel_id;
+$$ LANGUAGE sql;
+
+-- Перенос комнаты в другой отель, ее удаление или восстановление меняют рейтинг отеля
+CREATE OR REPLACE FUNCTION rooms_refresh_hotel_rating() RETURNS trigger AS $$
+BEGIN
+    PERFORM refresh_hotel_rating(OLD.hotel_id);
+    IF NEW.hotel_id IS DISTINCT FROM OLD.hotel_id THEN
+        PERFORM refresh_hotel_rating(NEW.hotel_id);
+    END IF;
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS rooms_refresh_hotel_rating ON rooms;
+CREATE TRIGGER rooms_refresh_hotel_rating
+    AFTER UPDATE OF hotel_id, deleted_at ON rooms
+    FOR EACH ROW
+    WHEN (OLD.hotel_id IS DISTINCT FROM NEW.hotel_id OR OLD.deleted_at IS DISTINCT FROM NEW.deleted_at)
+    EXECUTE FUNCTION rooms_refresh_hotel_rating();
+
+-- Пересчет для отелей, у которых уже есть удаленные комнаты
+SELECT refresh_hotel_rating(id) FROM hotels
+WHERE id IN (SELECT hotel_id FROM rooms WHERE deleted_at IS NOT NULL);
@@ -3,6 +3,7 @@
  * - getHotels: список отелей с опциональными фильтрами
  * - getHotelById: отель по id
  * - getHotelRooms: номера для конкретного отеля
+ * - getHotelRatingSummary: средние оценки и распределение по одобренным отзывам
  * - createHotel: создание нового отеля (только для администратора)
  * Теги кеша: 'Hotels' (LIST), 'Hotel' (by id), 'Room' (LIST-<hotelId>)
  */
@@ -10,10 +11,18 @@
 
 import { baseApi } from '@/app/api/baseApi';
 import type { Hotel, Room } from '@/shared/types';
+import type { HotelSearchParams, HotelSearchResponse } from '@/features/hotels/types';
 
-export interface HotelsListParams {
+export interface HotelsListParams extends Omit<HotelSearchParams, 'search'> {
   q?: string;
-  city?: string;
+}
+
+export interface HotelRatingSummary {
+  hotel_id: number;
+  averageRating: number;
+  averageRoomRating: number;
+  reviewCount: number;
+  distribution: Record<'1' | '2' | '3' | '4' | '5', number>;
 }
 
 export interface CreateHotelRequest {
@@ -31,6 +40,7 @@ export const hotelsApi = baseApi.injectEndpoints({
   endpoints: (builder) => ({
     getHotels: builder.query<Hotel[], HotelsListParams | void>({
       query: (params) => (params ? { url: '/hotels', params } : '/hotels'),
+      transformResponse: (res: HotelSearchResponse<Hotel>) => res.data,
       providesTags: (result) =>
         result?.length
           ? [
@@ -61,6 +71,11 @@ export const hotelsApi = baseApi.injectEndpoints({
         { type: 'Room' as const, id: `LIST-${hotelId}` },
       ],
     }),
+
+    getHotelRatingSummary: builder.query<HotelRatingSummary, string>({
+      query: (hotelId) => `/hotels/${hotelId}/rating-summary`,
+      providesTags: (_result, _error, hotelId) => [{ type: 'Hotel' as const, id: hotelId }],
+    }),
   }),
 });
 
@@ -69,4 +84,5 @@ export const {
   useGetHotelByIdQuery,
   useCreateHotelMutation,
   useGetHotelRoomsQuery,
+  useGetHotelRatingSummaryQuery,
 } = hotelsApi;
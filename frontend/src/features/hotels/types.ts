@@ -73,8 +73,9 @@ export interface HotelSearchParams {
   sortOrder?: 'asc' | 'desc';
 }
 
-export interface HotelSearchResponse {
-  data: Hotel[];
+// T — модель отеля на стороне клиента: ответ /hotels используется и с Hotel из shared/types
+export interface HotelSearchResponse<T = Hotel> {
+  data: T[];
   pagination: {
     total: number;
     page: number;
@@ -37,6 +37,7 @@ export interface Hotel extends BaseEntity {
   pricePerNight: number;
   availableRooms: number;
   rating: number;
+  averageRating?: number;
   reviewCount: number;
   reviews?: Review[];
 }